@@ -0,0 +1,149 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"istio.io/istio/istioctl/pkg/analyze"
+	"istio.io/istio/pilot/pkg/config/kube/crd"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+var (
+	analyzeFilenames    []string
+	analyzeDomainSuffix string
+	analyzeOutputFormat string
+
+	analyzeCmd = &cobra.Command{
+		Use:   "analyze",
+		Short: "Find mistakes spanning several Istio config resources",
+		Long: `
+analyze loads Istio configs from files, or from the cluster, and reports
+mistakes that only show when resources are looked at together, such as
+routes to subsets no destination rule defines or virtual services bound to
+gateways that do not exist.
+
+The command fails when an error is found, so that it can gate CI pipelines.
+`,
+		Example: `
+# Analyze configs before applying them.
+istioctl experimental analyze -f virtual-services.yaml -f destination-rules.yaml
+
+# Analyze the configs of the cluster, in JSON.
+istioctl experimental analyze -o json`,
+		RunE: func(c *cobra.Command, _ []string) error {
+			if analyzeOutputFormat != "short" && analyzeOutputFormat != "json" {
+				return fmt.Errorf("unknown output format %q", analyzeOutputFormat)
+			}
+
+			configs, err := analyzeInputs()
+			if err != nil {
+				return err
+			}
+			for i := range configs {
+				if configs[i].Domain == "" {
+					configs[i].Domain = analyzeDomainSuffix
+				}
+			}
+
+			messages := analyze.Analyze(configs, analyze.Analyzers)
+			if analyzeOutputFormat == "json" {
+				if messages == nil {
+					messages = []analyze.Message{}
+				}
+				out, err := json.MarshalIndent(messages, "", "  ") // nolint: vetshadow
+				if err != nil {
+					return err
+				}
+				c.Println(string(out))
+			} else {
+				for _, m := range messages {
+					c.Println(m)
+				}
+				if len(messages) == 0 {
+					c.Printf("No issues found in %d configs.\n", len(configs))
+				}
+			}
+
+			if analyze.HasErrors(messages) {
+				return errors.New("errors found in the analyzed configs")
+			}
+			return nil
+		},
+	}
+)
+
+// analyzeInputs loads the configs of the files, or of the cluster when no
+// file is given.
+func analyzeInputs() ([]model.Config, error) {
+	if len(analyzeFilenames) == 0 {
+		configClient, err := newClient()
+		if err != nil {
+			return nil, err
+		}
+		var configs []model.Config
+		for _, typ := range configClient.ConfigDescriptor().Types() {
+			list, err := configClient.List(typ, namespace)
+			if err != nil {
+				return nil, err
+			}
+			configs = append(configs, list...)
+		}
+		return configs, nil
+	}
+
+	var configs []model.Config
+	for _, filename := range analyzeFilenames {
+		var input []byte
+		var err error
+		if filename == "-" {
+			input, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			input, err = ioutil.ReadFile(filename)
+		}
+		if err != nil {
+			return nil, err
+		}
+		list, _, err := crd.ParseInputs(string(input))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+		for i := range list {
+			if list[i].Namespace, err = handleNamespaces(list[i].Namespace); err != nil {
+				return nil, err
+			}
+		}
+		configs = append(configs, list...)
+	}
+	return configs, nil
+}
+
+func init() {
+	analyzeCmd.PersistentFlags().StringSliceVarP(&analyzeFilenames, "filename", "f", nil,
+		"Istio config files to analyze. If not set, the configs of the cluster are analyzed")
+	analyzeCmd.PersistentFlags().StringVar(&analyzeDomainSuffix, "domain", "cluster.local",
+		"DNS domain suffix used to resolve short host names")
+	analyzeCmd.PersistentFlags().StringVarP(&analyzeOutputFormat, "output", "o", "short",
+		"Output format. One of:json|short")
+
+	experimentalCmd.AddCommand(analyzeCmd)
+}
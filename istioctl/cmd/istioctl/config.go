@@ -32,7 +32,6 @@ import (
 var (
 
 	// TODO - Pull in remaining xDS information from pilot agent via curl and add to output
-	// TODO - Add config-diff to get the difference between pilot's xDS API response and the proxy config
 	// TODO - Add support for non-default proxy config locations
 	// TODO - Add support for non-kube istio deployments
 	configCmd = &cobra.Command{
@@ -43,7 +42,10 @@ Retrieves the local proxy configuration for the specified pod when running in Ku
 
 Available configuration types:
 
-	[clusters listeners routes static]
+	[clusters listeners routes static diff]
+
+The diff configuration type compares the clusters, listeners and routes
+Pilot would send to the proxy with the configuration the proxy is running.
 
 `,
 		Example: `# Retrieve all config for productpage-v1-bb8d5cbc7-k7qbm pod
@@ -53,7 +55,10 @@ istioctl proxy-config productpage-v1-bb8d5cbc7-k7qbm
 istioctl proxy-config productpage-v1-bb8d5cbc7-k7qbm clusters
 
 # Retrieve static config for productpage-v1-bb8d5cbc7-k7qbm pod in the application namespace
-istioctl proxy-config -n application productpage-v1-bb8d5cbc7-k7qbm static`,
+istioctl proxy-config -n application productpage-v1-bb8d5cbc7-k7qbm static
+
+# Check whether the productpage-v1-bb8d5cbc7-k7qbm proxy is in sync with Pilot
+istioctl proxy-config productpage-v1-bb8d5cbc7-k7qbm diff`,
 		Aliases: []string{"pc"},
 		Args:    cobra.MinimumNArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
@@ -70,6 +75,9 @@ istioctl proxy-config -n application productpage-v1-bb8d5cbc7-k7qbm static`,
 			if ns == v1.NamespaceAll {
 				ns = defaultNamespace
 			}
+			if configType == "diff" {
+				return diffProxyConfig(c.OutOrStdout(), podName, ns)
+			}
 			debug, err := callPilotAgentDebug(podName, ns, configType)
 			if err != nil {
 				return err
@@ -81,6 +89,8 @@ istioctl proxy-config -n application productpage-v1-bb8d5cbc7-k7qbm static`,
 )
 
 func init() {
+	configCmd.PersistentFlags().StringVar(&proxyDomainSuffix, "domain", "cluster.local",
+		"DNS domain suffix of the cluster, used by diff for proxies without a --domain argument")
 	rootCmd.AddCommand(configCmd)
 }
 
@@ -0,0 +1,134 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/istioctl/pkg/configdiff"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/serviceregistry/kube"
+)
+
+const (
+	pilotServiceName    = "istio-pilot"
+	pilotMonitoringPort = "http-monitoring"
+	proxyContainerName  = "istio-proxy"
+)
+
+var (
+	// proxyDomainSuffix is the DNS domain suffix of the cluster, which the
+	// domain of proxies defaults to.
+	proxyDomainSuffix string
+)
+
+// diffProxyConfig compares the config Pilot would send to the proxy of a pod
+// with the config dumped by the proxy, and writes the differences to w.
+func diffProxyConfig(w io.Writer, podName, podNamespace string) error {
+	_, client, err := kube.CreateInterface(kubeconfig)
+	if err != nil {
+		return err
+	}
+	pod, err := client.CoreV1().Pods(podNamespace).Get(podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	proxyID := proxyServiceNode(pod, proxyDomainSuffix)
+	pilotDump, err := client.CoreV1().RESTClient().Get().
+		Namespace(istioNamespace).
+		Resource("services").
+		Name(pilotServiceName+":"+pilotMonitoringPort).
+		SubResource("proxy").
+		Suffix("debug/config_dump").
+		Param("proxyID", proxyID).
+		DoRaw()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve Pilot config for %q: %v", proxyID, err)
+	}
+	intended, err := configdiff.ParsePilotDump(pilotDump)
+	if err != nil {
+		return err
+	}
+
+	envoyDump, err := callPilotAgentDebug(podName, podNamespace, "config_dump")
+	if err != nil {
+		return err
+	}
+	actual, err := configdiff.ParseEnvoyDump([]byte(envoyDump))
+	if err != nil {
+		return err
+	}
+
+	return writeProxyDiffs(w, podName, podNamespace, configdiff.Compare(intended, actual))
+}
+
+// writeProxyDiffs writes the differences found for the proxy of a pod to w,
+// and returns an error if there are any.
+func writeProxyDiffs(w io.Writer, podName, podNamespace string, diffs []configdiff.Diff) error {
+	if len(diffs) == 0 {
+		fmt.Fprintf(w, "Proxy %s/%s is in sync with Pilot\n", podNamespace, podName)
+		return nil
+	}
+	for _, diff := range diffs {
+		fmt.Fprintln(w, diff)
+	}
+	return fmt.Errorf("proxy %s/%s is out of sync with Pilot: %d differences", podNamespace, podName, len(diffs))
+}
+
+// proxyServiceNode returns the service node the proxy of a pod identifies
+// itself with, as set up by pilot-agent on Kubernetes. The domain of the
+// proxy is taken from its --domain argument, and defaults to the namespace
+// of the pod in the cluster domain.
+func proxyServiceNode(pod *v1.Pod, domainSuffix string) string {
+	node := model.Proxy{
+		Type:      model.Sidecar,
+		IPAddress: pod.Status.PodIP,
+		ID:        pod.Name + "." + pod.Namespace,
+		Domain:    pod.Namespace + ".svc." + domainSuffix,
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name != proxyContainerName {
+			continue
+		}
+		// the proxy type is the argument of the pilot-agent proxy command
+		if len(c.Args) > 1 && c.Args[0] == "proxy" {
+			node.Type = model.NodeType(c.Args[1])
+		}
+		if domain := flagValue(c.Args, "domain"); domain != "" {
+			node.Domain = domain
+		}
+	}
+	return node.ServiceNode()
+}
+
+// flagValue returns the value of a flag in command line arguments, given
+// either as --name value or --name=value.
+func flagValue(args []string, name string) string {
+	for i, arg := range args {
+		switch {
+		case arg == "--"+name && i+1 < len(args):
+			return args[i+1]
+		case strings.HasPrefix(arg, "--"+name+"="):
+			return strings.TrimPrefix(arg, "--"+name+"=")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"istio.io/istio/istioctl/pkg/configdiff"
+)
+
+func TestWriteProxyDiffs(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeProxyDiffs(&out, "productpage", "default", nil); err != nil {
+		t.Errorf("unexpected error for proxy in sync: %v", err)
+	}
+	if want := "Proxy default/productpage is in sync with Pilot\n"; out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	diffs := []configdiff.Diff{
+		{Type: "cluster", Name: "outbound|9080||reviews", Status: configdiff.Missing},
+		{Type: "listener", Name: "0.0.0.0_9080", Status: configdiff.Changed, Fields: []string{"filter_chains"}},
+	}
+	if err := writeProxyDiffs(&out, "productpage", "default", diffs); err == nil {
+		t.Error("expected error for proxy out of sync")
+	}
+	want := `cluster "outbound|9080||reviews" is missing` + "\n" +
+		`listener "0.0.0.0_9080" is changed: filter_chains` + "\n"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
@@ -15,14 +15,17 @@
 package convert
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/spf13/cobra"
+	kubeyaml "k8s.io/apimachinery/pkg/util/yaml"
 
 	"istio.io/istio/istioctl/pkg/convert"
 	"istio.io/istio/pilot/pkg/config/kube/crd"
@@ -31,8 +34,9 @@ import (
 )
 
 var (
-	inFilenames []string
-	outFilename string
+	inFilenames    []string
+	outFilename    string
+	reportFilename string
 )
 
 // Command for converting v1alpha1 configs to v1alpha3
@@ -47,10 +51,15 @@ func Command() *cobra.Command {
 			"or in certain edge cases. " +
 			"The input must be the set of configs that would be in place in an environment at a given " +
 			"time. " +
-			"This allows the command to attempt to create and merge output configs intelligently." +
-			"Output configs are given the namespace and domain of the first input config " +
-			"so it is recommended that input configs be part of the same namespace and domain.",
-		Example: "istioctl experimental convert-networking-config -f v1alpha1/default-route.yaml -f v1alpha1/header-delay.yaml",
+			"This allows the command to attempt to create and merge output configs intelligently. " +
+			"Whole cluster dumps, such as the output of 'kubectl get routerules --all-namespaces -o yaml', " +
+			"are accepted: resources other than v1alpha1 networking configs are skipped, and v1alpha3 " +
+			"configs are kept as is. " +
+			"Output configs are created in the namespace of the destination of the input configs. " +
+			"The fields that cannot be represented in v1alpha3 are listed in a report.",
+		Example: "istioctl experimental convert-networking-config -f v1alpha1/default-route.yaml -f v1alpha1/header-delay.yaml\n" +
+			"kubectl get routerules,destinationpolicies,egressrules --all-namespaces -o yaml | " +
+			"istioctl experimental convert-networking-config -f - --report report.txt",
 		RunE: func(c *cobra.Command, args []string) error {
 			if len(inFilenames) == 0 {
 				return fmt.Errorf("no input files provided")
@@ -90,7 +99,22 @@ func Command() *cobra.Command {
 				writer = file
 			}
 
-			return convertConfigs(readers, writer)
+			reportWriter := os.Stderr
+			if reportFilename != "" {
+				file, err := os.Create(reportFilename)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if err := file.Close(); err != nil {
+						log.Errorf("Did not close report successfully: %v", err)
+					}
+				}()
+
+				reportWriter = file
+			}
+
+			return convertConfigs(readers, writer, reportWriter)
 		},
 	}
 
@@ -98,11 +122,13 @@ func Command() *cobra.Command {
 		nil, "Input filename")
 	cmd.PersistentFlags().StringVarP(&outFilename, "output", "o",
 		"-", "Output filename")
+	cmd.PersistentFlags().StringVar(&reportFilename, "report",
+		"", "Filename of the report of the fields that could not be converted, standard error if not set")
 
 	return cmd
 }
 
-func convertConfigs(readers []io.Reader, writer io.Writer) error {
+func convertConfigs(readers []io.Reader, writer io.Writer, reportWriter io.Writer) error {
 	configDescriptor := model.ConfigDescriptor{
 		model.RouteRule,
 		model.VirtualService,
@@ -119,7 +145,8 @@ func convertConfigs(readers []io.Reader, writer io.Writer) error {
 		model.EndUserAuthenticationPolicySpecBinding,
 	}
 
-	configs, err := readConfigs(readers)
+	report := &convert.Report{}
+	configs, err := readConfigs(readers, report)
 	if err != nil {
 		return err
 	}
@@ -128,14 +155,40 @@ func convertConfigs(readers []io.Reader, writer io.Writer) error {
 		return err
 	}
 
+	// v1alpha3 configs of the input are kept, and take precedence over the
+	// configs converted to the same resource
 	out := make([]model.Config, 0)
-	out = append(out, convert.DestinationPolicies(configs)...)
-	out = append(out, convert.RouteRules(configs)...)
-	out = append(out, convert.EgressRules(configs)...)
+	existing := make(map[string]model.Config)
+	for _, config := range configs {
+		switch config.Type {
+		case model.VirtualService.Type, model.Gateway.Type, model.ExternalService.Type, model.DestinationRule.Type:
+			out = append(out, config)
+			existing[config.Key()] = config
+		case model.RouteRule.Type, model.DestinationPolicy.Type, model.EgressRule.Type:
+			// converted below
+		default:
+			report.Add(config.ConfigMeta, "", "not a networking config, skipped")
+		}
+	}
+
+	converted := make([]model.Config, 0)
+	converted = append(converted, convert.DestinationPolicies(configs, report)...)
+	converted = append(converted, convert.RouteRules(configs, report)...)
+	converted = append(converted, convert.EgressRules(configs, report)...)
 	// TODO: k8s ingress -> gateway?
 	// TODO: create missing destination rules/subsets?
+	for _, config := range converted {
+		if _, exists := existing[config.Key()]; exists {
+			report.Add(config.ConfigMeta, "", "already in the input, the converted config is dropped")
+			continue
+		}
+		out = append(out, config)
+	}
 
 	writeYAMLOutput(configDescriptor, out, writer)
+	if err := report.Write(reportWriter); err != nil {
+		return err
+	}
 
 	// sanity check that the outputs are valid
 	if err := validateConfigs(out); err != nil {
@@ -144,7 +197,7 @@ func convertConfigs(readers []io.Reader, writer io.Writer) error {
 	return nil
 }
 
-func readConfigs(readers []io.Reader) ([]model.Config, error) {
+func readConfigs(readers []io.Reader, report *convert.Report) ([]model.Config, error) {
 	out := make([]model.Config, 0)
 	for _, reader := range readers {
 		data, err := ioutil.ReadAll(reader)
@@ -152,12 +205,21 @@ func readConfigs(readers []io.Reader) ([]model.Config, error) {
 			return nil, err
 		}
 
-		configs, kinds, err := crd.ParseInputs(string(data))
+		input, err := expandLists(data)
 		if err != nil {
 			return nil, err
 		}
-		if len(kinds) != 0 {
-			return nil, fmt.Errorf("unsupported kinds: %v", kinds)
+
+		configs, kinds, err := crd.ParseInputs(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, kind := range kinds {
+			report.Add(model.ConfigMeta{
+				Type:      kind.Kind,
+				Name:      kind.Name,
+				Namespace: kind.Namespace,
+			}, "", "not an Istio config, skipped")
 		}
 
 		out = append(out, configs...)
@@ -165,6 +227,40 @@ func readConfigs(readers []io.Reader) ([]model.Config, error) {
 	return out, nil
 }
 
+// expandLists replaces the lists of a YAML or JSON stream, as dumped by
+// kubectl, with their items.
+func expandLists(data []byte) (string, error) {
+	var out bytes.Buffer
+	decoder := kubeyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 512*1024)
+	for {
+		obj := make(map[string]interface{})
+		err := decoder.Decode(&obj)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("cannot parse input: %v", err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+
+		items := []interface{}{obj}
+		if kind, _ := obj["kind"].(string); strings.HasSuffix(kind, "List") {
+			items, _ = obj["items"].([]interface{})
+		}
+		for _, item := range items {
+			doc, err := yaml.Marshal(item)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString("---\n")
+			out.Write(doc)
+		}
+	}
+	return out.String(), nil
+}
+
 func writeYAMLOutput(descriptor model.ConfigDescriptor, configs []model.Config, writer io.Writer) {
 	for i, config := range configs {
 		schema, exists := descriptor.GetByType(config.Type)
@@ -15,6 +15,7 @@
 package convert
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"testing"
@@ -24,8 +25,9 @@ import (
 
 func TestCommand(t *testing.T) {
 	tt := []struct {
-		in  []string
-		out string
+		in     []string
+		out    string
+		report string
 	}{
 		{in: []string{"rule-default-route.yaml"},
 			out: "rule-default-route.yaml"},
@@ -73,6 +75,10 @@ func TestCommand(t *testing.T) {
 
 		{in: []string{"egress-rule-wildcard-httpbin.yaml"},
 			out: "egress-rule-wildcard-httpbin.yaml"},
+
+		{in: []string{"cluster-dump.yaml"},
+			out:    "cluster-dump.yaml",
+			report: "cluster-dump.report"},
 	}
 
 	for _, tc := range tt {
@@ -94,11 +100,15 @@ func TestCommand(t *testing.T) {
 			}
 			defer out.Close() // nolint: errcheck
 
-			if err := convertConfigs(readers, out); err != nil {
+			var report bytes.Buffer
+			if err := convertConfigs(readers, out, &report); err != nil {
 				t.Fatalf("Unexpected error converting configs: %v", err)
 			}
 
 			util.CompareYAML(outFilename, t)
+			if tc.report != "" {
+				util.CompareContent(report.Bytes(), "testdata/v1alpha3/"+tc.report+".golden", t)
+			}
 		})
 	}
 }
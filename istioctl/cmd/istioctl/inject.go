@@ -61,31 +61,41 @@ func getMeshConfigFromConfigMap(kubeconfig string) (*meshconfig.MeshConfig, erro
 }
 
 func getInjectConfigFromConfigMap(kubeconfig string) (string, error) {
-	_, client, err := kube.CreateInterface(kubeconfig)
+	injectConfig, err := getInjectConfig(kubeconfig, injectConfigMapName)
 	if err != nil {
 		return "", err
 	}
-	config, err := client.CoreV1().ConfigMaps(istioNamespace).Get(injectConfigMapName, metav1.GetOptions{})
+	return injectConfig.Template, nil
+}
+
+// getInjectConfig reads the sidecar injection config, including the
+// injection policy, from the named configmap in the Istio namespace.
+func getInjectConfig(kubeconfig, configMapName string) (*inject.Config, error) {
+	_, client, err := kube.CreateInterface(kubeconfig)
 	if err != nil {
-		return "", fmt.Errorf("could not find valid configmap %q from namespace  %q: %v - "+
+		return nil, err
+	}
+	config, err := client.CoreV1().ConfigMaps(istioNamespace).Get(configMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not find valid configmap %q from namespace  %q: %v - "+
 			"Use --injectConfigFile or re-run kube-inject with `-i <istioSystemNamespace> and ensure istio-inject configmap exists",
-			injectConfigMapName, istioNamespace, err)
+			configMapName, istioNamespace, err)
 	}
 	// values in the data are strings, while proto might use a
 	// different data type.  therefore, we have to get a value by a
 	// key
 	injectData, exists := config.Data[injectConfigMapKey]
 	if !exists {
-		return "", fmt.Errorf("missing configuration map key %q in %q",
-			injectConfigMapKey, injectConfigMapName)
+		return nil, fmt.Errorf("missing configuration map key %q in %q",
+			injectConfigMapKey, configMapName)
 	}
 	var injectConfig inject.Config
 	if err := yaml.Unmarshal([]byte(injectData), &injectConfig); err != nil {
-		return "", fmt.Errorf("unable to convert data from configmap %q: %v",
-			injectConfigMapName, err)
+		return nil, fmt.Errorf("unable to convert data from configmap %q: %v",
+			configMapName, err)
 	}
-	log.Debugf("using inject template from configmap %q", injectConfigMapName)
-	return injectConfig.Template, nil
+	log.Debugf("using inject template from configmap %q", configMapName)
+	return &injectConfig, nil
 }
 
 var (
@@ -101,6 +111,7 @@ var (
 	includeInboundPorts string
 	excludeInboundPorts string
 	debugMode           bool
+	statusPort          int
 	emitTemplate        bool
 
 	inFilename          string
@@ -254,6 +265,7 @@ istioctl kube-inject -f deployment.yaml -o deployment-injected.yaml --injectConf
 					IncludeInboundPorts: includeInboundPorts,
 					ExcludeInboundPorts: excludeInboundPorts,
 					DebugMode:           debugMode,
+					StatusPort:          statusPort,
 				})
 			}
 
@@ -321,6 +333,9 @@ func init() {
 		"Comma separated list of inbound ports for which traffic should not be redirected to Envoy. "+
 			"Only applies if redirecting all inbound traffic by default.")
 	injectCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Use debug images and settings for the sidecar")
+	injectCmd.PersistentFlags().IntVar(&statusPort, "statusPort", inject.DefaultStatusPort,
+		"Port on which the sidecar serves its readiness probe, which fails until Envoy has received its "+
+			"configuration. The probe is disabled if 0.")
 
 	injectCmd.PersistentFlags().StringVar(&meshConfigMapName, "meshConfigMapName", "istio",
 		fmt.Sprintf("ConfigMap name for Istio mesh configuration, key should be %q", configMapKey))
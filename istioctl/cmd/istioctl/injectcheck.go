@@ -0,0 +1,201 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pilot/pkg/kube/inject"
+	"istio.io/istio/pilot/pkg/serviceregistry/kube"
+)
+
+const (
+	// injectionNamespaceLabel is the namespace label selecting namespaces
+	// for automatic sidecar injection.
+	injectionNamespaceLabel = "istio-injection"
+
+	defaultInjectorConfigMapName = "istio-sidecar-injector"
+)
+
+var (
+	checkFilename            string
+	checkInjectConfigMapName string
+	checkOutputFormat        string
+
+	injectCheckCmd = &cobra.Command{
+		Use:   "inject-check",
+		Short: "Explain whether the sidecar injector would inject pods and what it would change",
+		Long: `
+inject-check reads the live sidecar injector configuration and mesh
+configuration and reports, for each pod template in a file or for each
+running pod, whether the sidecar would be injected and why, along with
+the JSON patch the injector would apply.
+
+Running pods are also checked for having been injected with a stale
+sidecar template.
+`,
+		Example: `
+# Check the pod templates of a deployment before applying it.
+istioctl experimental inject-check -f deployment.yaml
+
+# Check the running pods of a namespace for stale injection.
+istioctl experimental inject-check -n default`,
+		RunE: func(c *cobra.Command, _ []string) error {
+			if checkOutputFormat != "short" && checkOutputFormat != "json" {
+				return fmt.Errorf("unknown output format %q", checkOutputFormat)
+			}
+
+			meshConfig, err := getMeshConfigFromConfigMap(kubeconfig)
+			if err != nil {
+				return err
+			}
+			injectConfig, err := getInjectConfig(kubeconfig, checkInjectConfigMapName)
+			if err != nil {
+				return err
+			}
+
+			var results []*inject.CheckResult
+			if checkFilename != "" {
+				var in io.Reader = os.Stdin
+				if checkFilename != "-" {
+					f, err := os.Open(checkFilename) // nolint: vetshadow
+					if err != nil {
+						return err
+					}
+					defer f.Close() // nolint: errcheck
+					in = f
+				}
+				if results, err = inject.CheckResourceFile(injectConfig, meshConfig, in); err != nil {
+					return err
+				}
+			} else {
+				_, client, err := kube.CreateInterface(kubeconfig) // nolint: vetshadow
+				if err != nil {
+					return err
+				}
+				ns := namespace
+				if ns == "" {
+					ns = defaultNamespace
+				}
+				pods, err := client.CoreV1().Pods(ns).List(metav1.ListOptions{})
+				if err != nil {
+					return err
+				}
+				// the webhook is only invoked for pods in labeled namespaces
+				labeled := make(map[string]bool)
+				for i := range pods.Items {
+					pod := &pods.Items[i]
+					if _, checked := labeled[pod.Namespace]; !checked {
+						podNamespace, err := client.CoreV1().Namespaces().Get(pod.Namespace, metav1.GetOptions{})
+						if err != nil {
+							return err
+						}
+						labeled[pod.Namespace] = podNamespace.Labels[injectionNamespaceLabel] == "enabled"
+					}
+					result, err := inject.CheckPod(injectConfig, meshConfig, pod)
+					if err != nil {
+						return err
+					}
+					if !labeled[pod.Namespace] && result.Inject {
+						result.Inject = false
+						result.Patch = nil
+						result.Reason = fmt.Sprintf("namespace %q is not labeled %s=enabled",
+							pod.Namespace, injectionNamespaceLabel)
+					}
+					results = append(results, result)
+				}
+			}
+
+			if checkOutputFormat == "json" {
+				out, err := json.MarshalIndent(results, "", "  ")
+				if err != nil {
+					return err
+				}
+				c.Println(string(out))
+				return nil
+			}
+			printCheckResults(c.OutOrStdout(), results)
+			return nil
+		},
+	}
+)
+
+func printCheckResults(w io.Writer, results []*inject.CheckResult) {
+	for _, result := range results {
+		decision := "skip"
+		if result.Inject {
+			decision = "inject"
+		}
+		name := result.Name
+		if result.Namespace != "" {
+			name = result.Namespace + "/" + result.Name
+		}
+		fmt.Fprintf(w, "%s %s: %s (%s)\n", result.Kind, name, decision, result.Reason)
+		if result.Stale {
+			fmt.Fprintf(w, "  stale: injected with sidecar template %.8s\n", result.InjectedVersion)
+		}
+		for _, op := range result.Patch {
+			fmt.Fprintf(w, "  %s %s%s\n", patchSymbol(op.Op), op.Path, patchValueName(op.Value))
+		}
+	}
+}
+
+// patchSymbol renders a JSON patch operation as a diff marker.
+func patchSymbol(op string) string {
+	switch op {
+	case "add":
+		return "+"
+	case "remove":
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// patchValueName summarizes a patch value by the name of the added
+// container or volume, or by the value itself for annotations.
+func patchValueName(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case map[string]interface{}:
+		if name, ok := v["name"]; ok {
+			return fmt.Sprintf(" (%v)", name)
+		}
+		return ""
+	case string:
+		return fmt.Sprintf(" = %q", v)
+	default:
+		return ""
+	}
+}
+
+func init() {
+	injectCheckCmd.PersistentFlags().StringVarP(&checkFilename, "filename", "f", "",
+		"Kubernetes resource file to check. If not set, the running pods are checked")
+	injectCheckCmd.PersistentFlags().StringVar(&checkInjectConfigMapName, "injectConfigMapName",
+		defaultInjectorConfigMapName, fmt.Sprintf("ConfigMap name for Istio sidecar injection, key should be %q",
+			injectConfigMapKey))
+	injectCheckCmd.PersistentFlags().StringVarP(&checkOutputFormat, "output", "o", "short",
+		"Output format. One of:json|short")
+
+	experimentalCmd.AddCommand(injectCheckCmd)
+}
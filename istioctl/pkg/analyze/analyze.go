@@ -0,0 +1,260 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package analyze finds mistakes spanning several Istio config resources,
+// which the validation of each resource in isolation cannot detect.
+package analyze
+
+import (
+	"fmt"
+	"sort"
+
+	networking "istio.io/api/networking/v1alpha3"
+	routing "istio.io/api/routing/v1alpha1"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// Severity of a message.
+type Severity string
+
+const (
+	// Error messages are for configs that do not behave as written.
+	Error Severity = "Error"
+	// Warning messages are for configs that are likely mistakes.
+	Warning Severity = "Warning"
+)
+
+// Message is a finding of an analyzer about a resource.
+type Message struct {
+	Analyzer string   `json:"analyzer"`
+	Severity Severity `json:"severity"`
+	// Resource is the path of the resource, as type/namespace/name.
+	Resource string `json:"resource"`
+	Message  string `json:"message"`
+}
+
+func (m Message) String() string {
+	return fmt.Sprintf("%s [%s] %s: %s", m.Severity, m.Analyzer, m.Resource, m.Message)
+}
+
+// Analyzer checks a set of configs for mistakes.
+type Analyzer interface {
+	// Name identifies the analyzer in its messages.
+	Name() string
+	// Analyze returns the messages about the configs.
+	Analyze(configs []model.Config) []Message
+}
+
+// analyzerFunc adapts a function to the Analyzer interface.
+type analyzerFunc struct {
+	name    string
+	analyze func(configs []model.Config) []Message
+}
+
+func (a analyzerFunc) Name() string {
+	return a.name
+}
+
+func (a analyzerFunc) Analyze(configs []model.Config) []Message {
+	messages := a.analyze(configs)
+	for i := range messages {
+		messages[i].Analyzer = a.name
+	}
+	return messages
+}
+
+// Analyzers are the analyzers run by default.
+var Analyzers = []Analyzer{
+	analyzerFunc{"virtualservice-subsets", virtualServiceSubsets},
+	analyzerFunc{"virtualservice-gateways", virtualServiceGateways},
+	analyzerFunc{"destinationrule-shadowing", destinationRuleShadowing},
+	analyzerFunc{"routerule-leftovers", routeRuleLeftovers},
+}
+
+// Analyze runs the analyzers over the configs and returns their messages,
+// ordered by resource.
+func Analyze(configs []model.Config, analyzers []Analyzer) []Message {
+	var messages []Message
+	for _, analyzer := range analyzers {
+		messages = append(messages, analyzer.Analyze(configs)...)
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Resource < messages[j].Resource
+	})
+	return messages
+}
+
+// HasErrors returns whether any message is an error.
+func HasErrors(messages []Message) bool {
+	for _, m := range messages {
+		if m.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourcePath returns the path of a config in messages.
+func ResourcePath(meta model.ConfigMeta) string {
+	return fmt.Sprintf("%s/%s/%s", meta.Type, meta.Namespace, meta.Name)
+}
+
+func newMessage(severity Severity, config model.Config, format string, args ...interface{}) Message {
+	return Message{
+		Severity: severity,
+		Resource: ResourcePath(config.ConfigMeta),
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func configsOfType(configs []model.Config, typ string) []model.Config {
+	var out []model.Config
+	for _, config := range configs {
+		if config.Type == typ {
+			out = append(out, config)
+		}
+	}
+	return out
+}
+
+// resolveHost returns the FQDN of a host of a config, as Pilot does.
+func resolveHost(config model.Config, host string) string {
+	return model.ResolveFQDN(host, config.Namespace+".svc."+config.Domain)
+}
+
+// virtualServiceDestinations returns the destinations of the HTTP, mirror
+// and TCP routes of a virtual service.
+func virtualServiceDestinations(vs *networking.VirtualService) []*networking.Destination {
+	var out []*networking.Destination
+	for _, http := range vs.Http {
+		for _, dst := range http.Route {
+			out = append(out, dst.Destination)
+		}
+		if http.Mirror != nil {
+			out = append(out, http.Mirror)
+		}
+	}
+	for _, tcp := range vs.Tcp {
+		for _, dst := range tcp.Route {
+			out = append(out, dst.Destination)
+		}
+	}
+	return out
+}
+
+// virtualServiceSubsets reports routes to subsets that no destination rule
+// defines for the destination host. Such routes fail with 503s.
+func virtualServiceSubsets(configs []model.Config) []Message {
+	subsets := make(map[string]map[string]bool)
+	for _, config := range configsOfType(configs, model.DestinationRule.Type) {
+		rule := config.Spec.(*networking.DestinationRule)
+		host := resolveHost(config, rule.Name)
+		if subsets[host] == nil {
+			subsets[host] = make(map[string]bool)
+		}
+		for _, subset := range rule.Subsets {
+			subsets[host][subset.Name] = true
+		}
+	}
+
+	var messages []Message
+	for _, config := range configsOfType(configs, model.VirtualService.Type) {
+		vs := config.Spec.(*networking.VirtualService)
+		for _, dst := range virtualServiceDestinations(vs) {
+			if dst == nil || dst.Subset == "" {
+				continue
+			}
+			host := resolveHost(config, dst.Name)
+			if _, exists := subsets[host]; !exists {
+				messages = append(messages, newMessage(Error, config,
+					"subset %q of host %q is not defined: no destination rule for the host", dst.Subset, host))
+			} else if !subsets[host][dst.Subset] {
+				messages = append(messages, newMessage(Error, config,
+					"subset %q of host %q is not defined by its destination rule", dst.Subset, host))
+			}
+		}
+	}
+	return messages
+}
+
+// virtualServiceGateways reports virtual services bound to gateways that do
+// not exist, which leaves them without effect on those gateways.
+func virtualServiceGateways(configs []model.Config) []Message {
+	gateways := make(map[string]bool)
+	for _, config := range configsOfType(configs, model.Gateway.Type) {
+		gateways[config.Name] = true
+	}
+
+	var messages []Message
+	for _, config := range configsOfType(configs, model.VirtualService.Type) {
+		vs := config.Spec.(*networking.VirtualService)
+		for _, gateway := range vs.Gateways {
+			if gateway != model.IstioMeshGateway && !gateways[gateway] {
+				messages = append(messages, newMessage(Error, config, "gateway %q is not defined", gateway))
+			}
+		}
+	}
+	return messages
+}
+
+// destinationRuleShadowing reports destination rules for the same host, of
+// which only one takes effect.
+func destinationRuleShadowing(configs []model.Config) []Message {
+	rules := configsOfType(configs, model.DestinationRule.Type)
+	sort.Slice(rules, func(i, j int) bool {
+		return ResourcePath(rules[i].ConfigMeta) < ResourcePath(rules[j].ConfigMeta)
+	})
+
+	first := make(map[string]model.Config)
+	var messages []Message
+	for _, config := range rules {
+		host := resolveHost(config, config.Spec.(*networking.DestinationRule).Name)
+		if shadowing, exists := first[host]; exists {
+			messages = append(messages, newMessage(Warning, config,
+				"destination rule for host %q conflicts with %s, only one of them takes effect",
+				host, ResourcePath(shadowing.ConfigMeta)))
+			continue
+		}
+		first[host] = config
+	}
+	return messages
+}
+
+// routeRuleLeftovers reports route rules for hosts that virtual services now
+// route. Proxies using the v1alpha3 config ignore the route rules.
+func routeRuleLeftovers(configs []model.Config) []Message {
+	routed := make(map[string]model.Config)
+	for _, config := range configsOfType(configs, model.VirtualService.Type) {
+		for _, host := range config.Spec.(*networking.VirtualService).Hosts {
+			if _, exists := routed[resolveHost(config, host)]; !exists {
+				routed[resolveHost(config, host)] = config
+			}
+		}
+	}
+
+	var messages []Message
+	for _, config := range configsOfType(configs, model.RouteRule.Type) {
+		rule := config.Spec.(*routing.RouteRule)
+		if rule.Destination == nil {
+			continue
+		}
+		host := model.ResolveHostname(config.ConfigMeta, rule.Destination)
+		if vs, exists := routed[host]; exists {
+			messages = append(messages, newMessage(Warning, config,
+				"route rule for host %q is left over next to virtual service %s",
+				host, ResourcePath(vs.ConfigMeta)))
+		}
+	}
+	return messages
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+
+	networking "istio.io/api/networking/v1alpha3"
+	routing "istio.io/api/routing/v1alpha1"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+func config(typ, name string, spec proto.Message) model.Config {
+	return model.Config{
+		ConfigMeta: model.ConfigMeta{Type: typ, Name: name, Namespace: "default", Domain: "cluster.local"},
+		Spec:       spec,
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	configs := []model.Config{
+		config(model.DestinationRule.Type, "reviews", &networking.DestinationRule{
+			Name:    "reviews",
+			Subsets: []*networking.Subset{{Name: "v1"}},
+		}),
+		config(model.DestinationRule.Type, "reviews-shadow", &networking.DestinationRule{
+			Name: "reviews.default.svc.cluster.local",
+		}),
+		config(model.Gateway.Type, "bookinfo-gateway", &networking.Gateway{}),
+		config(model.VirtualService.Type, "reviews", &networking.VirtualService{
+			Hosts:    []string{"reviews"},
+			Gateways: []string{model.IstioMeshGateway, "bookinfo-gateway"},
+			Http: []*networking.HTTPRoute{{
+				Route: []*networking.DestinationWeight{
+					{Destination: &networking.Destination{Name: "reviews", Subset: "v1"}},
+				},
+			}},
+		}),
+		config(model.VirtualService.Type, "ratings", &networking.VirtualService{
+			Hosts:    []string{"ratings"},
+			Gateways: []string{"missing-gateway"},
+			Http: []*networking.HTTPRoute{{
+				Route: []*networking.DestinationWeight{
+					{Destination: &networking.Destination{Name: "reviews", Subset: "v2"}},
+				},
+				Mirror: &networking.Destination{Name: "ratings", Subset: "v1"},
+			}},
+		}),
+		config(model.RouteRule.Type, "ratings-default", &routing.RouteRule{
+			Destination: &routing.IstioService{Name: "ratings"},
+		}),
+		config(model.RouteRule.Type, "details-default", &routing.RouteRule{
+			Destination: &routing.IstioService{Name: "details"},
+		}),
+	}
+
+	messages := Analyze(configs, Analyzers)
+	got := make(map[string][]string)
+	for _, m := range messages {
+		got[m.Analyzer] = append(got[m.Analyzer], m.Resource)
+	}
+	want := map[string][]string{
+		// undefined subset v2 and no destination rule for ratings
+		"virtualservice-subsets":    {"virtual-service/default/ratings", "virtual-service/default/ratings"},
+		"virtualservice-gateways":   {"virtual-service/default/ratings"},
+		"destinationrule-shadowing": {"destination-rule/default/reviews-shadow"},
+		"routerule-leftovers":       {"route-rule/default/ratings-default"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got messages %v, want %v", messages, want)
+	}
+	if !HasErrors(messages) {
+		t.Errorf("expected errors")
+	}
+
+	for i := 1; i < len(messages); i++ {
+		if messages[i-1].Resource > messages[i].Resource {
+			t.Errorf("messages are not ordered by resource: %v", messages)
+		}
+	}
+}
+
+func TestAnalyzeClean(t *testing.T) {
+	configs := []model.Config{
+		config(model.DestinationRule.Type, "reviews", &networking.DestinationRule{
+			Name:    "reviews",
+			Subsets: []*networking.Subset{{Name: "v1"}},
+		}),
+		config(model.VirtualService.Type, "reviews", &networking.VirtualService{
+			Hosts: []string{"reviews"},
+			Http: []*networking.HTTPRoute{{
+				Route: []*networking.DestinationWeight{
+					{Destination: &networking.Destination{Name: "reviews", Subset: "v1"}},
+				},
+			}},
+		}),
+	}
+	if messages := Analyze(configs, Analyzers); len(messages) != 0 {
+		t.Errorf("expected no messages, got %v", messages)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configdiff compares the proxy config Pilot intends to send with the
+// config an Envoy proxy is actually running.
+package configdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Resource types compared by the diff.
+const (
+	Cluster  = "cluster"
+	Listener = "listener"
+	Route    = "route"
+)
+
+const httpConnectionManager = "envoy.http_connection_manager"
+
+// Resources indexes proxy config resources by type and name. Each resource
+// is the generic JSON decoding of the Envoy proto with its original field
+// names.
+type Resources map[string]map[string]interface{}
+
+func (r Resources) add(typ, name string, value interface{}) {
+	if r[typ] == nil {
+		r[typ] = make(map[string]interface{})
+	}
+	r[typ][name] = value
+}
+
+// Status of a resource in the diff.
+type Status string
+
+const (
+	// Missing resources are intended by Pilot but absent from the proxy.
+	Missing Status = "missing"
+	// Unexpected resources are present in the proxy but not intended by Pilot.
+	Unexpected Status = "unexpected"
+	// Changed resources differ between Pilot and the proxy.
+	Changed Status = "changed"
+)
+
+// Diff is a difference for a single resource.
+type Diff struct {
+	Type   string
+	Name   string
+	Status Status
+	// Fields are the paths of the fields that differ for changed resources.
+	Fields []string
+}
+
+func (d Diff) String() string {
+	if d.Status != Changed {
+		return fmt.Sprintf("%s %q is %s", d.Type, d.Name, d.Status)
+	}
+	return fmt.Sprintf("%s %q is %s: %s", d.Type, d.Name, d.Status, strings.Join(d.Fields, ", "))
+}
+
+// ParsePilotDump parses the Pilot /debug/config_dump output.
+func ParsePilotDump(data []byte) (Resources, error) {
+	var dump struct {
+		Clusters  []map[string]interface{} `json:"clusters"`
+		Listeners []map[string]interface{} `json:"listeners"`
+	}
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return nil, fmt.Errorf("cannot parse Pilot config dump: %v", err)
+	}
+	out := Resources{}
+	for _, cluster := range dump.Clusters {
+		out.addCluster(cluster)
+	}
+	for _, listener := range dump.Listeners {
+		out.addListener(listener)
+	}
+	return out, nil
+}
+
+// ParseEnvoyDump parses the Envoy admin /config_dump output. Only dynamic
+// resources are kept since static resources come from the bootstrap config
+// rather than Pilot.
+func ParseEnvoyDump(data []byte) (Resources, error) {
+	var dump struct {
+		Configs json.RawMessage `json:"configs"`
+	}
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return nil, fmt.Errorf("cannot parse Envoy config dump: %v", err)
+	}
+
+	// Older Envoy versions key the config dumps by name, newer versions
+	// list them.
+	var configs []map[string]interface{}
+	var keyed map[string]map[string]interface{}
+	if err := json.Unmarshal(dump.Configs, &keyed); err == nil {
+		for _, config := range keyed {
+			configs = append(configs, config)
+		}
+	} else if err := json.Unmarshal(dump.Configs, &configs); err != nil {
+		return nil, fmt.Errorf("cannot parse Envoy config dump: %v", err)
+	}
+
+	out := Resources{}
+	for _, config := range configs {
+		for _, item := range list(config["dynamic_active_clusters"]) {
+			if cluster, ok := field(item, "cluster").(map[string]interface{}); ok {
+				out.addCluster(cluster)
+			}
+		}
+		for _, item := range list(config["dynamic_active_listeners"]) {
+			if listener, ok := field(item, "listener").(map[string]interface{}); ok {
+				out.addListener(listener)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (r Resources) addCluster(cluster map[string]interface{}) {
+	name, _ := cluster["name"].(string)
+	r.add(Cluster, name, cluster)
+}
+
+// addListener indexes the listener and the route configs inlined in its HTTP
+// connection managers.
+func (r Resources) addListener(listener map[string]interface{}) {
+	name, _ := listener["name"].(string)
+	r.add(Listener, name, listener)
+
+	for _, chain := range list(listener["filter_chains"]) {
+		for _, filter := range list(field(chain, "filters")) {
+			if field(filter, "name") != httpConnectionManager {
+				continue
+			}
+			route, ok := field(field(filter, "config"), "route_config").(map[string]interface{})
+			if !ok {
+				continue
+			}
+			routeName, _ := route["name"].(string)
+			if routeName == "" {
+				routeName = name
+			}
+			r.add(Route, routeName, route)
+		}
+	}
+}
+
+// Compare returns the differences between the intended and the actual
+// resources, sorted by type and name.
+func Compare(intended, actual Resources) []Diff {
+	var out []Diff
+	for _, typ := range []string{Cluster, Listener, Route} {
+		names := make(map[string]bool)
+		for name := range intended[typ] {
+			names[name] = true
+		}
+		for name := range actual[typ] {
+			names[name] = true
+		}
+		sorted := make([]string, 0, len(names))
+		for name := range names {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+
+		for _, name := range sorted {
+			want, wantExists := intended[typ][name]
+			got, gotExists := actual[typ][name]
+			switch {
+			case !gotExists:
+				out = append(out, Diff{Type: typ, Name: name, Status: Missing})
+			case !wantExists:
+				out = append(out, Diff{Type: typ, Name: name, Status: Unexpected})
+			default:
+				if fields := compareValues("", want, got); len(fields) > 0 {
+					out = append(out, Diff{Type: typ, Name: name, Status: Changed, Fields: fields})
+				}
+			}
+		}
+	}
+	return out
+}
+
+// compareValues returns the paths at which the JSON values differ.
+func compareValues(path string, want, got interface{}) []string {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return []string{pathOrRoot(path)}
+		}
+		keys := make(map[string]bool)
+		for k := range w {
+			keys[k] = true
+		}
+		for k := range g {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		var out []string
+		for _, k := range sorted {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			out = append(out, compareValues(child, w[k], g[k])...)
+		}
+		return out
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return []string{pathOrRoot(path)}
+		}
+		var out []string
+		for i := range w {
+			out = append(out, compareValues(fmt.Sprintf("%s[%d]", path, i), w[i], g[i])...)
+		}
+		return out
+	default:
+		if !reflect.DeepEqual(want, got) {
+			return []string{pathOrRoot(path)}
+		}
+		return nil
+	}
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func list(value interface{}) []interface{} {
+	out, _ := value.([]interface{})
+	return out
+}
+
+func field(value interface{}, name string) interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return m[name]
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configdiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+const pilotDump = `{
+  "clusters": [
+    {"name": "outbound|80||a.default.svc.cluster.local", "connect_timeout": "1s"},
+    {"name": "outbound|80||b.default.svc.cluster.local", "connect_timeout": "1s"}
+  ],
+  "listeners": [
+    {
+      "name": "0.0.0.0_80",
+      "filter_chains": [{"filters": [{
+        "name": "envoy.http_connection_manager",
+        "config": {"route_config": {"name": "80", "virtual_hosts": [{"name": "a", "domains": ["a"]}]}}
+      }]}]
+    }
+  ]
+}`
+
+const envoyDump = `{
+  "configs": {
+    "clusters": {
+      "static_clusters": [{"name": "xds-grpc"}],
+      "dynamic_active_clusters": [
+        {"version_info": "1", "cluster": {"name": "outbound|80||a.default.svc.cluster.local", "connect_timeout": "5s"}},
+        {"version_info": "1", "cluster": {"name": "outbound|80||c.default.svc.cluster.local", "connect_timeout": "1s"}}
+      ]
+    },
+    "listeners": {
+      "dynamic_active_listeners": [{"version_info": "1", "listener": {
+        "name": "0.0.0.0_80",
+        "filter_chains": [{"filters": [{
+          "name": "envoy.http_connection_manager",
+          "config": {"route_config": {"name": "80", "virtual_hosts": [{"name": "a", "domains": ["a", "a:80"]}]}}
+        }]}]
+      }}]
+    }
+  }
+}`
+
+func TestCompare(t *testing.T) {
+	intended, err := ParsePilotDump([]byte(pilotDump))
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := ParseEnvoyDump([]byte(envoyDump))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := Compare(intended, actual)
+	want := []Diff{
+		{Type: Cluster, Name: "outbound|80||a.default.svc.cluster.local", Status: Changed, Fields: []string{"connect_timeout"}},
+		{Type: Cluster, Name: "outbound|80||b.default.svc.cluster.local", Status: Missing},
+		{Type: Cluster, Name: "outbound|80||c.default.svc.cluster.local", Status: Unexpected},
+		{Type: Listener, Name: "0.0.0.0_80", Status: Changed,
+			Fields: []string{"filter_chains[0].filters[0].config.route_config.virtual_hosts[0].domains"}},
+		{Type: Route, Name: "80", Status: Changed, Fields: []string{"virtual_hosts[0].domains"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() =\n%v\nwant\n%v", got, want)
+	}
+
+	if diffs := Compare(intended, intended); len(diffs) != 0 {
+		t.Errorf("expected no differences comparing a config with itself, got %v", diffs)
+	}
+}
+
+func TestParseEnvoyDumpList(t *testing.T) {
+	actual, err := ParseEnvoyDump([]byte(`{"configs": [
+	  {"@type": "type.googleapis.com/envoy.admin.v2alpha.ClustersConfigDump",
+	   "dynamic_active_clusters": [{"cluster": {"name": "a"}}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := actual[Cluster]["a"]; !exists {
+		t.Errorf("expected cluster a, got %v", actual)
+	}
+}
@@ -19,60 +19,69 @@ import (
 	"istio.io/api/routing/v1alpha1"
 
 	"istio.io/istio/pilot/pkg/model"
-	"istio.io/istio/pkg/log"
 )
 
-// DestinationPolicies converts v1alpha1 destination policies to v1alpha3 destination rules
-func DestinationPolicies(configs []model.Config) []model.Config {
-	policies := make([]*v1alpha1.DestinationPolicy, 0)
-	for _, config := range configs {
-		if config.Type == model.DestinationPolicy.Type {
-			policies = append(policies, config.Spec.(*v1alpha1.DestinationPolicy))
+// DestinationPolicies converts v1alpha1 destination policies to v1alpha3
+// destination rules. The policies for a destination, across all namespaces,
+// are merged into the destination rule of the destination namespace: the
+// policy without labels becomes its traffic policy and the others its subsets.
+func DestinationPolicies(configs []model.Config, report *Report) []model.Config {
+	destinationRules := make(map[string]*model.Config) // FQDN -> destination rule
+	subsets := make(map[string]model.Config)           // FQDN and subset -> converted policy
+	for _, config := range configsOfType(configs, model.DestinationPolicy.Type) {
+		policy := config.Spec.(*v1alpha1.DestinationPolicy)
+		w := fieldReporter{report: report, config: config.ConfigMeta}
+
+		fqdn := model.ResolveHostname(config.ConfigMeta, policy.Destination)
+		namespace := destinationNamespace(config.ConfigMeta, policy.Destination)
+		destinationRule := destinationRules[fqdn]
+		if destinationRule == nil {
+			host := convertHost(config.ConfigMeta, policy.Destination, namespace)
+			destinationRule = &model.Config{
+				ConfigMeta: model.ConfigMeta{
+					Type:      model.DestinationRule.Type,
+					Name:      host,
+					Namespace: namespace,
+					Domain:    config.Domain,
+				},
+				Spec: &v1alpha3.DestinationRule{Name: host},
+			}
+			destinationRules[fqdn] = destinationRule
 		}
-	}
 
-	destinationRules := make(map[string]*v1alpha3.DestinationRule) // host -> destination rule
-	for _, policy := range policies {
-		host := convertIstioService(policy.Destination)
-		if destinationRules[host] == nil {
-			destinationRules[host] = &v1alpha3.DestinationRule{Name: host}
+		subset := convertDestinationPolicy(policy, w)
+		key := fqdn + "/" + subset.Name
+		if previous, exists := subsets[key]; exists {
+			w.warn("destination.labels", "policy for labels %v already converted from %s, dropped",
+				model.Labels(policy.Destination.Labels), resourceName(previous.ConfigMeta))
+			continue
 		}
-		destinationRule := destinationRules[host]
-		destinationRule.Subsets = append(destinationRule.Subsets, convertDestinationPolicy(policy))
-	}
+		subsets[key] = config
 
-	// rewrite traffic policies where len(subsets) == 1
-	for _, rule := range destinationRules {
-		if len(rule.Subsets) == 1 {
-			rule.TrafficPolicy = rule.Subsets[0].TrafficPolicy
-			rule.Subsets = nil
+		rule := destinationRule.Spec.(*v1alpha3.DestinationRule)
+		if len(policy.Destination.Labels) == 0 {
+			rule.TrafficPolicy = subset.TrafficPolicy
+		} else {
+			rule.Subsets = append(rule.Subsets, subset)
 		}
 	}
 
-	out := make([]model.Config, 0)
-	for host, rule := range destinationRules {
-		out = append(out, model.Config{
-			ConfigMeta: model.ConfigMeta{
-				Type:      model.VirtualService.Type,
-				Name:      host,
-				Namespace: configs[0].Namespace,
-				Domain:    configs[0].Domain,
-			},
-			Spec: rule,
-		})
+	out := make([]model.Config, 0, len(destinationRules))
+	for _, rule := range destinationRules {
+		out = append(out, *rule)
 	}
-
+	sortConfigs(out)
 	return out
 }
 
-func convertLoadBalancing(in *v1alpha1.LoadBalancing) *v1alpha3.LoadBalancerSettings {
+func convertLoadBalancing(in *v1alpha1.LoadBalancing, w fieldReporter) *v1alpha3.LoadBalancerSettings {
 	if in == nil {
 		return nil
 	}
 
 	switch v := in.LbPolicy.(type) {
 	case *v1alpha1.LoadBalancing_Custom:
-		log.Warnf("Custom load balancing not supported")
+		w.warn("loadBalancing.custom", "custom load balancing not supported, dropped")
 	case *v1alpha1.LoadBalancing_Name:
 		simple := &v1alpha3.LoadBalancerSettings_Simple{}
 		switch v.Name {
@@ -90,7 +99,7 @@ func convertLoadBalancing(in *v1alpha1.LoadBalancing) *v1alpha3.LoadBalancerSett
 	return nil
 }
 
-func convertDestinationPolicy(in *v1alpha1.DestinationPolicy) *v1alpha3.Subset {
+func convertDestinationPolicy(in *v1alpha1.DestinationPolicy, w fieldReporter) *v1alpha3.Subset {
 	if in == nil {
 		return nil
 	}
@@ -99,18 +108,17 @@ func convertDestinationPolicy(in *v1alpha1.DestinationPolicy) *v1alpha3.Subset {
 		Name:   labelsToSubsetName(in.Destination.Labels),
 		Labels: in.Destination.Labels,
 		TrafficPolicy: &v1alpha3.TrafficPolicy{
-			LoadBalancer: convertLoadBalancing(in.LoadBalancing),
+			LoadBalancer: convertLoadBalancing(in.LoadBalancing, w),
 		},
 	}
 
-	// TODO: in.Source
 	if in.Source != nil {
-		log.Warnf("Destination policy source not supported")
+		w.warn("source", "source restrictions not supported, the policy applies to all sources")
 	}
 
 	if in.CircuitBreaker != nil {
 		if in.CircuitBreaker.GetCustom() != nil {
-			log.Warnf("Custom circuit breaker policy not supported")
+			w.warn("circuitBreaker.custom", "custom circuit breaker policy not supported, dropped")
 		}
 
 		if cb := in.CircuitBreaker.GetSimpleCb(); cb != nil {
@@ -139,7 +147,7 @@ func convertDestinationPolicy(in *v1alpha1.DestinationPolicy) *v1alpha3.Subset {
 	}
 
 	if in.Custom != nil {
-		log.Warn("Custom destination policy not supported")
+		w.warn("custom", "custom destination policy not supported, dropped")
 	}
 
 	return out
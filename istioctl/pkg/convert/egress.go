@@ -20,21 +20,16 @@ import (
 	"istio.io/api/networking/v1alpha3"
 	"istio.io/api/routing/v1alpha1"
 	"istio.io/istio/pilot/pkg/model"
-	"istio.io/istio/pkg/log"
 )
 
-// EgressRules converts v1alpha1 egress rules to v1alpha3 external services
-func EgressRules(configs []model.Config) []model.Config {
-	egressRules := make([]*v1alpha1.EgressRule, 0)
-	for _, config := range configs {
-		if config.Type == model.EgressRule.Type {
-			egressRules = append(egressRules, config.Spec.(*v1alpha1.EgressRule))
-		}
-	}
-
-	externalServices := make([]*v1alpha3.ExternalService, 0)
-	for _, egressRule := range egressRules {
-		host := convertIstioService(egressRule.Destination)
+// EgressRules converts v1alpha1 egress rules to v1alpha3 external services,
+// in the namespace of each egress rule
+func EgressRules(configs []model.Config, report *Report) []model.Config {
+	out := make([]model.Config, 0)
+	for _, config := range configsOfType(configs, model.EgressRule.Type) {
+		egressRule := config.Spec.(*v1alpha1.EgressRule)
+		w := fieldReporter{report: report, config: config.ConfigMeta}
+		host := convertHost(config.ConfigMeta, egressRule.Destination, config.Namespace)
 
 		ports := make([]*v1alpha3.Port, 0)
 		for _, egressPort := range egressRule.Ports {
@@ -46,28 +41,24 @@ func EgressRules(configs []model.Config) []model.Config {
 		}
 
 		if egressRule.UseEgressProxy {
-			log.Warnf("Use egress proxy field not supported")
+			w.warn("useEgressProxy", "egress proxy not supported, traffic leaves from the sidecars")
 		}
 
-		externalServices = append(externalServices, &v1alpha3.ExternalService{
-			Hosts:     []string{host},
-			Ports:     ports,
-			Discovery: v1alpha3.ExternalService_NONE,
-		})
-	}
-
-	out := make([]model.Config, 0)
-	for _, externalService := range externalServices {
 		out = append(out, model.Config{
 			ConfigMeta: model.ConfigMeta{
 				Type:      model.ExternalService.Type,
-				Name:      externalService.Hosts[0],
-				Namespace: configs[0].Namespace,
-				Domain:    configs[0].Domain,
+				Name:      host,
+				Namespace: config.Namespace,
+				Domain:    config.Domain,
+			},
+			Spec: &v1alpha3.ExternalService{
+				Hosts:     []string{host},
+				Ports:     ports,
+				Discovery: v1alpha3.ExternalService_NONE,
 			},
-			Spec: externalService,
 		})
 	}
 
+	sortConfigs(out)
 	return out
 }
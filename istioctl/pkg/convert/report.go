@@ -0,0 +1,87 @@
+// Copyright 2018 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// Report lists the parts of the input configs that the conversion dropped or
+// changed, because the v1alpha3 API cannot represent them.
+type Report struct {
+	Entries []ReportEntry
+}
+
+// ReportEntry is a field of an input config that was not converted as is.
+type ReportEntry struct {
+	// Resource is the input config, as kind namespace/name.
+	Resource string
+	// Field is the path of the field in the spec of the input config, empty
+	// when the whole config is concerned.
+	Field   string
+	Message string
+}
+
+func (e ReportEntry) String() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", e.Resource, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Resource, e.Field, e.Message)
+}
+
+// Add records that a field of a config was not converted as is.
+func (r *Report) Add(config model.ConfigMeta, field, format string, args ...interface{}) {
+	r.Entries = append(r.Entries, ReportEntry{
+		Resource: resourceName(config),
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Write writes the entries, ordered by resource, one per line.
+func (r *Report) Write(w io.Writer) error {
+	entries := append([]ReportEntry(nil), r.Entries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Resource < entries[j].Resource
+	})
+	for _, entry := range entries {
+		if _, err := fmt.Fprintln(w, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceName(config model.ConfigMeta) string {
+	namespace := config.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	return fmt.Sprintf("%s %s/%s", config.Type, namespace, config.Name)
+}
+
+// fieldReporter reports the fields of a single input config.
+type fieldReporter struct {
+	report *Report
+	config model.ConfigMeta
+}
+
+func (f fieldReporter) warn(field, format string, args ...interface{}) {
+	f.report.Add(f.config, field, format, args...)
+}
@@ -15,6 +15,7 @@
 package convert
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/gogo/protobuf/types"
@@ -23,79 +24,95 @@ import (
 	"istio.io/api/networking/v1alpha3"
 	"istio.io/api/routing/v1alpha1"
 	"istio.io/istio/pilot/pkg/model"
-	"istio.io/istio/pkg/log"
 )
 
-// RouteRules converts v1alpha1 route rules to v1alpha3 virtual services
-func RouteRules(configs []model.Config) []model.Config {
-	ruleConfigs := make([]model.Config, 0)
-	for _, config := range configs {
-		if config.Type == model.RouteRule.Type {
-			ruleConfigs = append(ruleConfigs, config)
-		}
-	}
-
+// RouteRules converts v1alpha1 route rules to v1alpha3 virtual services.
+// The rules for a destination, across all namespaces, are merged by
+// precedence into the virtual service of the destination namespace.
+func RouteRules(configs []model.Config, report *Report) []model.Config {
+	ruleConfigs := configsOfType(configs, model.RouteRule.Type)
 	model.SortRouteRules(ruleConfigs)
 
-	routeRules := make([]*v1alpha1.RouteRule, 0)
-	for _, ruleConfig := range ruleConfigs {
-		routeRules = append(routeRules, ruleConfig.Spec.(*v1alpha1.RouteRule))
-	}
-
-	virtualServices := make(map[string]*v1alpha3.VirtualService) // host -> virtual service
-	for _, routeRule := range routeRules {
-		host := convertIstioService(routeRule.Destination)
-		if virtualServices[host] == nil {
-			virtualServices[host] = &v1alpha3.VirtualService{
-				Hosts: []string{host},
+	virtualServices := make(map[string]*model.Config) // FQDN -> virtual service
+	merged := make(map[string]model.Config)           // FQDN -> last merged rule
+	catchAll := make(map[string]model.Config)         // FQDN -> first rule matching all requests
+	for _, config := range ruleConfigs {
+		routeRule := config.Spec.(*v1alpha1.RouteRule)
+		w := fieldReporter{report: report, config: config.ConfigMeta}
+
+		fqdn := model.ResolveHostname(config.ConfigMeta, routeRule.Destination)
+		namespace := destinationNamespace(config.ConfigMeta, routeRule.Destination)
+		virtualService := virtualServices[fqdn]
+		if virtualService == nil {
+			host := convertHost(config.ConfigMeta, routeRule.Destination, namespace)
+			virtualService = &model.Config{
+				ConfigMeta: model.ConfigMeta{
+					Type:      model.VirtualService.Type,
+					Name:      host,
+					Namespace: namespace,
+					Domain:    config.Domain,
+				},
+				Spec: &v1alpha3.VirtualService{
+					Hosts: []string{host},
+				},
 			}
+			virtualServices[fqdn] = virtualService
+		} else if previous := merged[fqdn]; previous.Spec.(*v1alpha1.RouteRule).Precedence == routeRule.Precedence {
+			w.warn("precedence", "same precedence %d as %s, the rules are ordered by namespace and name",
+				routeRule.Precedence, resourceName(previous.ConfigMeta))
 		}
-		rule := virtualServices[host]
 
-		rule.Http = append(rule.Http, convertRouteRule(routeRule))
-	}
+		if first, exists := catchAll[fqdn]; exists {
+			w.warn("", "unreachable, %s has a higher precedence and matches all requests",
+				resourceName(first.ConfigMeta))
+		} else if matchesAll(routeRule.Match) {
+			catchAll[fqdn] = config
+		}
 
-	out := make([]model.Config, 0)
-	for host, virtualService := range virtualServices {
-		out = append(out, model.Config{
-			ConfigMeta: model.ConfigMeta{
-				Type:      model.VirtualService.Type,
-				Name:      host,
-				Namespace: configs[0].Namespace,
-				Domain:    configs[0].Domain,
-			},
-			Spec: virtualService,
-		})
+		rule := virtualService.Spec.(*v1alpha3.VirtualService)
+		rule.Http = append(rule.Http, convertRouteRule(config.ConfigMeta, routeRule, namespace, w))
+		merged[fqdn] = config
 	}
 
+	out := make([]model.Config, 0, len(virtualServices))
+	for _, virtualService := range virtualServices {
+		out = append(out, *virtualService)
+	}
+	sortConfigs(out)
 	return out
 }
 
-func convertRouteRule(in *v1alpha1.RouteRule) *v1alpha3.HTTPRoute {
+// matchesAll returns whether a route rule match condition, once converted,
+// matches all requests.
+func matchesAll(in *v1alpha1.MatchCondition) bool {
+	return in == nil || (in.Source == nil && len(in.Request.GetHeaders()) == 0)
+}
+
+func convertRouteRule(config model.ConfigMeta, in *v1alpha1.RouteRule, namespace string, w fieldReporter) *v1alpha3.HTTPRoute {
 	if in == nil {
 		return nil
 	}
 
 	if in.L4Fault != nil {
-		log.Warn("L4 fault not supported")
+		w.warn("l4Fault", "L4 faults are not supported, dropped")
 	}
 
 	return &v1alpha3.HTTPRoute{
-		Match:            convertMatch(in.Match),
-		Route:            convertRoutes(in),
+		Match:            convertMatch(in.Match, w),
+		Route:            convertRoutes(config, in, namespace),
 		Redirect:         convertRedirect(in.Redirect),
 		Rewrite:          convertRewrite(in.Rewrite),
 		WebsocketUpgrade: in.WebsocketUpgrade,
-		Timeout:          convertHTTPTimeout(in.HttpReqTimeout),
-		Retries:          convertRetry(in.HttpReqRetries),
-		Fault:            convertHTTPFault(in.HttpFault),
-		Mirror:           convertMirror(in.Mirror),
+		Timeout:          convertHTTPTimeout(in.HttpReqTimeout, w),
+		Retries:          convertRetry(in.HttpReqRetries, w),
+		Fault:            convertHTTPFault(in.HttpFault, w),
+		Mirror:           convertMirror(config, in.Mirror, namespace),
 		CorsPolicy:       convertCORSPolicy(in.CorsPolicy),
 		AppendHeaders:    in.AppendHeaders,
 	}
 }
 
-func convertMatch(in *v1alpha1.MatchCondition) []*v1alpha3.HTTPMatchRequest {
+func convertMatch(in *v1alpha1.MatchCondition, w fieldReporter) []*v1alpha3.HTTPMatchRequest {
 	if in == nil {
 		return nil
 	}
@@ -106,36 +123,40 @@ func convertMatch(in *v1alpha1.MatchCondition) []*v1alpha3.HTTPMatchRequest {
 
 	if in.Request != nil {
 		for name, stringMatch := range in.Request.Headers {
+			field := "match.request.headers." + name
 			switch name {
 			case model.HeaderMethod:
-				out.Method = convertStringMatch(stringMatch)
+				out.Method = convertStringMatch(stringMatch, field, w)
 			case model.HeaderAuthority:
-				out.Authority = convertStringMatch(stringMatch)
+				out.Authority = convertStringMatch(stringMatch, field, w)
 			case model.HeaderScheme:
-				out.Scheme = convertStringMatch(stringMatch)
+				out.Scheme = convertStringMatch(stringMatch, field, w)
 			case model.HeaderURI:
-				out.Uri = convertStringMatch(stringMatch)
+				out.Uri = convertStringMatch(stringMatch, field, w)
 			default:
-				out.Headers[name] = convertStringMatch(stringMatch)
+				out.Headers[name] = convertStringMatch(stringMatch, field, w)
 			}
 		}
 	}
 
 	if in.Tcp != nil {
-		log.Warn("TCP matching not supported")
+		w.warn("match.tcp", "TCP matching not supported, dropped")
 	}
 	if in.Udp != nil {
-		log.Warn("UDP matching not supported")
+		w.warn("match.udp", "UDP matching not supported, dropped")
 	}
 	if in.Source != nil {
-		// TODO: out.SourceLabels
-		log.Warn("Source matching not supported")
+		out.SourceLabels = in.Source.Labels
+		if in.Source.Name != "" || in.Source.Service != "" {
+			w.warn("match.source", "source services not supported, only the source labels %v are matched",
+				model.Labels(in.Source.Labels))
+		}
 	}
 
 	return []*v1alpha3.HTTPMatchRequest{out}
 }
 
-func convertStringMatch(in *v1alpha1.StringMatch) *v1alpha3.StringMatch {
+func convertStringMatch(in *v1alpha1.StringMatch, field string, w fieldReporter) *v1alpha3.StringMatch {
 	out := &v1alpha3.StringMatch{}
 	switch m := in.MatchType.(type) {
 	case *v1alpha1.StringMatch_Exact:
@@ -145,14 +166,14 @@ func convertStringMatch(in *v1alpha1.StringMatch) *v1alpha3.StringMatch {
 	case *v1alpha1.StringMatch_Regex:
 		out.MatchType = &v1alpha3.StringMatch_Regex{Regex: m.Regex}
 	default:
-		log.Warn("Unsupported string match type")
+		w.warn(field, "unsupported string match type, dropped")
 		return nil
 	}
 	return out
 }
 
-func convertRoutes(in *v1alpha1.RouteRule) []*v1alpha3.DestinationWeight {
-	host := convertIstioService(in.Destination)
+func convertRoutes(config model.ConfigMeta, in *v1alpha1.RouteRule, namespace string) []*v1alpha3.DestinationWeight {
+	host := convertHost(config, in.Destination, namespace)
 
 	var out []*v1alpha3.DestinationWeight
 	if in.Redirect == nil && len(in.Route) == 0 {
@@ -169,7 +190,7 @@ func convertRoutes(in *v1alpha1.RouteRule) []*v1alpha3.DestinationWeight {
 	for _, route := range in.Route {
 		name := host
 		if route.Destination != nil {
-			name = convertIstioService(route.Destination)
+			name = convertHost(config, route.Destination, namespace)
 		}
 
 		out = append(out, &v1alpha3.DestinationWeight{
@@ -203,27 +224,27 @@ func convertRewrite(in *v1alpha1.HTTPRewrite) *v1alpha3.HTTPRewrite {
 	return &out
 }
 
-func convertHTTPTimeout(in *v1alpha1.HTTPTimeout) *types.Duration {
+func convertHTTPTimeout(in *v1alpha1.HTTPTimeout, w fieldReporter) *types.Duration {
 	if in == nil {
 		return nil
 	}
 
 	if st := in.GetSimpleTimeout(); st != nil {
 		if st.OverrideHeaderName != "" {
-			log.Warn("Timeout override header name not supported, ignored")
+			w.warn("httpReqTimeout.simpleTimeout.overrideHeaderName", "timeout override header name not supported, ignored")
 		}
 
 		return convertGogoDuration(st.Timeout)
 	}
 
 	if ct := in.GetCustom(); ct != nil {
-		log.Warn("Custom timeout policy not supported")
+		w.warn("httpReqTimeout.custom", "custom timeout policy not supported, dropped")
 	}
 
 	return nil
 }
 
-func convertRetry(in *v1alpha1.HTTPRetry) *v1alpha3.HTTPRetry {
+func convertRetry(in *v1alpha1.HTTPRetry, w fieldReporter) *v1alpha3.HTTPRetry {
 	if in == nil {
 		return nil
 	}
@@ -231,7 +252,7 @@ func convertRetry(in *v1alpha1.HTTPRetry) *v1alpha3.HTTPRetry {
 	switch v := in.RetryPolicy.(type) {
 	case *v1alpha1.HTTPRetry_SimpleRetry:
 		if v.SimpleRetry.OverrideHeaderName != "" {
-			log.Warn("Simple retry override header name not supported")
+			w.warn("httpReqRetries.simpleRetry.overrideHeaderName", "retry override header name not supported, ignored")
 		}
 
 		return &v1alpha3.HTTPRetry{
@@ -239,33 +260,33 @@ func convertRetry(in *v1alpha1.HTTPRetry) *v1alpha3.HTTPRetry {
 			PerTryTimeout: convertGogoDuration(v.SimpleRetry.PerTryTimeout),
 		}
 	case *v1alpha1.HTTPRetry_Custom:
-		log.Warn("Custom retry not supported")
+		w.warn("httpReqRetries.custom", "custom retry policy not supported, dropped")
 	}
 	return nil
 }
 
-func convertHTTPFault(in *v1alpha1.HTTPFaultInjection) *v1alpha3.HTTPFaultInjection {
+func convertHTTPFault(in *v1alpha1.HTTPFaultInjection, w fieldReporter) *v1alpha3.HTTPFaultInjection {
 	if in == nil {
 		return nil
 	}
 
 	return &v1alpha3.HTTPFaultInjection{
-		Abort: convertFaultAbort(in.Abort),
-		Delay: convertFaultDelay(in.Delay),
+		Abort: convertFaultAbort(in.Abort, w),
+		Delay: convertFaultDelay(in.Delay, w),
 	}
 }
 
-func convertFaultAbort(in *v1alpha1.HTTPFaultInjection_Abort) *v1alpha3.HTTPFaultInjection_Abort {
+func convertFaultAbort(in *v1alpha1.HTTPFaultInjection_Abort, w fieldReporter) *v1alpha3.HTTPFaultInjection_Abort {
 	if in == nil {
 		return nil
 	}
 
 	out := &v1alpha3.HTTPFaultInjection_Abort{
-		Percent: convertPercent(in.Percent),
+		Percent: convertPercent(in.Percent, "httpFault.abort.percent", w),
 	}
 
 	if in.OverrideHeaderName != "" {
-		log.Warn("Abort override header name not supported")
+		w.warn("httpFault.abort.overrideHeaderName", "abort override header name not supported, ignored")
 	}
 
 	switch v := in.ErrorType.(type) {
@@ -280,22 +301,22 @@ func convertFaultAbort(in *v1alpha1.HTTPFaultInjection_Abort) *v1alpha3.HTTPFaul
 	return out
 }
 
-func convertFaultDelay(in *v1alpha1.HTTPFaultInjection_Delay) *v1alpha3.HTTPFaultInjection_Delay {
+func convertFaultDelay(in *v1alpha1.HTTPFaultInjection_Delay, w fieldReporter) *v1alpha3.HTTPFaultInjection_Delay {
 	if in == nil {
 		return nil
 	}
 
 	out := &v1alpha3.HTTPFaultInjection_Delay{
-		Percent: convertPercent(in.Percent),
+		Percent: convertPercent(in.Percent, "httpFault.delay.percent", w),
 	}
 
 	if in.OverrideHeaderName != "" {
-		log.Warn("Delay override header name not supported")
+		w.warn("httpFault.delay.overrideHeaderName", "delay override header name not supported, ignored")
 	}
 
 	switch v := in.HttpDelayType.(type) {
 	case *v1alpha1.HTTPFaultInjection_Delay_ExponentialDelay:
-		log.Warn("Exponential delay is not supported")
+		w.warn("httpFault.delay.exponentialDelay", "exponential delay not supported, delay dropped")
 		return nil
 	case *v1alpha1.HTTPFaultInjection_Delay_FixedDelay:
 		out.HttpDelayType = &v1alpha3.HTTPFaultInjection_Delay_FixedDelay{
@@ -305,21 +326,21 @@ func convertFaultDelay(in *v1alpha1.HTTPFaultInjection_Delay) *v1alpha3.HTTPFaul
 	return out
 }
 
-func convertPercent(in float32) int32 {
+func convertPercent(in float32, field string, w fieldReporter) int32 {
 	out := int32(in)
 	if in != float32(out) {
-		log.Warn("Percent truncated")
+		w.warn(field, "percent %v truncated to %d", in, out)
 	}
 	return out
 }
 
-func convertMirror(in *v1alpha1.IstioService) *v1alpha3.Destination {
+func convertMirror(config model.ConfigMeta, in *v1alpha1.IstioService, namespace string) *v1alpha3.Destination {
 	if in == nil {
 		return nil
 	}
 
 	return &v1alpha3.Destination{
-		Name:   convertIstioService(in),
+		Name:   convertHost(config, in, namespace),
 		Subset: labelsToSubsetName(in.Labels),
 	}
 }
@@ -329,22 +350,41 @@ func convertCORSPolicy(in *v1alpha1.CorsPolicy) *v1alpha3.CorsPolicy {
 		return nil
 	}
 
-	return &v1alpha3.CorsPolicy{
-		AllowOrigin:      in.AllowOrigin,
-		AllowMethods:     in.AllowMethods,
-		AllowHeaders:     in.AllowHeaders,
-		ExposeHeaders:    in.ExposeHeaders,
-		MaxAge:           convertGogoDuration(in.MaxAge),
-		AllowCredentials: &types.BoolValue{Value: in.AllowCredentials.Value},
+	out := &v1alpha3.CorsPolicy{
+		AllowOrigin:   in.AllowOrigin,
+		AllowMethods:  in.AllowMethods,
+		AllowHeaders:  in.AllowHeaders,
+		ExposeHeaders: in.ExposeHeaders,
+		MaxAge:        convertGogoDuration(in.MaxAge),
+	}
+	if in.AllowCredentials != nil {
+		out.AllowCredentials = &types.BoolValue{Value: in.AllowCredentials.Value}
 	}
+	return out
 }
 
-// TODO: domain, namespace etc?
-// convert an Istio service to a v1alpha3 host
-func convertIstioService(service *v1alpha1.IstioService) string {
+// destinationNamespace returns the namespace of an Istio service referenced
+// by a config, where the converted config is created.
+func destinationNamespace(config model.ConfigMeta, service *v1alpha1.IstioService) string {
+	if service.Namespace != "" {
+		return service.Namespace
+	}
+	return config.Namespace
+}
+
+// convertHost converts an Istio service referenced by a config to a v1alpha3
+// host, as resolved from the namespace of the converted config.
+func convertHost(config model.ConfigMeta, service *v1alpha1.IstioService, namespace string) string {
 	if service.Service != "" { // Favor FQDN
 		return service.Service
 	}
+	serviceNamespace := destinationNamespace(config, service)
+	if service.Domain != "" {
+		return service.Name + "." + serviceNamespace + "." + service.Domain
+	}
+	if serviceNamespace != namespace {
+		return service.Name + "." + serviceNamespace
+	}
 	return service.Name // otherwise shortname
 }
 
@@ -354,8 +394,32 @@ func labelsToSubsetName(labels model.Labels) string {
 }
 
 func convertGogoDuration(in *duration.Duration) *types.Duration {
+	if in == nil {
+		return nil
+	}
 	return &types.Duration{
 		Seconds: in.Seconds,
 		Nanos:   in.Nanos,
 	}
 }
+
+func configsOfType(configs []model.Config, typ string) []model.Config {
+	out := make([]model.Config, 0)
+	for _, config := range configs {
+		if config.Type == typ {
+			out = append(out, config)
+		}
+	}
+	return out
+}
+
+// sortConfigs orders converted configs by namespace and name, so that the
+// output does not depend on map iteration.
+func sortConfigs(configs []model.Config) {
+	sort.Slice(configs, func(i, j int) bool {
+		if configs[i].Namespace != configs[j].Namespace {
+			return configs[i].Namespace < configs[j].Namespace
+		}
+		return configs[i].Name < configs[j].Name
+	})
+}
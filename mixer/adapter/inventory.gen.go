@@ -21,6 +21,7 @@ import (
 	denier "istio.io/istio/mixer/adapter/denier"
 	dogstatsd "istio.io/istio/mixer/adapter/dogstatsd"
 	fluentd "istio.io/istio/mixer/adapter/fluentd"
+	kafka "istio.io/istio/mixer/adapter/kafka"
 	kubernetesenv "istio.io/istio/mixer/adapter/kubernetesenv"
 	list "istio.io/istio/mixer/adapter/list"
 	memquota "istio.io/istio/mixer/adapter/memquota"
@@ -28,11 +29,14 @@ import (
 	opa "istio.io/istio/mixer/adapter/opa"
 	prometheus "istio.io/istio/mixer/adapter/prometheus"
 	rbac "istio.io/istio/mixer/adapter/rbac"
+	remote "istio.io/istio/mixer/adapter/remote"
 	servicecontrol "istio.io/istio/mixer/adapter/servicecontrol"
 	solarwinds "istio.io/istio/mixer/adapter/solarwinds"
 	stackdriver "istio.io/istio/mixer/adapter/stackdriver"
 	statsd "istio.io/istio/mixer/adapter/statsd"
 	stdio "istio.io/istio/mixer/adapter/stdio"
+	tracing "istio.io/istio/mixer/adapter/tracing"
+	webhook "istio.io/istio/mixer/adapter/webhook"
 	adptr "istio.io/istio/mixer/pkg/adapter"
 )
 
@@ -43,6 +47,7 @@ func Inventory() []adptr.InfoFn {
 		denier.GetInfo,
 		dogstatsd.GetInfo,
 		fluentd.GetInfo,
+		kafka.GetInfo,
 		kubernetesenv.GetInfo,
 		list.GetInfo,
 		memquota.GetInfo,
@@ -50,10 +55,13 @@ func Inventory() []adptr.InfoFn {
 		opa.GetInfo,
 		prometheus.GetInfo,
 		rbac.GetInfo,
+		remote.GetInfo,
 		servicecontrol.GetInfo,
 		solarwinds.GetInfo,
 		stackdriver.GetInfo,
 		statsd.GetInfo,
 		stdio.GetInfo,
+		tracing.GetInfo,
+		webhook.GetInfo,
 	}
 }
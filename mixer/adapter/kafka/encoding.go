@@ -0,0 +1,286 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/adapter/kafka/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/adapter/util"
+	"istio.io/istio/mixer/template/logentry"
+	"istio.io/istio/mixer/template/metric"
+)
+
+type (
+	// encoder serializes instances into the values of Kafka messages.
+	encoder interface {
+		encodeLogEntry(inst *logentry.Instance) ([]byte, error)
+		encodeMetric(inst *metric.Instance) ([]byte, error)
+	}
+
+	jsonEncoder  struct{}
+	avroEncoder  struct{}
+	protoEncoder struct{}
+
+	jsonLogEntry struct {
+		Name                        string                 `json:"name"`
+		Variables                   map[string]interface{} `json:"variables,omitempty"`
+		Timestamp                   time.Time              `json:"timestamp"`
+		Severity                    string                 `json:"severity,omitempty"`
+		MonitoredResourceType       string                 `json:"monitoredResourceType,omitempty"`
+		MonitoredResourceDimensions map[string]interface{} `json:"monitoredResourceDimensions,omitempty"`
+	}
+
+	jsonMetric struct {
+		Name                        string                 `json:"name"`
+		Value                       interface{}            `json:"value"`
+		Dimensions                  map[string]interface{} `json:"dimensions,omitempty"`
+		MonitoredResourceType       string                 `json:"monitoredResourceType,omitempty"`
+		MonitoredResourceDimensions map[string]interface{} `json:"monitoredResourceDimensions,omitempty"`
+	}
+)
+
+func newEncoder(format config.Params_Format) encoder {
+	switch format {
+	case config.AVRO:
+		return avroEncoder{}
+	case config.PROTO:
+		return protoEncoder{}
+	default:
+		return jsonEncoder{}
+	}
+}
+
+func (jsonEncoder) encodeLogEntry(inst *logentry.Instance) ([]byte, error) {
+	return json.Marshal(&jsonLogEntry{
+		Name:                        inst.Name,
+		Variables:                   jsonValues(inst.Variables),
+		Timestamp:                   inst.Timestamp,
+		Severity:                    inst.Severity,
+		MonitoredResourceType:       inst.MonitoredResourceType,
+		MonitoredResourceDimensions: jsonValues(inst.MonitoredResourceDimensions),
+	})
+}
+
+func (jsonEncoder) encodeMetric(inst *metric.Instance) ([]byte, error) {
+	return json.Marshal(&jsonMetric{
+		Name:                        inst.Name,
+		Value:                       jsonValue(inst.Value),
+		Dimensions:                  jsonValues(inst.Dimensions),
+		MonitoredResourceType:       inst.MonitoredResourceType,
+		MonitoredResourceDimensions: jsonValues(inst.MonitoredResourceDimensions),
+	})
+}
+
+// jsonValue returns the value as a JSON string, number or boolean.
+func jsonValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case nil, string, int64, bool:
+		return v
+	case float64:
+		// JSON has no representation for these.
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return adapter.Stringify(v)
+		}
+		return v
+	}
+	return adapter.Stringify(v)
+}
+
+func jsonValues(m map[string]interface{}) map[string]interface{} {
+	if len(m) == 0 {
+		return nil
+	}
+	values := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		values[k] = jsonValue(v)
+	}
+	return values
+}
+
+// Branches of the union of the dynamically typed values in Avro: ["null", "boolean", "long", "double", "string"].
+const (
+	avroNull int64 = iota
+	avroBoolean
+	avroLong
+	avroDouble
+	avroString
+)
+
+// avroWriter writes Avro binary encoded data, as specified in
+// https://avro.apache.org/docs/1.8.2/spec.html#binary_encoding.
+type avroWriter struct {
+	bytes.Buffer
+}
+
+func (w *avroWriter) long(v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v) // zig-zag encoded, like Avro
+	w.Write(buf[:n])
+}
+
+func (w *avroWriter) string(v string) {
+	w.long(int64(len(v)))
+	w.WriteString(v)
+}
+
+func (w *avroWriter) boolean(v bool) {
+	if v {
+		w.WriteByte(1)
+	} else {
+		w.WriteByte(0)
+	}
+}
+
+func (w *avroWriter) double(v float64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+	w.Write(buf[:])
+}
+
+func (w *avroWriter) value(v interface{}) {
+	switch t := v.(type) {
+	case nil:
+		w.long(avroNull)
+	case bool:
+		w.long(avroBoolean)
+		w.boolean(t)
+	case int64:
+		w.long(avroLong)
+		w.long(t)
+	case float64:
+		w.long(avroDouble)
+		w.double(t)
+	default:
+		w.long(avroString)
+		w.string(adapter.Stringify(v))
+	}
+}
+
+// values writes a map of values as a single block, with its keys sorted.
+func (w *avroWriter) values(m map[string]interface{}) {
+	if len(m) > 0 {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		w.long(int64(len(keys)))
+		for _, k := range keys {
+			w.string(k)
+			w.value(m[k])
+		}
+	}
+	w.long(0)
+}
+
+func (avroEncoder) encodeLogEntry(inst *logentry.Instance) ([]byte, error) {
+	var w avroWriter
+	w.string(inst.Name)
+	w.values(inst.Variables)
+	w.long(inst.Timestamp.UnixNano() / int64(time.Microsecond))
+	w.string(inst.Severity)
+	w.string(inst.MonitoredResourceType)
+	w.values(inst.MonitoredResourceDimensions)
+	return w.Bytes(), nil
+}
+
+func (avroEncoder) encodeMetric(inst *metric.Instance) ([]byte, error) {
+	var w avroWriter
+	w.string(inst.Name)
+	w.value(inst.Value)
+	w.values(inst.Dimensions)
+	w.string(inst.MonitoredResourceType)
+	w.values(inst.MonitoredResourceDimensions)
+	return w.Bytes(), nil
+}
+
+func (protoEncoder) encodeLogEntry(inst *logentry.Instance) ([]byte, error) {
+	ts, err := types.TimestampProto(inst.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &logentry.InstanceMsg{
+		Name:                  inst.Name,
+		Timestamp:             &v1beta1.TimeStamp{Value: ts},
+		Severity:              inst.Severity,
+		MonitoredResourceType: inst.MonitoredResourceType,
+	}
+	if msg.Variables, err = protoValues(inst.Variables); err != nil {
+		return nil, err
+	}
+	if msg.MonitoredResourceDimensions, err = protoValues(inst.MonitoredResourceDimensions); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+func (protoEncoder) encodeMetric(inst *metric.Instance) ([]byte, error) {
+	msg := &metric.InstanceMsg{
+		Name:                  inst.Name,
+		MonitoredResourceType: inst.MonitoredResourceType,
+	}
+
+	var err error
+	if msg.Value, err = protoValue(inst.Value); err != nil {
+		return nil, err
+	}
+	if msg.Dimensions, err = protoValues(inst.Dimensions); err != nil {
+		return nil, err
+	}
+	if msg.MonitoredResourceDimensions, err = protoValues(inst.MonitoredResourceDimensions); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+// protoValue returns the value as an istio.mixer.adapter.model.v1beta1.Value. Values of types that have no
+// representation in the proto are encoded as strings.
+func protoValue(v interface{}) (*v1beta1.Value, error) {
+	pv, err := util.NewValue(v)
+	if _, unsupported := err.(*util.UnsupportedTypeError); unsupported {
+		return &v1beta1.Value{Value: &v1beta1.Value_StringValue{StringValue: adapter.Stringify(v)}}, nil
+	}
+	return pv, err
+}
+
+func protoValues(m map[string]interface{}) (map[string]*v1beta1.Value, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	values := make(map[string]*v1beta1.Value, len(m))
+	for k, v := range m {
+		pv, err := protoValue(v)
+		if err != nil {
+			return nil, err
+		}
+		if pv != nil {
+			values[k] = pv
+		}
+	}
+	return values, nil
+}
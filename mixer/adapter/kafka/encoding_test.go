@@ -0,0 +1,195 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/template/logentry"
+	"istio.io/istio/mixer/template/metric"
+)
+
+var ts = time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func TestJSON(t *testing.T) {
+	le := &logentry.Instance{
+		Name:      "accesslog",
+		Timestamp: ts,
+		Severity:  "Info",
+		Variables: map[string]interface{}{
+			"code":    int64(200),
+			"latency": 10 * time.Millisecond,
+			"ip":      net.ParseIP("10.0.0.1"),
+			"ok":      true,
+			"ratio":   math.NaN(),
+		},
+	}
+	b, err := jsonEncoder{}.encodeLogEntry(le)
+	if err != nil {
+		t.Fatalf("encodeLogEntry() => %v", err)
+	}
+	want := `{"name":"accesslog","variables":{"code":200,"ip":"10.0.0.1","latency":"10ms","ok":true,"ratio":"NaN"},` +
+		`"timestamp":"2018-05-01T10:00:00Z","severity":"Info"}`
+	if string(b) != want {
+		t.Errorf("encodeLogEntry() => %s, want %s", b, want)
+	}
+
+	m := &metric.Instance{
+		Name:                        "requestsize",
+		Value:                       float64(1.5),
+		Dimensions:                  map[string]interface{}{"destination": adapter.DNSName("reviews")},
+		MonitoredResourceType:       "global",
+		MonitoredResourceDimensions: map[string]interface{}{"cluster": "east"},
+	}
+	if b, err = (jsonEncoder{}).encodeMetric(m); err != nil {
+		t.Fatalf("encodeMetric() => %v", err)
+	}
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	wantMetric := map[string]interface{}{
+		"name":                        "requestsize",
+		"value":                       1.5,
+		"dimensions":                  map[string]interface{}{"destination": "reviews"},
+		"monitoredResourceType":       "global",
+		"monitoredResourceDimensions": map[string]interface{}{"cluster": "east"},
+	}
+	if !reflect.DeepEqual(got, wantMetric) {
+		t.Errorf("encodeMetric() => %v, want %v", got, wantMetric)
+	}
+}
+
+func TestAvro(t *testing.T) {
+	m := &metric.Instance{
+		Name:       "rc",
+		Value:      int64(-2),
+		Dimensions: map[string]interface{}{"b": true, "a": "x"},
+	}
+	b, err := avroEncoder{}.encodeMetric(m)
+	if err != nil {
+		t.Fatalf("encodeMetric() => %v", err)
+	}
+	want := []byte{
+		0x04, 'r', 'c', // name
+		0x04, 0x03, // value: long -2
+		0x04,                       // dimensions: block of 2 entries, sorted by key
+		0x02, 'a', 0x08, 0x02, 'x', // "a": string "x"
+		0x02, 'b', 0x02, 0x01, // "b": boolean true
+		0x00, // end of dimensions
+		0x00, // monitored_resource_type
+		0x00, // monitored_resource_dimensions
+	}
+	if !bytes.Equal(b, want) {
+		t.Errorf("encodeMetric() => %x, want %x", b, want)
+	}
+
+	le := &logentry.Instance{
+		Name:      "l",
+		Timestamp: time.Unix(0, 1000),
+		Severity:  "I",
+		Variables: map[string]interface{}{"d": float64(1), "n": nil},
+	}
+	if b, err = (avroEncoder{}).encodeLogEntry(le); err != nil {
+		t.Fatalf("encodeLogEntry() => %v", err)
+	}
+	want = []byte{
+		0x02, 'l', // name
+		0x04,                                                            // variables: block of 2 entries
+		0x02, 'd', 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f, // "d": double 1
+		0x02, 'n', 0x00, // "n": null
+		0x00,      // end of variables
+		0x02,      // timestamp: 1 microsecond
+		0x02, 'I', // severity
+		0x00, // monitored_resource_type
+		0x00, // monitored_resource_dimensions
+	}
+	if !bytes.Equal(b, want) {
+		t.Errorf("encodeLogEntry() => %x, want %x", b, want)
+	}
+}
+
+func TestProto(t *testing.T) {
+	le := &logentry.Instance{
+		Name:                  "accesslog",
+		Timestamp:             ts,
+		Severity:              "Info",
+		MonitoredResourceType: "global",
+		Variables: map[string]interface{}{
+			"code":    int64(200),
+			"latency": 10 * time.Millisecond,
+			"headers": map[string]string{"a": "b"},
+		},
+	}
+	b, err := protoEncoder{}.encodeLogEntry(le)
+	if err != nil {
+		t.Fatalf("encodeLogEntry() => %v", err)
+	}
+
+	var got logentry.InstanceMsg
+	if err = proto.Unmarshal(b, &got); err != nil {
+		t.Fatalf("invalid proto: %v", err)
+	}
+	pts, _ := types.TimestampProto(ts)
+	want := logentry.InstanceMsg{
+		Name:                  "accesslog",
+		Timestamp:             &v1beta1.TimeStamp{Value: pts},
+		Severity:              "Info",
+		MonitoredResourceType: "global",
+		Variables: map[string]*v1beta1.Value{
+			"code": {Value: &v1beta1.Value_Int64Value{Int64Value: 200}},
+			"latency": {Value: &v1beta1.Value_DurationValue{
+				DurationValue: &v1beta1.Duration{Value: types.DurationProto(10 * time.Millisecond)}}},
+			"headers": {Value: &v1beta1.Value_StringValue{StringValue: "a=b"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("encodeLogEntry() => %v, want %v", got, want)
+	}
+
+	m := &metric.Instance{
+		Name:       "requestcount",
+		Value:      int64(1),
+		Dimensions: map[string]interface{}{"source": net.ParseIP("10.0.0.1").To4()},
+	}
+	if b, err = (protoEncoder{}).encodeMetric(m); err != nil {
+		t.Fatalf("encodeMetric() => %v", err)
+	}
+	var gotMetric metric.InstanceMsg
+	if err = proto.Unmarshal(b, &gotMetric); err != nil {
+		t.Fatalf("invalid proto: %v", err)
+	}
+	wantMetric := metric.InstanceMsg{
+		Name:  "requestcount",
+		Value: &v1beta1.Value{Value: &v1beta1.Value_Int64Value{Int64Value: 1}},
+		Dimensions: map[string]*v1beta1.Value{
+			"source": {Value: &v1beta1.Value_IpAddressValue{IpAddressValue: &v1beta1.IPAddress{Value: []byte{10, 0, 0, 1}}}},
+		},
+	}
+	if !reflect.DeepEqual(gotMetric, wantMetric) {
+		t.Errorf("encodeMetric() => %v, want %v", gotMetric, wantMetric)
+	}
+}
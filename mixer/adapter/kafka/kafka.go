@@ -0,0 +1,243 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate $GOPATH/src/istio.io/istio/bin/mixer_codegen.sh -f mixer/adapter/kafka/config/config.proto
+
+// Package kafka provides an adapter that publishes logentry and metric instances to Kafka topics.
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"istio.io/istio/mixer/adapter/kafka/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/template/logentry"
+	"istio.io/istio/mixer/template/metric"
+)
+
+type (
+	builder struct {
+		adapterConfig *config.Params
+		logEntryTypes map[string]*logentry.Type
+		metricTypes   map[string]*metric.Type
+		newProducer   newProducerFn
+	}
+
+	handler struct {
+		topic    pattern
+		key      pattern
+		enc      encoder
+		producer producer
+	}
+)
+
+// ensure types implement the requisite interfaces
+var _ logentry.HandlerBuilder = &builder{}
+var _ logentry.Handler = &handler{}
+var _ metric.HandlerBuilder = &builder{}
+var _ metric.Handler = &handler{}
+
+///////////////// Configuration-time Methods ///////////////
+
+// adapter.HandlerBuilder#Build
+func (b *builder) Build(_ context.Context, env adapter.Env) (adapter.Handler, error) {
+	ac := b.adapterConfig
+
+	// The patterns are checked by Validate.
+	topic, _ := parsePattern(ac.Topic)
+	key, _ := parsePattern(ac.Key)
+
+	p, err := b.newProducer(ac, env)
+	if err != nil {
+		return nil, env.Logger().Errorf("Unable to create Kafka producer: %v", err)
+	}
+
+	return &handler{
+		topic:    topic,
+		key:      key,
+		enc:      newEncoder(ac.Format),
+		producer: p,
+	}, nil
+}
+
+// adapter.HandlerBuilder#SetAdapterConfig
+func (b *builder) SetAdapterConfig(cfg adapter.Config) {
+	b.adapterConfig = cfg.(*config.Params)
+}
+
+// adapter.HandlerBuilder#Validate
+func (b *builder) Validate() (ce *adapter.ConfigErrors) {
+	ac := b.adapterConfig
+
+	if len(ac.Brokers) == 0 {
+		ce = ce.Appendf("brokers", "at least one broker must be specified")
+	}
+	for _, broker := range ac.Brokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			ce = ce.Appendf("brokers", "broker address %q is malformed: %v", broker, err)
+		}
+	}
+
+	if _, found := config.Params_Format_name[int32(ac.Format)]; !found {
+		ce = ce.Appendf("format", "unknown format %v", ac.Format)
+	}
+
+	if topic, err := parsePattern(ac.Topic); err != nil {
+		ce = ce.Appendf("topic", "invalid topic pattern: %v", err)
+	} else if len(topic) == 0 {
+		ce = ce.Appendf("topic", "topic must be specified")
+	} else if l := topic.literals(); strings.IndexFunc(l, func(r rune) bool { return !validTopicChar(r) }) >= 0 {
+		ce = ce.Appendf("topic", "topic %q can only contain letters, digits, '.', '_' and '-'", ac.Topic)
+	}
+
+	if _, err := parsePattern(ac.Key); err != nil {
+		ce = ce.Appendf("key", "invalid key pattern: %v", err)
+	}
+
+	if _, found := requiredAcks[ac.RequiredAcks]; !found {
+		ce = ce.Appendf("requiredAcks", "unknown required acks %v", ac.RequiredAcks)
+	}
+
+	if ac.MaxRetries < 0 {
+		ce = ce.Appendf("maxRetries", "max retries must be >= 0, got %d", ac.MaxRetries)
+	}
+
+	if ac.RetryBackoff < 0 {
+		ce = ce.Appendf("retryBackoff", "retry backoff must be >= 0, got %v", ac.RetryBackoff)
+	}
+
+	if ac.FlushFrequency < 0 {
+		ce = ce.Appendf("flushFrequency", "flush frequency must be >= 0, got %v", ac.FlushFrequency)
+	}
+
+	if ac.FlushMessages < 0 {
+		ce = ce.Appendf("flushMessages", "flush messages must be >= 0, got %d", ac.FlushMessages)
+	}
+
+	if ac.FlushBytes < 0 {
+		ce = ce.Appendf("flushBytes", "flush bytes must be >= 0, got %d", ac.FlushBytes)
+	}
+
+	if ac.Timeout <= 0 {
+		ce = ce.Appendf("timeout", "timeout must be > 0, got %v", ac.Timeout)
+	}
+
+	if ac.ClientId == "" {
+		ce = ce.Appendf("clientId", "client ID must be specified")
+	}
+
+	if ac.Tls != nil && (ac.Tls.CertFile == "") != (ac.Tls.KeyFile == "") {
+		ce = ce.Appendf("tls", "both the client certificate and its key must be specified")
+	}
+
+	if ac.Sasl != nil && ac.Sasl.Username == "" {
+		ce = ce.Appendf("sasl", "user name must be specified")
+	}
+
+	return ce
+}
+
+// logentry.HandlerBuilder#SetLogEntryTypes
+func (b *builder) SetLogEntryTypes(types map[string]*logentry.Type) {
+	b.logEntryTypes = types
+}
+
+// metric.HandlerBuilder#SetMetricTypes
+func (b *builder) SetMetricTypes(types map[string]*metric.Type) {
+	b.metricTypes = types
+}
+
+////////////////// Request-time Methods //////////////////////////
+
+// logentry.Handler#HandleLogEntry
+func (h *handler) HandleLogEntry(_ context.Context, insts []*logentry.Instance) error {
+	var result *multierror.Error
+	for _, inst := range insts {
+		value, err := h.enc.encodeLogEntry(inst)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("unable to encode instance %s: %v", inst.Name, err))
+			continue
+		}
+		h.publish(logEntryLookup(inst), value)
+	}
+	return result.ErrorOrNil()
+}
+
+// metric.Handler#HandleMetric
+func (h *handler) HandleMetric(_ context.Context, insts []*metric.Instance) error {
+	var result *multierror.Error
+	for _, inst := range insts {
+		value, err := h.enc.encodeMetric(inst)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("unable to encode instance %s: %v", inst.Name, err))
+			continue
+		}
+		h.publish(metricLookup(inst), value)
+	}
+	return result.ErrorOrNil()
+}
+
+// publish sends the value to the topic and with the key resolved from the fields of the instance.
+func (h *handler) publish(lookup lookupFn, value []byte) {
+	topic := h.topic.resolve(lookup, topicSafe)
+	if len(topic) > maxTopicLength {
+		topic = topic[:maxTopicLength]
+	}
+
+	var key []byte
+	if len(h.key) > 0 {
+		key = []byte(h.key.resolve(lookup, identity))
+	}
+
+	h.producer.send(topic, key, value)
+}
+
+// adapter.Handler#Close
+func (h *handler) Close() error {
+	return h.producer.close()
+}
+
+////////////////// Bootstrap //////////////////////////
+
+// GetInfo returns the adapter.Info specific to this adapter.
+func GetInfo() adapter.Info {
+	return adapter.Info{
+		Name:        "kafka",
+		Impl:        "istio.io/istio/mixer/adapter/kafka",
+		Description: "Publishes logs and metrics to Kafka topics",
+		SupportedTemplates: []string{
+			logentry.TemplateName,
+			metric.TemplateName,
+		},
+		NewBuilder: func() adapter.HandlerBuilder { return &builder{newProducer: newSaramaProducer} },
+		DefaultConfig: &config.Params{
+			Brokers:        []string{"localhost:9092"},
+			Format:         config.JSON,
+			Topic:          "istio-{name}",
+			RequiredAcks:   config.LEADER,
+			MaxRetries:     3,
+			RetryBackoff:   100 * time.Millisecond,
+			FlushFrequency: 500 * time.Millisecond,
+			FlushMessages:  100,
+			Timeout:        10 * time.Second,
+			ClientId:       "istio-mixer",
+		},
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"istio.io/istio/mixer/adapter/kafka/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/adapter/test"
+	"istio.io/istio/mixer/template/logentry"
+	"istio.io/istio/mixer/template/metric"
+)
+
+// broker is an in-memory stand-in for a Kafka cluster, in which every topic has the same number of partitions.
+type broker struct {
+	partitions int
+
+	mu     sync.Mutex
+	topics map[string][][]message
+	next   int // partition of the next message without a key
+	closed bool
+}
+
+type message struct {
+	key   string
+	value []byte
+}
+
+func newBroker(partitions int) *broker {
+	return &broker{
+		partitions: partitions,
+		topics:     make(map[string][][]message),
+	}
+}
+
+func (b *broker) newProducer(*config.Params, adapter.Env) (producer, error) {
+	return b, nil
+}
+
+// send appends the message to a partition of the topic, chosen by hashing the key like the default partitioner.
+func (b *broker) send(topic string, key []byte, value []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.topics[topic] == nil {
+		b.topics[topic] = make([][]message, b.partitions)
+	}
+
+	partition := b.next
+	if key == nil {
+		b.next = (b.next + 1) % b.partitions
+	} else {
+		h := fnv.New32a()
+		_, _ = h.Write(key)
+		partition = int(h.Sum32() % uint32(b.partitions))
+	}
+
+	b.topics[topic][partition] = append(b.topics[topic][partition], message{key: string(key), value: value})
+}
+
+func (b *broker) close() error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	return nil
+}
+
+// messages returns the messages of a topic, per partition.
+func (b *broker) messages(topic string) [][]message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.topics[topic]
+}
+
+func (b *broker) topicNames() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var names []string
+	for name := range b.topics {
+		names = append(names, name)
+	}
+	return names
+}
+
+func params() *config.Params {
+	p := *GetInfo().DefaultConfig.(*config.Params)
+	return &p
+}
+
+func build(t *testing.T, b *broker, p *config.Params) *handler {
+	bld := GetInfo().NewBuilder().(*builder)
+	bld.newProducer = b.newProducer
+	bld.SetAdapterConfig(p)
+	bld.SetLogEntryTypes(map[string]*logentry.Type{"accesslog": {}})
+	bld.SetMetricTypes(map[string]*metric.Type{"requestcount": {}})
+	if err := bld.Validate(); err != nil {
+		t.Fatalf("Validate() => %v", err)
+	}
+
+	h, err := bld.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Build() => %v", err)
+	}
+	return h.(*handler)
+}
+
+func TestGetInfo(t *testing.T) {
+	info := GetInfo()
+	if !reflect.DeepEqual(info.SupportedTemplates, []string{logentry.TemplateName, metric.TemplateName}) {
+		t.Errorf("SupportedTemplates: %v, want logentry and metric", info.SupportedTemplates)
+	}
+
+	b := info.NewBuilder()
+	b.SetAdapterConfig(info.DefaultConfig)
+	if err := b.Validate(); err != nil {
+		t.Errorf("Validate() of the default config => %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(p *config.Params)
+		field  string
+	}{
+		{"no broker", func(p *config.Params) { p.Brokers = nil }, "brokers"},
+		{"malformed broker", func(p *config.Params) { p.Brokers = []string{"localhost"} }, "brokers"},
+		{"unknown format", func(p *config.Params) { p.Format = 42 }, "format"},
+		{"no topic", func(p *config.Params) { p.Topic = "" }, "topic"},
+		{"invalid topic", func(p *config.Params) { p.Topic = "istio/{name}" }, "topic"},
+		{"unknown topic field", func(p *config.Params) { p.Topic = "istio-{value}" }, "topic"},
+		{"unterminated key field", func(p *config.Params) { p.Key = "{name" }, "key"},
+		{"unknown required acks", func(p *config.Params) { p.RequiredAcks = 42 }, "requiredAcks"},
+		{"negative retries", func(p *config.Params) { p.MaxRetries = -1 }, "maxRetries"},
+		{"negative backoff", func(p *config.Params) { p.RetryBackoff = -time.Second }, "retryBackoff"},
+		{"negative flush frequency", func(p *config.Params) { p.FlushFrequency = -time.Second }, "flushFrequency"},
+		{"negative flush messages", func(p *config.Params) { p.FlushMessages = -1 }, "flushMessages"},
+		{"negative flush bytes", func(p *config.Params) { p.FlushBytes = -1 }, "flushBytes"},
+		{"no timeout", func(p *config.Params) { p.Timeout = 0 }, "timeout"},
+		{"no client ID", func(p *config.Params) { p.ClientId = "" }, "clientId"},
+		{"certificate without key", func(p *config.Params) { p.Tls = &config.Params_TLS{CertFile: "cert.pem"} }, "tls"},
+		{"SASL without user name", func(p *config.Params) { p.Sasl = &config.Params_SASL{Password: "secret"} }, "sasl"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := params()
+			c.modify(p)
+
+			b := GetInfo().NewBuilder().(*builder)
+			b.SetAdapterConfig(p)
+			ce := b.Validate()
+			if ce == nil || len(ce.Multi.Errors) != 1 || ce.Multi.Errors[0].(adapter.ConfigError).Field != c.field {
+				t.Fatalf("Validate() => %v, want a single error for %s", ce, c.field)
+			}
+		})
+	}
+}
+
+func TestHandleLogEntry(t *testing.T) {
+	b := newBroker(4)
+	p := params()
+	p.Topic = "istio-{name}-{severity}"
+	p.Key = "{variables.destination}"
+	h := build(t, b, p)
+
+	ts := time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+	var insts []*logentry.Instance
+	for i := 0; i < 10; i++ {
+		insts = append(insts, &logentry.Instance{
+			Name:      "accesslog",
+			Severity:  "Info",
+			Timestamp: ts,
+			Variables: map[string]interface{}{"destination": "reviews", "code": int64(200 + i)},
+		})
+	}
+	if err := h.HandleLogEntry(context.Background(), insts); err != nil {
+		t.Fatalf("HandleLogEntry() => %v", err)
+	}
+
+	// All the messages with the same key are in the same partition, in order.
+	var got []message
+	for _, partition := range b.messages("istio-accesslog-Info") {
+		if len(partition) > 0 && len(got) > 0 {
+			t.Fatalf("messages with the same key in several partitions")
+		}
+		got = append(got, partition...)
+	}
+	if len(got) != 10 {
+		t.Fatalf("got %d messages, want 10 in topic istio-accesslog-Info, topics: %v", len(got), b.topicNames())
+	}
+
+	for i, m := range got {
+		if m.key != "reviews" {
+			t.Errorf("message %d: key %q, want reviews", i, m.key)
+		}
+		var e map[string]interface{}
+		if err := json.Unmarshal(m.value, &e); err != nil {
+			t.Fatalf("message %d: invalid JSON %s: %v", i, m.value, err)
+		}
+		if code := e["variables"].(map[string]interface{})["code"]; code != float64(200+i) {
+			t.Errorf("message %d: code %v, want %d", i, code, 200+i)
+		}
+	}
+
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() => %v", err)
+	}
+	if !b.closed {
+		t.Errorf("the producer is not closed")
+	}
+}
+
+func TestHandleMetric(t *testing.T) {
+	b := newBroker(2)
+	p := params()
+	p.Format = config.PROTO
+	p.Topic = "metrics.{dimensions.destination}"
+	h := build(t, b, p)
+
+	insts := []*metric.Instance{
+		{Name: "requestcount", Value: int64(1), Dimensions: map[string]interface{}{"destination": "reviews.default"}},
+		{Name: "requestcount", Value: int64(1), Dimensions: map[string]interface{}{"destination": "ratings:9080"}},
+		{Name: "requestcount", Value: int64(1)},
+	}
+	if err := h.HandleMetric(context.Background(), insts); err != nil {
+		t.Fatalf("HandleMetric() => %v", err)
+	}
+
+	// Characters that cannot be part of a topic are replaced, and there is no key so messages are spread over the
+	// partitions.
+	for _, topic := range []string{"metrics.reviews.default", "metrics.ratings_9080", "metrics."} {
+		partitions := b.messages(topic)
+		if len(partitions) == 0 || len(partitions[0])+len(partitions[1]) != 1 {
+			t.Errorf("topic %s: %v, want one message, topics: %v", topic, partitions, b.topicNames())
+		}
+	}
+}
+
+func TestHandleMetric_EncodingError(t *testing.T) {
+	b := newBroker(1)
+	p := params()
+	p.Format = config.PROTO
+	h := build(t, b, p)
+
+	insts := []*metric.Instance{
+		{Name: "requestcount", Value: time.Date(20000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "requestcount", Value: int64(1)},
+	}
+	err := h.HandleMetric(context.Background(), insts)
+	if err == nil || !strings.Contains(err.Error(), "unable to encode instance requestcount") {
+		t.Errorf("HandleMetric() => %v, want an encoding error", err)
+	}
+	if got := b.messages("istio-requestcount"); len(got) != 1 || len(got[0]) != 1 {
+		t.Errorf("messages: %v, want the valid instance to be published", got)
+	}
+}
+
+func TestBuild_ProducerError(t *testing.T) {
+	bld := GetInfo().NewBuilder().(*builder)
+	bld.newProducer = func(*config.Params, adapter.Env) (producer, error) {
+		return nil, errors.New("no broker available")
+	}
+	bld.SetAdapterConfig(params())
+
+	if _, err := bld.Build(context.Background(), test.NewEnv(t)); err == nil || !strings.Contains(err.Error(), "no broker available") {
+		t.Errorf("Build() => %v, want the producer error", err)
+	}
+}
+
+func TestSaramaConfig(t *testing.T) {
+	p := params()
+	p.RequiredAcks = config.ALL
+	p.MaxRetries = 5
+	p.FlushMessages = 42
+	p.Sasl = &config.Params_SASL{Username: "mixer", Password: "secret"}
+	p.Tls = &config.Params_TLS{ServerName: "kafka.example.com"}
+
+	cfg, err := newSaramaConfig(p)
+	if err != nil {
+		t.Fatalf("newSaramaConfig() => %v", err)
+	}
+
+	if cfg.ClientID != "istio-mixer" || cfg.Producer.RequiredAcks != sarama.WaitForAll || cfg.Producer.Retry.Max != 5 ||
+		cfg.Producer.Flush.Messages != 42 || cfg.Producer.Flush.Frequency != 500*time.Millisecond ||
+		!cfg.Producer.Return.Errors || cfg.Producer.Return.Successes {
+		t.Errorf("producer config: %+v", cfg.Producer)
+	}
+	if !cfg.Net.SASL.Enable || cfg.Net.SASL.User != "mixer" || cfg.Net.SASL.Password != "secret" {
+		t.Errorf("SASL config: %+v", cfg.Net.SASL)
+	}
+	if !cfg.Net.TLS.Enable || cfg.Net.TLS.Config.ServerName != "kafka.example.com" {
+		t.Errorf("TLS config: %+v", cfg.Net.TLS)
+	}
+}
+
+func TestSaramaConfig_TLSErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kafka")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	notPEM := filepath.Join(dir, "ca.pem")
+	if err = ioutil.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		tls *config.Params_TLS
+		err string
+	}{
+		{&config.Params_TLS{CaFile: filepath.Join(dir, "missing.pem")}, "unable to read CA certificates"},
+		{&config.Params_TLS{CaFile: notPEM}, "no CA certificate found"},
+		{&config.Params_TLS{CertFile: notPEM, KeyFile: notPEM}, "unable to load client certificate"},
+	}
+
+	for _, c := range cases {
+		p := params()
+		p.Tls = c.tls
+		if _, err := newSaramaConfig(p); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("newSaramaConfig(%v) => %v, want %s", c.tls, err, c.err)
+		}
+	}
+}
+
+func TestPattern(t *testing.T) {
+	inst := &logentry.Instance{
+		Name:                        "accesslog",
+		Severity:                    "Error",
+		MonitoredResourceType:       "global",
+		Variables:                   map[string]interface{}{"code": int64(503)},
+		MonitoredResourceDimensions: map[string]interface{}{"cluster": "east/1"},
+	}
+
+	cases := []struct {
+		pattern string
+		want    string
+		err     string
+	}{
+		{"istio", "istio", ""},
+		{"{name}", "accesslog", ""},
+		{"{name}-{severity}.{variables.code}", "accesslog-Error.503", ""},
+		{"{monitored_resource_type}/{monitored_resource_dimensions.cluster}", "global/east/1", ""},
+		{"{variables.missing}-{dimensions.code}", "-", ""},
+		{"{name", "", "missing '}'"},
+		{"name}", "", "unexpected '}'"},
+		{"{}", "", "unknown field \"\""},
+		{"{variables.}", "", "unknown field"},
+		{"{timestamp}", "", "unknown field"},
+	}
+
+	for _, c := range cases {
+		p, err := parsePattern(c.pattern)
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("parsePattern(%q) => %v, want %s", c.pattern, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parsePattern(%q) => %v", c.pattern, err)
+			continue
+		}
+		if got := p.resolve(logEntryLookup(inst), identity); got != c.want {
+			t.Errorf("%q.resolve() => %q, want %q", c.pattern, got, c.want)
+		}
+	}
+}
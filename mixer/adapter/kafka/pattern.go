@@ -0,0 +1,170 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/template/logentry"
+	"istio.io/istio/mixer/template/metric"
+)
+
+// Fields of the instances that can be referenced by patterns.
+const (
+	nameField                  = "name"
+	severityField              = "severity"
+	monitoredResourceTypeField = "monitored_resource_type"
+
+	variablesPrefix                   = "variables."
+	dimensionsPrefix                  = "dimensions."
+	monitoredResourceDimensionsPrefix = "monitored_resource_dimensions."
+)
+
+// maxTopicLength is the maximum length of a Kafka topic.
+const maxTopicLength = 249
+
+type (
+	// pattern is a topic or key, in which {field} is replaced by the value of a field of the instance.
+	pattern []segment
+
+	// segment is either a literal, or a reference to a field.
+	segment struct {
+		literal string
+		field   string
+	}
+
+	// lookupFn returns the value of a field of an instance, nil if the instance does not have the field.
+	lookupFn func(field string) interface{}
+)
+
+func parsePattern(s string) (pattern, error) {
+	var p pattern
+	for len(s) > 0 {
+		open := strings.IndexAny(s, "{}")
+		if open < 0 {
+			p = append(p, segment{literal: s})
+			break
+		}
+		if s[open] == '}' {
+			return nil, fmt.Errorf("unexpected '}' at %q", s[open:])
+		}
+		if open > 0 {
+			p = append(p, segment{literal: s[:open]})
+		}
+
+		end := strings.IndexByte(s[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("missing '}' after %q", s[open:])
+		}
+		field := s[open+1 : open+end]
+		if !validField(field) {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+		p = append(p, segment{field: field})
+		s = s[open+end+1:]
+	}
+	return p, nil
+}
+
+func validField(field string) bool {
+	switch field {
+	case nameField, severityField, monitoredResourceTypeField:
+		return true
+	}
+	for _, prefix := range []string{variablesPrefix, dimensionsPrefix, monitoredResourceDimensionsPrefix} {
+		if strings.HasPrefix(field, prefix) && len(field) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// literals returns the concatenation of the literal segments of the pattern.
+func (p pattern) literals() string {
+	var b bytes.Buffer
+	for _, s := range p {
+		b.WriteString(s.literal)
+	}
+	return b.String()
+}
+
+// resolve returns the pattern, with the fields replaced by their values after applying fn.
+func (p pattern) resolve(lookup lookupFn, fn func(string) string) string {
+	var b bytes.Buffer
+	for _, s := range p {
+		if s.field == "" {
+			b.WriteString(s.literal)
+		} else {
+			b.WriteString(fn(adapter.Stringify(lookup(s.field))))
+		}
+	}
+	return b.String()
+}
+
+func identity(s string) string {
+	return s
+}
+
+// validTopicChar returns whether a character can be part of a Kafka topic.
+func validTopicChar(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-'
+}
+
+// topicSafe replaces the characters that cannot be part of a Kafka topic by '_'.
+func topicSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		if validTopicChar(r) {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+func logEntryLookup(inst *logentry.Instance) lookupFn {
+	return func(field string) interface{} {
+		switch {
+		case field == nameField:
+			return inst.Name
+		case field == severityField:
+			return inst.Severity
+		case field == monitoredResourceTypeField:
+			return inst.MonitoredResourceType
+		case strings.HasPrefix(field, variablesPrefix):
+			return inst.Variables[strings.TrimPrefix(field, variablesPrefix)]
+		case strings.HasPrefix(field, monitoredResourceDimensionsPrefix):
+			return inst.MonitoredResourceDimensions[strings.TrimPrefix(field, monitoredResourceDimensionsPrefix)]
+		}
+		return nil
+	}
+}
+
+func metricLookup(inst *metric.Instance) lookupFn {
+	return func(field string) interface{} {
+		switch {
+		case field == nameField:
+			return inst.Name
+		case field == monitoredResourceTypeField:
+			return inst.MonitoredResourceType
+		case strings.HasPrefix(field, dimensionsPrefix):
+			return inst.Dimensions[strings.TrimPrefix(field, dimensionsPrefix)]
+		case strings.HasPrefix(field, monitoredResourceDimensionsPrefix):
+			return inst.MonitoredResourceDimensions[strings.TrimPrefix(field, monitoredResourceDimensionsPrefix)]
+		}
+		return nil
+	}
+}
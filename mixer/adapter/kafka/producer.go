@@ -0,0 +1,141 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+
+	"istio.io/istio/mixer/adapter/kafka/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/adapter/util"
+)
+
+type (
+	// producer publishes messages to Kafka asynchronously.
+	producer interface {
+		// send queues a message for delivery. It blocks while the queue is full.
+		send(topic string, key []byte, value []byte)
+
+		// close delivers the queued messages, and releases the resources of the producer.
+		close() error
+	}
+
+	// newProducerFn creates a producer. Indirection for testing.
+	newProducerFn func(ac *config.Params, env adapter.Env) (producer, error)
+
+	// saramaProducer is a producer backed by a Sarama async producer, which batches the messages per broker.
+	saramaProducer struct {
+		p      sarama.AsyncProducer
+		logger adapter.Logger
+
+		// closed when the delivery errors are all logged.
+		done chan struct{}
+	}
+)
+
+var requiredAcks = map[config.Params_RequiredAcks]sarama.RequiredAcks{
+	config.LEADER: sarama.WaitForLocal,
+	config.NONE:   sarama.NoResponse,
+	config.ALL:    sarama.WaitForAll,
+}
+
+func newSaramaProducer(ac *config.Params, env adapter.Env) (producer, error) {
+	cfg, err := newSaramaConfig(ac)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := sarama.NewAsyncProducer(ac.Brokers, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to brokers %v: %v", ac.Brokers, err)
+	}
+
+	sp := &saramaProducer{
+		p:      p,
+		logger: env.Logger(),
+		done:   make(chan struct{}),
+	}
+	env.ScheduleDaemon(sp.logErrors)
+	return sp, nil
+}
+
+func newSaramaConfig(ac *config.Params) (*sarama.Config, error) {
+	cfg := sarama.NewConfig()
+	cfg.ClientID = ac.ClientId
+
+	cfg.Producer.RequiredAcks = requiredAcks[ac.RequiredAcks]
+	cfg.Producer.Timeout = ac.Timeout
+	cfg.Producer.Retry.Max = int(ac.MaxRetries)
+	cfg.Producer.Retry.Backoff = ac.RetryBackoff
+	cfg.Producer.Flush.Frequency = ac.FlushFrequency
+	cfg.Producer.Flush.Messages = int(ac.FlushMessages)
+	cfg.Producer.Flush.Bytes = int(ac.FlushBytes)
+	cfg.Producer.Return.Successes = false
+	cfg.Producer.Return.Errors = true
+
+	if ac.Tls != nil {
+		tlsConfig, err := util.NewTLSConfig(util.TLSOptions{
+			CaFile:             ac.Tls.CaFile,
+			CertFile:           ac.Tls.CertFile,
+			KeyFile:            ac.Tls.KeyFile,
+			ServerName:         ac.Tls.ServerName,
+			InsecureSkipVerify: ac.Tls.InsecureSkipVerify,
+		})
+		if err != nil {
+			return nil, err
+		}
+		cfg.Net.TLS.Enable = true
+		cfg.Net.TLS.Config = tlsConfig
+	}
+
+	if ac.Sasl != nil {
+		cfg.Net.SASL.Enable = true
+		cfg.Net.SASL.User = ac.Sasl.Username
+		cfg.Net.SASL.Password = ac.Sasl.Password
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (p *saramaProducer) send(topic string, key []byte, value []byte) {
+	msg := &sarama.ProducerMessage{
+		Topic: topic,
+		Value: sarama.ByteEncoder(value),
+	}
+	if len(key) > 0 {
+		msg.Key = sarama.ByteEncoder(key)
+	}
+	p.p.Input() <- msg
+}
+
+// logErrors logs the messages that could not be delivered, until the producer is closed.
+func (p *saramaProducer) logErrors() {
+	defer close(p.done)
+	for err := range p.p.Errors() {
+		_ = p.logger.Errorf("Unable to publish message to topic %s: %v", err.Msg.Topic, err.Err)
+	}
+}
+
+func (p *saramaProducer) close() error {
+	// The errors channel is closed once the queued messages are delivered.
+	p.p.AsyncClose()
+	<-p.done
+	return nil
+}
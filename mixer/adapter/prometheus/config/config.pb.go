@@ -19,10 +19,14 @@ import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
+import _ "github.com/gogo/protobuf/types"
+
+import time "time"
 
 import strconv "strconv"
 
 import encoding_binary "encoding/binary"
+import github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 
 import strings "strings"
 import reflect "reflect"
@@ -33,6 +37,7 @@ import io "io"
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -48,6 +53,7 @@ const (
 	GAUGE        Params_MetricInfo_Kind = 1
 	COUNTER      Params_MetricInfo_Kind = 2
 	DISTRIBUTION Params_MetricInfo_Kind = 3
+	SUMMARY      Params_MetricInfo_Kind = 4
 )
 
 var Params_MetricInfo_Kind_name = map[int32]string{
@@ -55,12 +61,14 @@ var Params_MetricInfo_Kind_name = map[int32]string{
 	1: "GAUGE",
 	2: "COUNTER",
 	3: "DISTRIBUTION",
+	4: "SUMMARY",
 }
 var Params_MetricInfo_Kind_value = map[string]int32{
 	"UNSPECIFIED":  0,
 	"GAUGE":        1,
 	"COUNTER":      2,
 	"DISTRIBUTION": 3,
+	"SUMMARY":      4,
 }
 
 func (Params_MetricInfo_Kind) EnumDescriptor() ([]byte, []int) {
@@ -98,6 +106,16 @@ type Params_MetricInfo struct {
 	// The names of labels to use: these need to match the dimensions of the Istio metric.
 	// TODO: see if we can remove this and rely on only the dimensions in the future.
 	LabelNames []string `protobuf:"bytes,6,rep,name=label_names,json=labelNames" json:"label_names,omitempty"`
+	// For metrics with a metric kind of SUMMARY, this provides a mechanism for configuring the quantiles
+	// reported for the observed values. This field will be ignored for non-summary metric kinds.
+	Summary *Params_MetricInfo_SummaryDefinition `protobuf:"bytes,7,opt,name=summary" json:"summary,omitempty"`
+	// Optional. Amount of time after which a label set that has not been updated is removed from the metric.
+	// Label sets never expire when this is not set.
+	Expiration time.Duration `protobuf:"bytes,8,opt,name=expiration,stdduration" json:"expiration"`
+	// Optional. Maximum number of label sets of the metric. Values with new label sets are dropped once the
+	// limit is reached, and counted by the `istio_mixer_prometheus_series_overflow_total` metric. The number of
+	// label sets is not limited when this is not set.
+	MaxSeries int32 `protobuf:"varint,9,opt,name=max_series,json=maxSeries,proto3" json:"max_series,omitempty"`
 }
 
 func (m *Params_MetricInfo) Reset()                    { *m = Params_MetricInfo{} }
@@ -345,6 +363,40 @@ func (*Params_MetricInfo_BucketsDefinition_Explicit) Descriptor() ([]byte, []int
 	return fileDescriptorConfig, []int{0, 0, 0, 2}
 }
 
+// Describes the quantiles of SUMMARY kind metrics.
+type Params_MetricInfo_SummaryDefinition struct {
+	// The quantiles to estimate. Defaults to the 0.5, 0.9 and 0.99 quantiles, with errors of 0.05, 0.01
+	// and 0.001 respectively.
+	Objectives []*Params_MetricInfo_SummaryDefinition_Objective `protobuf:"bytes,1,rep,name=objectives" json:"objectives,omitempty"`
+	// Duration for which an observation is included in the quantiles. Defaults to 10 minutes.
+	MaxAge time.Duration `protobuf:"bytes,2,opt,name=max_age,json=maxAge,stdduration" json:"max_age"`
+	// Number of buckets used to exclude the observations older than `max_age` from the quantiles.
+	// Defaults to 5.
+	AgeBuckets uint32 `protobuf:"varint,3,opt,name=age_buckets,json=ageBuckets,proto3" json:"age_buckets,omitempty"`
+}
+
+func (m *Params_MetricInfo_SummaryDefinition) Reset()      { *m = Params_MetricInfo_SummaryDefinition{} }
+func (*Params_MetricInfo_SummaryDefinition) ProtoMessage() {}
+func (*Params_MetricInfo_SummaryDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfig, []int{0, 0, 1}
+}
+
+// A quantile to estimate, with its allowed absolute error.
+type Params_MetricInfo_SummaryDefinition_Objective struct {
+	// Must be in the (0, 1) range.
+	Quantile float64 `protobuf:"fixed64,1,opt,name=quantile,proto3" json:"quantile,omitempty"`
+	// Must be in the [0, 1] range.
+	Error float64 `protobuf:"fixed64,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *Params_MetricInfo_SummaryDefinition_Objective) Reset() {
+	*m = Params_MetricInfo_SummaryDefinition_Objective{}
+}
+func (*Params_MetricInfo_SummaryDefinition_Objective) ProtoMessage() {}
+func (*Params_MetricInfo_SummaryDefinition_Objective) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfig, []int{0, 0, 1, 0}
+}
+
 func init() {
 	proto.RegisterType((*Params)(nil), "adapter.prometheus.config.Params")
 	proto.RegisterType((*Params_MetricInfo)(nil), "adapter.prometheus.config.Params.MetricInfo")
@@ -352,6 +404,8 @@ func init() {
 	proto.RegisterType((*Params_MetricInfo_BucketsDefinition_Linear)(nil), "adapter.prometheus.config.Params.MetricInfo.BucketsDefinition.Linear")
 	proto.RegisterType((*Params_MetricInfo_BucketsDefinition_Exponential)(nil), "adapter.prometheus.config.Params.MetricInfo.BucketsDefinition.Exponential")
 	proto.RegisterType((*Params_MetricInfo_BucketsDefinition_Explicit)(nil), "adapter.prometheus.config.Params.MetricInfo.BucketsDefinition.Explicit")
+	proto.RegisterType((*Params_MetricInfo_SummaryDefinition)(nil), "adapter.prometheus.config.Params.MetricInfo.SummaryDefinition")
+	proto.RegisterType((*Params_MetricInfo_SummaryDefinition_Objective)(nil), "adapter.prometheus.config.Params.MetricInfo.SummaryDefinition.Objective")
 	proto.RegisterEnum("adapter.prometheus.config.Params_MetricInfo_Kind", Params_MetricInfo_Kind_name, Params_MetricInfo_Kind_value)
 }
 func (x Params_MetricInfo_Kind) String() string {
@@ -454,6 +508,29 @@ func (m *Params_MetricInfo) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if m.Summary != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.Summary.Size()))
+		n2, err := m.Summary.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	dAtA[i] = 0x42
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Expiration)))
+	n3, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Expiration, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n3
+	if m.MaxSeries != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxSeries))
+	}
 	return i, nil
 }
 
@@ -473,11 +550,11 @@ func (m *Params_MetricInfo_BucketsDefinition) MarshalTo(dAtA []byte) (int, error
 	var l int
 	_ = l
 	if m.Definition != nil {
-		nn2, err := m.Definition.MarshalTo(dAtA[i:])
+		nn4, err := m.Definition.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn2
+		i += nn4
 	}
 	return i, nil
 }
@@ -488,11 +565,11 @@ func (m *Params_MetricInfo_BucketsDefinition_LinearBuckets) MarshalTo(dAtA []byt
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintConfig(dAtA, i, uint64(m.LinearBuckets.Size()))
-		n3, err := m.LinearBuckets.MarshalTo(dAtA[i:])
+		n5, err := m.LinearBuckets.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n5
 	}
 	return i, nil
 }
@@ -502,11 +579,11 @@ func (m *Params_MetricInfo_BucketsDefinition_ExponentialBuckets) MarshalTo(dAtA
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintConfig(dAtA, i, uint64(m.ExponentialBuckets.Size()))
-		n4, err := m.ExponentialBuckets.MarshalTo(dAtA[i:])
+		n6, err := m.ExponentialBuckets.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n6
 	}
 	return i, nil
 }
@@ -516,11 +593,11 @@ func (m *Params_MetricInfo_BucketsDefinition_ExplicitBuckets) MarshalTo(dAtA []b
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintConfig(dAtA, i, uint64(m.ExplicitBuckets.Size()))
-		n5, err := m.ExplicitBuckets.MarshalTo(dAtA[i:])
+		n7, err := m.ExplicitBuckets.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n7
 	}
 	return i, nil
 }
@@ -614,14 +691,87 @@ func (m *Params_MetricInfo_BucketsDefinition_Explicit) MarshalTo(dAtA []byte) (i
 		i++
 		i = encodeVarintConfig(dAtA, i, uint64(len(m.Bounds)*8))
 		for _, num := range m.Bounds {
-			f6 := math.Float64bits(float64(num))
-			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f6))
+			f8 := math.Float64bits(float64(num))
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f8))
 			i += 8
 		}
 	}
 	return i, nil
 }
 
+func (m *Params_MetricInfo_SummaryDefinition) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_MetricInfo_SummaryDefinition) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Objectives) > 0 {
+		for _, msg := range m.Objectives {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintConfig(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxAge)))
+	n9, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxAge, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
+	if m.AgeBuckets != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.AgeBuckets))
+	}
+	return i, nil
+}
+
+func (m *Params_MetricInfo_SummaryDefinition_Objective) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_MetricInfo_SummaryDefinition_Objective) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Quantile != 0 {
+		dAtA[i] = 0x9
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Quantile))))
+		i += 8
+	}
+	if m.Error != 0 {
+		dAtA[i] = 0x11
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Error))))
+		i += 8
+	}
+	return i, nil
+}
+
 func encodeVarintConfig(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -671,6 +821,15 @@ func (m *Params_MetricInfo) Size() (n int) {
 			n += 1 + l + sovConfig(uint64(l))
 		}
 	}
+	if m.Summary != nil {
+		l = m.Summary.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Expiration)
+	n += 1 + l + sovConfig(uint64(l))
+	if m.MaxSeries != 0 {
+		n += 1 + sovConfig(uint64(m.MaxSeries))
+	}
 	return n
 }
 
@@ -749,6 +908,35 @@ func (m *Params_MetricInfo_BucketsDefinition_Explicit) Size() (n int) {
 	return n
 }
 
+func (m *Params_MetricInfo_SummaryDefinition) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Objectives) > 0 {
+		for _, e := range m.Objectives {
+			l = e.Size()
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxAge)
+	n += 1 + l + sovConfig(uint64(l))
+	if m.AgeBuckets != 0 {
+		n += 1 + sovConfig(uint64(m.AgeBuckets))
+	}
+	return n
+}
+
+func (m *Params_MetricInfo_SummaryDefinition_Objective) Size() (n int) {
+	var l int
+	_ = l
+	if m.Quantile != 0 {
+		n += 9
+	}
+	if m.Error != 0 {
+		n += 9
+	}
+	return n
+}
+
 func sovConfig(x uint64) (n int) {
 	for {
 		n++
@@ -783,6 +971,9 @@ func (this *Params_MetricInfo) String() string {
 		`Kind:` + fmt.Sprintf("%v", this.Kind) + `,`,
 		`Buckets:` + strings.Replace(fmt.Sprintf("%v", this.Buckets), "Params_MetricInfo_BucketsDefinition", "Params_MetricInfo_BucketsDefinition", 1) + `,`,
 		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
+		`Summary:` + strings.Replace(fmt.Sprintf("%v", this.Summary), "Params_MetricInfo_SummaryDefinition", "Params_MetricInfo_SummaryDefinition", 1) + `,`,
+		`Expiration:` + strings.Replace(strings.Replace(this.Expiration.String(), "Duration", "google_protobuf1.Duration", 1), `&`, ``, 1) + `,`,
+		`MaxSeries:` + fmt.Sprintf("%v", this.MaxSeries) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -861,6 +1052,29 @@ func (this *Params_MetricInfo_BucketsDefinition_Explicit) String() string {
 	}, "")
 	return s
 }
+func (this *Params_MetricInfo_SummaryDefinition) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_MetricInfo_SummaryDefinition{`,
+		`Objectives:` + strings.Replace(fmt.Sprintf("%v", this.Objectives), "Params_MetricInfo_SummaryDefinition_Objective", "Params_MetricInfo_SummaryDefinition_Objective", 1) + `,`,
+		`MaxAge:` + strings.Replace(strings.Replace(this.MaxAge.String(), "Duration", "google_protobuf1.Duration", 1), `&`, ``, 1) + `,`,
+		`AgeBuckets:` + fmt.Sprintf("%v", this.AgeBuckets) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Params_MetricInfo_SummaryDefinition_Objective) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_MetricInfo_SummaryDefinition_Objective{`,
+		`Quantile:` + fmt.Sprintf("%v", this.Quantile) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringConfig(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -1147,6 +1361,88 @@ func (m *Params_MetricInfo) Unmarshal(dAtA []byte) error {
 			}
 			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Summary", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Summary == nil {
+				m.Summary = &Params_MetricInfo_SummaryDefinition{}
+			}
+			if err := m.Summary.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expiration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Expiration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSeries", wireType)
+			}
+			m.MaxSeries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSeries |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -1592,6 +1888,208 @@ func (m *Params_MetricInfo_BucketsDefinition_Explicit) Unmarshal(dAtA []byte) er
 	}
 	return nil
 }
+func (m *Params_MetricInfo_SummaryDefinition) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SummaryDefinition: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SummaryDefinition: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Objectives", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Objectives = append(m.Objectives, &Params_MetricInfo_SummaryDefinition_Objective{})
+			if err := m.Objectives[len(m.Objectives)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAge", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MaxAge, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AgeBuckets", wireType)
+			}
+			m.AgeBuckets = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AgeBuckets |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params_MetricInfo_SummaryDefinition_Objective) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Objective: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Objective: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quantile", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Quantile = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Error = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipConfig(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1700,44 +2198,56 @@ var (
 func init() { proto.RegisterFile("mixer/adapter/prometheus/config/config.proto", fileDescriptorConfig) }
 
 var fileDescriptorConfig = []byte{
-	// 612 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xbf, 0x4f, 0xdb, 0x4e,
-	0x14, 0xf7, 0x91, 0x5f, 0xf0, 0x1c, 0xc0, 0xdf, 0xfb, 0xa2, 0x2a, 0xcd, 0x70, 0x8d, 0x60, 0xc9,
-	0x80, 0x1c, 0x95, 0x2e, 0x9d, 0x2a, 0x11, 0x70, 0x20, 0xfd, 0x11, 0xd0, 0x41, 0xa4, 0xaa, 0x4b,
-	0xe4, 0xd8, 0x97, 0x70, 0x22, 0xbe, 0x8b, 0xec, 0x4b, 0x61, 0xa9, 0xd4, 0xb1, 0x63, 0xff, 0x8c,
-	0xfe, 0x29, 0xa8, 0x13, 0x63, 0xc7, 0xc6, 0x5d, 0x3a, 0xb2, 0x75, 0xad, 0x7c, 0xb6, 0x81, 0xaa,
-	0xea, 0x80, 0x32, 0xe5, 0xde, 0xbb, 0xcf, 0xaf, 0xf7, 0xa2, 0x33, 0x6c, 0x07, 0xfc, 0x92, 0x85,
-	0x2d, 0xd7, 0x77, 0xa7, 0x8a, 0x85, 0xad, 0x69, 0x28, 0x03, 0xa6, 0xce, 0xd8, 0x2c, 0x6a, 0x79,
-	0x52, 0x8c, 0xf8, 0x38, 0xfb, 0xb1, 0xa7, 0xa1, 0x54, 0x12, 0x3f, 0xce, 0x70, 0xf6, 0x1d, 0xce,
-	0x4e, 0x01, 0xf5, 0x8d, 0xb1, 0x1c, 0x4b, 0x8d, 0x6a, 0x25, 0xa7, 0x94, 0xb0, 0xf9, 0x75, 0x19,
-	0xca, 0xc7, 0x6e, 0xe8, 0x06, 0x11, 0xee, 0x40, 0x25, 0x60, 0x2a, 0xe4, 0x5e, 0x54, 0x43, 0x8d,
-	0x42, 0xd3, 0xdc, 0xd9, 0xb6, 0xff, 0xa9, 0x66, 0xa7, 0x1c, 0xfb, 0x8d, 0x26, 0x74, 0xc5, 0x48,
-	0xd2, 0x9c, 0x5c, 0xff, 0x55, 0x01, 0xb8, 0xeb, 0x63, 0x0c, 0x45, 0xe1, 0x06, 0xac, 0x86, 0x1a,
-	0xa8, 0xb9, 0x42, 0xf5, 0x19, 0x6f, 0xc1, 0x2a, 0x17, 0x91, 0x72, 0x85, 0xc7, 0x06, 0xfa, 0x72,
-	0x49, 0x5f, 0x56, 0xf3, 0x66, 0x2f, 0x01, 0x35, 0xc0, 0xf4, 0x59, 0xe4, 0x85, 0x7c, 0xaa, 0xb8,
-	0x14, 0xb5, 0x82, 0x86, 0xdc, 0x6f, 0x61, 0x07, 0x8a, 0xe7, 0x5c, 0xf8, 0xb5, 0x62, 0x03, 0x35,
-	0xd7, 0x76, 0x9e, 0x3e, 0x24, 0xae, 0xfd, 0x8a, 0x0b, 0x9f, 0x6a, 0x3a, 0x7e, 0x0b, 0x95, 0xe1,
-	0xcc, 0x3b, 0x67, 0x2a, 0xaa, 0x95, 0x1a, 0xa8, 0x69, 0xee, 0xbc, 0x78, 0x90, 0x52, 0x3b, 0xe5,
-	0xee, 0xb3, 0x11, 0x17, 0x3c, 0xc9, 0x45, 0x73, 0x39, 0xfc, 0x04, 0xcc, 0x89, 0x3b, 0x64, 0x13,
-	0x3d, 0x64, 0x54, 0x2b, 0x37, 0x0a, 0xcd, 0x15, 0x0a, 0xba, 0x95, 0x8c, 0x18, 0xd5, 0x3f, 0x95,
-	0xe0, 0xbf, 0xbf, 0xf8, 0x58, 0xc0, 0xda, 0x84, 0x0b, 0xe6, 0x86, 0x83, 0x3c, 0x17, 0xd2, 0xb9,
-	0x9c, 0xc5, 0x72, 0xd9, 0xaf, 0xb5, 0xe8, 0xa1, 0x41, 0x57, 0x53, 0xf9, 0x0c, 0x81, 0x3f, 0xc0,
-	0xff, 0xec, 0x72, 0x2a, 0x05, 0x13, 0x8a, 0xbb, 0x93, 0x5b, 0xd3, 0x25, 0x6d, 0xfa, 0x72, 0x41,
-	0x53, 0xe7, 0x4e, 0xf9, 0xd0, 0xa0, 0xf8, 0x9e, 0x51, 0x6e, 0xaf, 0xc0, 0x62, 0x97, 0xd3, 0x09,
-	0xf7, 0xb8, 0xba, 0xf5, 0x2e, 0x68, 0xef, 0x83, 0xc5, 0xbd, 0xb5, 0xec, 0xa1, 0x41, 0xd7, 0x73,
-	0x8b, 0x0c, 0x55, 0xf7, 0xa1, 0x9c, 0xee, 0x03, 0x6f, 0x03, 0x16, 0xb3, 0x60, 0xa0, 0x59, 0xec,
-	0x8f, 0x95, 0x97, 0xa8, 0x25, 0x66, 0x41, 0x47, 0x5f, 0xe4, 0x69, 0x37, 0xa0, 0x74, 0xc1, 0x7d,
-	0x75, 0xa6, 0xd7, 0x83, 0x68, 0x5a, 0xe0, 0x47, 0x50, 0x96, 0xa3, 0x51, 0xc4, 0x94, 0x4e, 0x8e,
-	0x68, 0x56, 0xd5, 0xdf, 0x83, 0x79, 0x6f, 0x01, 0x0f, 0xb4, 0xda, 0x82, 0xd5, 0x71, 0x28, 0x2f,
-	0xd4, 0xd9, 0x60, 0xe4, 0x7a, 0x4a, 0x86, 0x99, 0x65, 0x35, 0x6d, 0x76, 0x74, 0x2f, 0xc9, 0x13,
-	0x79, 0xee, 0x84, 0x65, 0xc6, 0x69, 0x51, 0xdf, 0x84, 0xe5, 0x7c, 0xf8, 0x24, 0xdb, 0x50, 0xce,
-	0x84, 0x9f, 0xbe, 0x6b, 0x44, 0xb3, 0xaa, 0x5d, 0x05, 0xf0, 0x6f, 0x77, 0xb5, 0xb9, 0x0b, 0xc5,
-	0xe4, 0x4d, 0xe0, 0x75, 0x30, 0xfb, 0xbd, 0x93, 0x63, 0x67, 0xaf, 0xdb, 0xe9, 0x3a, 0xfb, 0x96,
-	0x81, 0x57, 0xa0, 0x74, 0xb0, 0xdb, 0x3f, 0x70, 0x2c, 0x84, 0x4d, 0xa8, 0xec, 0x1d, 0xf5, 0x7b,
-	0xa7, 0x0e, 0xb5, 0x96, 0xb0, 0x05, 0xd5, 0xfd, 0xee, 0xc9, 0x29, 0xed, 0xb6, 0xfb, 0xa7, 0xdd,
-	0xa3, 0x9e, 0x55, 0x68, 0x3f, 0xbf, 0x9a, 0x13, 0xe3, 0x7a, 0x4e, 0x8c, 0x6f, 0x73, 0x62, 0xdc,
-	0xcc, 0x89, 0xf1, 0x31, 0x26, 0xe8, 0x4b, 0x4c, 0x8c, 0xab, 0x98, 0xa0, 0xeb, 0x98, 0xa0, 0xef,
-	0x31, 0x41, 0x3f, 0x63, 0x62, 0xdc, 0xc4, 0x04, 0x7d, 0xfe, 0x41, 0x8c, 0x77, 0xe5, 0xf4, 0xcf,
-	0x1c, 0x96, 0xf5, 0xd7, 0xe8, 0xd9, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2f, 0xc4, 0xb1, 0x23,
-	0xee, 0x04, 0x00, 0x00,
+	// 810 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x6f, 0xe3, 0x44,
+	0x18, 0xf6, 0xe4, 0xb3, 0x79, 0x93, 0xee, 0x66, 0x87, 0x15, 0xf2, 0x5a, 0xc2, 0x8d, 0xba, 0x97,
+	0x1c, 0x2a, 0x47, 0x94, 0x0b, 0x07, 0x40, 0x6a, 0xda, 0xb4, 0x0d, 0xd0, 0x74, 0x35, 0x69, 0x24,
+	0xe0, 0x12, 0x4d, 0xec, 0x89, 0x3b, 0xac, 0x3d, 0x0e, 0xf6, 0x78, 0x37, 0x1c, 0x90, 0x38, 0x72,
+	0xe4, 0x88, 0xc4, 0x1f, 0xe0, 0xce, 0x9f, 0xe8, 0x71, 0x8f, 0x9c, 0x60, 0x1b, 0x2e, 0x1c, 0xf7,
+	0x27, 0x20, 0xcf, 0xd8, 0x69, 0x56, 0x15, 0x12, 0xdd, 0x9e, 0xe2, 0xf7, 0xe3, 0x79, 0x9e, 0x77,
+	0x9e, 0xcc, 0xbc, 0xb0, 0x17, 0xf2, 0x25, 0x8b, 0x7b, 0xd4, 0xa3, 0x0b, 0xc9, 0xe2, 0xde, 0x22,
+	0x8e, 0x42, 0x26, 0x2f, 0x59, 0x9a, 0xf4, 0xdc, 0x48, 0xcc, 0xb9, 0x9f, 0xff, 0x38, 0x8b, 0x38,
+	0x92, 0x11, 0x7e, 0x92, 0xf7, 0x39, 0x37, 0x7d, 0x8e, 0x6e, 0xb0, 0x1e, 0xfb, 0x91, 0x1f, 0xa9,
+	0xae, 0x5e, 0xf6, 0xa5, 0x01, 0x96, 0xed, 0x47, 0x91, 0x1f, 0xb0, 0x9e, 0x8a, 0x66, 0xe9, 0xbc,
+	0xe7, 0xa5, 0x31, 0x95, 0x3c, 0x12, 0xba, 0xbe, 0xfb, 0x7b, 0x0b, 0x6a, 0xcf, 0x68, 0x4c, 0xc3,
+	0x04, 0x1f, 0x43, 0x3d, 0x64, 0x32, 0xe6, 0x6e, 0x62, 0xa2, 0x4e, 0xb9, 0xdb, 0xdc, 0xdf, 0x73,
+	0xfe, 0x53, 0xcd, 0xd1, 0x18, 0xe7, 0x4c, 0x01, 0x86, 0x62, 0x1e, 0x91, 0x02, 0x6c, 0xbd, 0x6e,
+	0x02, 0xdc, 0xe4, 0x31, 0x86, 0x8a, 0xa0, 0x21, 0x33, 0x51, 0x07, 0x75, 0x1b, 0x44, 0x7d, 0xe3,
+	0xa7, 0xb0, 0xcd, 0x45, 0x22, 0xa9, 0x70, 0xd9, 0x54, 0x15, 0x4b, 0xaa, 0xd8, 0x2a, 0x92, 0xa3,
+	0xac, 0xa9, 0x03, 0x4d, 0x8f, 0x25, 0x6e, 0xcc, 0x17, 0xd9, 0xbc, 0x66, 0x59, 0xb5, 0x6c, 0xa6,
+	0xf0, 0x00, 0x2a, 0xcf, 0xb9, 0xf0, 0xcc, 0x4a, 0x07, 0x75, 0x1f, 0xec, 0x7f, 0x78, 0x97, 0x71,
+	0x9d, 0x2f, 0xb8, 0xf0, 0x88, 0x82, 0xe3, 0xaf, 0xa0, 0x3e, 0x4b, 0xdd, 0xe7, 0x4c, 0x26, 0x66,
+	0xb5, 0x83, 0xba, 0xcd, 0xfd, 0xcf, 0xee, 0xc4, 0xd4, 0xd7, 0xd8, 0x23, 0x36, 0xe7, 0x82, 0x67,
+	0x73, 0x91, 0x82, 0x0e, 0xef, 0x40, 0x33, 0xa0, 0x33, 0x16, 0xa8, 0x43, 0x26, 0x66, 0xad, 0x53,
+	0xee, 0x36, 0x08, 0xa8, 0x54, 0x76, 0xc4, 0x24, 0x93, 0x4e, 0xd2, 0x30, 0xa4, 0xf1, 0xf7, 0x66,
+	0xfd, 0x1d, 0xa4, 0xc7, 0x1a, 0xbb, 0x29, 0x9d, 0xd3, 0xe1, 0x43, 0x00, 0xb6, 0x5c, 0x70, 0xfd,
+	0x67, 0x9b, 0x5b, 0x8a, 0xfc, 0x89, 0xa3, 0x6f, 0x83, 0x53, 0xdc, 0x06, 0xe7, 0x28, 0xbf, 0x0d,
+	0xfd, 0xad, 0xab, 0x3f, 0x77, 0x8c, 0x5f, 0xfe, 0xda, 0x41, 0x64, 0x03, 0x86, 0x3f, 0x00, 0x08,
+	0xe9, 0x72, 0x9a, 0xb0, 0x98, 0xb3, 0xc4, 0x6c, 0x74, 0x50, 0xb7, 0x4a, 0x1a, 0x21, 0x5d, 0x8e,
+	0x55, 0xc2, 0xfa, 0xa9, 0x0a, 0x8f, 0x6e, 0x9d, 0x1e, 0x0b, 0x78, 0x10, 0x70, 0xc1, 0x68, 0x3c,
+	0x2d, 0x5c, 0x45, 0x4a, 0x7d, 0x70, 0x3f, 0x57, 0x9d, 0x2f, 0x15, 0xe9, 0xa9, 0x41, 0xb6, 0x35,
+	0x7d, 0xde, 0x81, 0x7f, 0x80, 0xf7, 0xd8, 0x72, 0x11, 0x09, 0x26, 0x24, 0xa7, 0xc1, 0x5a, 0xb4,
+	0xa4, 0x44, 0x3f, 0xbf, 0xa7, 0xe8, 0xe0, 0x86, 0xf9, 0xd4, 0x20, 0x78, 0x43, 0xa8, 0x90, 0x97,
+	0xd0, 0x66, 0xcb, 0x45, 0xc0, 0x5d, 0x2e, 0xd7, 0xda, 0x65, 0xa5, 0x7d, 0x72, 0x7f, 0x6d, 0x45,
+	0x7b, 0x6a, 0x90, 0x87, 0x85, 0x44, 0xde, 0x65, 0x79, 0x50, 0xd3, 0x7e, 0xe0, 0x3d, 0xc0, 0x22,
+	0x0d, 0xa7, 0x0a, 0xc5, 0xde, 0xb2, 0xbc, 0x4a, 0xda, 0x22, 0x0d, 0x8f, 0x55, 0xa1, 0x98, 0xf6,
+	0x31, 0x54, 0x5f, 0x72, 0x4f, 0x5e, 0x2a, 0x7b, 0x10, 0xd1, 0x01, 0x7e, 0x1f, 0x6a, 0xd1, 0x7c,
+	0x9e, 0x30, 0xa9, 0x26, 0x47, 0x24, 0x8f, 0xac, 0x17, 0xd0, 0xdc, 0x30, 0xe0, 0x8e, 0x52, 0x4f,
+	0x61, 0xdb, 0x8f, 0xa3, 0x97, 0xf2, 0x72, 0x3a, 0xa7, 0xae, 0x8c, 0xe2, 0x5c, 0xb2, 0xa5, 0x93,
+	0xc7, 0x2a, 0x97, 0xcd, 0x93, 0xb8, 0x34, 0x60, 0xb9, 0xb0, 0x0e, 0xac, 0x5d, 0xd8, 0x2a, 0x0e,
+	0x9f, 0xcd, 0x36, 0x8b, 0x52, 0xe1, 0xe9, 0xad, 0x84, 0x48, 0x1e, 0xf5, 0x5b, 0x00, 0xde, 0xda,
+	0x2b, 0xeb, 0xd7, 0x12, 0x3c, 0xba, 0xf5, 0x1a, 0xf0, 0x25, 0x40, 0x34, 0xfb, 0x96, 0xb9, 0x92,
+	0xbf, 0x60, 0xc5, 0x56, 0x3b, 0xbd, 0xdf, 0x0b, 0x73, 0xce, 0x0b, 0x42, 0xb2, 0xc1, 0x8d, 0x3f,
+	0x81, 0x7a, 0xf6, 0x52, 0xa8, 0xcf, 0xcc, 0xd2, 0xff, 0x7f, 0x6b, 0xb5, 0x90, 0x2e, 0x0f, 0x7c,
+	0x96, 0xed, 0x09, 0xea, 0xb3, 0xb7, 0xae, 0xcf, 0x36, 0x01, 0xea, 0x17, 0x5e, 0x5a, 0x9f, 0x42,
+	0x63, 0xad, 0x8b, 0x2d, 0xd8, 0xfa, 0x2e, 0xa5, 0x42, 0xf2, 0x40, 0x6f, 0x55, 0x44, 0xd6, 0x71,
+	0xe6, 0x27, 0x8b, 0xe3, 0xb5, 0xd9, 0x3a, 0xd8, 0x1d, 0x41, 0x25, 0xdb, 0x77, 0xf8, 0x21, 0x34,
+	0x27, 0xa3, 0xf1, 0xb3, 0xc1, 0xe1, 0xf0, 0x78, 0x38, 0x38, 0x6a, 0x1b, 0xb8, 0x01, 0xd5, 0x93,
+	0x83, 0xc9, 0xc9, 0xa0, 0x8d, 0x70, 0x13, 0xea, 0x87, 0xe7, 0x93, 0xd1, 0xc5, 0x80, 0xb4, 0x4b,
+	0xb8, 0x0d, 0xad, 0xa3, 0xe1, 0xf8, 0x82, 0x0c, 0xfb, 0x93, 0x8b, 0xe1, 0xf9, 0xa8, 0x5d, 0xce,
+	0xca, 0xe3, 0xc9, 0xd9, 0xd9, 0x01, 0xf9, 0xba, 0x5d, 0xe9, 0x7f, 0x7c, 0x75, 0x6d, 0x1b, 0xaf,
+	0xae, 0x6d, 0xe3, 0x8f, 0x6b, 0xdb, 0x78, 0x73, 0x6d, 0x1b, 0x3f, 0xae, 0x6c, 0xf4, 0xdb, 0xca,
+	0x36, 0xae, 0x56, 0x36, 0x7a, 0xb5, 0xb2, 0xd1, 0xeb, 0x95, 0x8d, 0xfe, 0x59, 0xd9, 0xc6, 0x9b,
+	0x95, 0x8d, 0x7e, 0xfe, 0xdb, 0x36, 0xbe, 0xa9, 0x69, 0x87, 0x67, 0x35, 0x65, 0xc7, 0x47, 0xff,
+	0x0e, 0x00, 0x6a, 0x04, 0x72, 0x60, 0xf7, 0x06, 0x00, 0x00,
 }
@@ -45,6 +45,11 @@ type (
 		c    prometheus.Collector
 		sha  [sha1.Size]byte
 		kind config.Params_MetricInfo_Kind
+
+		// fully qualified name of the metric.
+		name string
+		// nil if the label sets of the metric neither expire nor are capped.
+		series *seriesTracker
 	}
 
 	builder struct {
@@ -58,12 +63,34 @@ type (
 	handler struct {
 		srv     server
 		metrics map[string]*cinfo
+
+		// closed when the handler is closed, to stop expiring label sets.
+		done chan struct{}
+	}
+
+	// deleter is implemented by the metric vectors, to remove expired label sets.
+	deleter interface {
+		Delete(labels prometheus.Labels) bool
 	}
 )
 
 var (
 	charReplacer = strings.NewReplacer("/", "_", ".", "_", " ", "_", "-", "")
 
+	// seriesOverflow counts the values dropped because their metric reached its max series.
+	seriesOverflow = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "mixer_prometheus",
+			Name:      "series_overflow_total",
+			Help:      "Number of values dropped because their metric reached its maximum number of label sets.",
+		},
+		[]string{"metric"},
+	)
+
+	// defaultObjectives are the quantiles of SUMMARY kind metrics that do not specify them.
+	defaultObjectives = map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
+
 	_ metric.HandlerBuilder = &builder{}
 	_ metric.Handler        = &handler{}
 )
@@ -101,135 +128,265 @@ func GetInfo() adapter.Info {
 
 func (b *builder) clearState() {
 	b.registry = prometheus.NewPedanticRegistry()
+	b.registry.MustRegister(seriesOverflow)
 	b.metrics = make(map[string]*cinfo)
 }
 
 func (b *builder) SetMetricTypes(map[string]*metric.Type) {}
 func (b *builder) SetAdapterConfig(cfg adapter.Config)    { b.cfg = cfg.(*config.Params) }
-func (b *builder) Validate() *adapter.ConfigErrors        { return nil }
+func (b *builder) Validate() (ce *adapter.ConfigErrors) {
+	for _, m := range b.cfg.Metrics {
+		if m.Expiration < 0 {
+			ce = ce.Appendf("metrics.expiration", "metric %s: expiration must be >= 0, got %v", m.InstanceName, m.Expiration)
+		}
+		if m.MaxSeries < 0 {
+			ce = ce.Appendf("metrics.maxSeries", "metric %s: max series must be >= 0, got %d", m.InstanceName, m.MaxSeries)
+		}
+		if m.Kind != config.SUMMARY || m.Summary == nil {
+			continue
+		}
+		for _, o := range m.Summary.Objectives {
+			if o.Quantile <= 0 || o.Quantile >= 1 {
+				ce = ce.Appendf("metrics.summary.objectives", "metric %s: quantile must be in (0, 1), got %v",
+					m.InstanceName, o.Quantile)
+			}
+			if o.Error < 0 || o.Error > 1 {
+				ce = ce.Appendf("metrics.summary.objectives", "metric %s: error must be in [0, 1], got %v",
+					m.InstanceName, o.Error)
+			}
+		}
+		if m.Summary.MaxAge < 0 {
+			ce = ce.Appendf("metrics.summary.maxAge", "metric %s: max age must be >= 0, got %v", m.InstanceName, m.Summary.MaxAge)
+		}
+	}
+	return
+}
+
 func (b *builder) Build(ctx context.Context, env adapter.Env) (adapter.Handler, error) {
 
 	cfg := b.cfg
 	var metricErr *multierror.Error
 
-	// newMetrics collects new metric configuration
-	newMetrics := make([]*config.Params_MetricInfo, 0, len(cfg.Metrics))
-
-	// Check for metric redefinition.
-	// If a metric is redefined clear the metric registry and metric map.
-	// prometheus client panics on metric redefinition.
-	// Addition and removal of metrics is ok.
-	var cl *cinfo
+	// The collectors of all the handlers are registered with the registry of the builder, which the
+	// server exports. Collectors whose configuration is unchanged are reused along with their series,
+	// while the collectors of redefined metrics, for example with different buckets, are replaced.
+	metrics := make(map[string]*cinfo, len(cfg.Metrics))
+	newMetrics := 0
 	for _, m := range cfg.Metrics {
-		// metric is not found in the current metric table
-		// should be added.
-		if cl = b.metrics[m.InstanceName]; cl == nil {
-			newMetrics = append(newMetrics, m)
+		sha := computeSha(m, env.Logger())
+
+		// the existing collector is safe to reuse
+		// if the sha of its config matches.
+		ci := b.metrics[m.InstanceName]
+		if _, found := metrics[m.InstanceName]; found {
+			// the metric is defined twice, the second definition is registered
+			// as a new metric, which fails if it conflicts with the first one.
+			ci = nil
+		}
+		if ci != nil && ci.sha == sha {
+			metrics[m.InstanceName] = ci
 			continue
 		}
+		redefined := ci != nil
+		if redefined {
+			env.Logger().Warningf("Metric %s redefined. Replacing its collector.", m.InstanceName)
+			b.unregister(m.InstanceName, ci)
+			delete(b.metrics, m.InstanceName)
+		}
 
-		// metric collector found and sha matches
-		// safe to reuse the existing collector.
-		if cl.sha == computeSha(m, env.Logger()) {
+		ci, err := newCinfo(m, sha)
+		if err != nil {
+			metricErr = multierror.Append(metricErr, err)
 			continue
 		}
-
-		// sha does not match.
-		env.Logger().Warningf("Metric %s redefined. Reloading adapter.", m.Name)
-		b.clearState()
-		// consider all configured metrics to be "new".
-		newMetrics = cfg.Metrics
-		break
+		newMetrics++
+
+		// TODO: make prometheus use the keys of metric.Type.Dimensions as the label names and remove from config.
+		c, err := registerOrGet(b.registry, ci.c)
+		if err != nil && redefined {
+			// the registry keeps the label names and help of unregistered metrics,
+			// a metric redefined with different ones needs a new registry.
+			b.rebuildRegistry()
+			c, err = registerOrGet(b.registry, ci.c)
+		}
+		if err != nil {
+			metricErr = multierror.Append(metricErr, fmt.Errorf("could not register metric: %v", err))
+			continue
+		}
+		if c != ci.c {
+			// an identical metric is defined for another instance, share its collector
+			// along with the tracking of its label sets.
+			shared := *ci
+			shared.c = c
+			shared.series = b.seriesOf(c)
+			ci = &shared
+		}
+		b.metrics[m.InstanceName] = ci
+		metrics[m.InstanceName] = ci
 	}
 
 	if env.Logger().VerbosityLevel(4) {
-		env.Logger().Infof("%d new metrics defined", len(newMetrics))
+		env.Logger().Infof("%d new metrics defined", newMetrics)
+	}
+
+	if err := b.srv.Start(env, promhttp.HandlerFor(b.registry, promhttp.HandlerOpts{})); err != nil {
+		return nil, err
 	}
 
-	var err error
-	for _, m := range newMetrics {
-		mname := m.InstanceName
-		if len(m.Name) != 0 {
-			mname = m.Name
+	h := &handler{srv: b.srv, metrics: metrics, done: make(chan struct{})}
+	if interval := expiryCheckInterval(metrics); interval > 0 {
+		env.ScheduleDaemon(func() { h.expireSeries(interval) })
+	}
+
+	return h, metricErr.ErrorOrNil()
+}
+
+// unregister removes the collector of the metric of the instance from the registry, unless the metric
+// of another instance shares it.
+func (b *builder) unregister(instance string, ci *cinfo) {
+	for name, other := range b.metrics {
+		if name != instance && other.c == ci.c {
+			return
 		}
-		ci := &cinfo{kind: m.Kind, sha: computeSha(m, env.Logger())}
-		switch m.Kind {
-		case config.GAUGE:
-			// TODO: make prometheus use the keys of metric.Type.Dimensions as the label names and remove from config.
-			ci.c, err = registerOrGet(b.registry, newGaugeVec(mname, m.Description, m.LabelNames))
-			if err != nil {
-				metricErr = multierror.Append(metricErr, fmt.Errorf("could not register metric: %v", err))
-				continue
-			}
-			b.metrics[m.InstanceName] = ci
-		case config.COUNTER:
-			ci.c, err = registerOrGet(b.registry, newCounterVec(mname, m.Description, m.LabelNames))
-			if err != nil {
-				metricErr = multierror.Append(metricErr, fmt.Errorf("could not register metric: %v", err))
-				continue
-			}
-			b.metrics[m.InstanceName] = ci
-		case config.DISTRIBUTION:
-			ci.c, err = registerOrGet(b.registry, newHistogramVec(mname, m.Description, m.LabelNames, m.Buckets))
-			if err != nil {
-				metricErr = multierror.Append(metricErr, fmt.Errorf("could not register metric: %v", err))
-				continue
-			}
-			b.metrics[m.InstanceName] = ci
-		default:
-			metricErr = multierror.Append(metricErr, fmt.Errorf("unknown metric kind (%d); could not register metric %v", m.Kind, m))
+	}
+	b.registry.Unregister(ci.c)
+}
+
+// rebuildRegistry replaces the registry with a new one, holding the collectors of the current metrics.
+func (b *builder) rebuildRegistry() {
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(seriesOverflow)
+	for _, ci := range b.metrics {
+		// the collectors were registered together, so they do not conflict.
+		_, _ = registerOrGet(registry, ci.c)
+	}
+	b.registry = registry
+}
+
+// seriesOf returns the tracker of the label sets of a registered collector. The label sets of a
+// collector are tracked once, with the settings of the metric that created it.
+func (b *builder) seriesOf(c prometheus.Collector) *seriesTracker {
+	for _, ci := range b.metrics {
+		if ci.c == c {
+			return ci.series
 		}
 	}
+	return nil
+}
+
+// newCinfo creates the collector of a metric.
+func newCinfo(m *config.Params_MetricInfo, sha [sha1.Size]byte) (*cinfo, error) {
+	mname := m.InstanceName
+	if len(m.Name) != 0 {
+		mname = m.Name
+	}
 
-	if err := b.srv.Start(env, promhttp.HandlerFor(b.registry, promhttp.HandlerOpts{})); err != nil {
-		return nil, err
+	ci := &cinfo{
+		kind: m.Kind,
+		sha:  sha,
+		name: prometheus.BuildFQName(namespace, "", safeName(mname)),
+	}
+	switch m.Kind {
+	case config.GAUGE:
+		ci.c = newGaugeVec(mname, m.Description, m.LabelNames)
+	case config.COUNTER:
+		ci.c = newCounterVec(mname, m.Description, m.LabelNames)
+	case config.DISTRIBUTION:
+		ci.c = newHistogramVec(mname, m.Description, m.LabelNames, m.Buckets)
+	case config.SUMMARY:
+		ci.c = newSummaryVec(mname, m.Description, m.LabelNames, m.Summary)
+	default:
+		return nil, fmt.Errorf("unknown metric kind (%d); could not register metric %v", m.Kind, m)
 	}
 
-	return &handler{b.srv, b.metrics}, metricErr.ErrorOrNil()
+	if m.Expiration > 0 || m.MaxSeries > 0 {
+		ci.series = newSeriesTracker(m.Expiration, int(m.MaxSeries))
+	}
+	return ci, nil
+}
+
+// expiryCheckInterval returns how often the label sets of the metrics are checked for
+// expiration: half of the shortest expiration, or 0 if none of them expire.
+func expiryCheckInterval(metrics map[string]*cinfo) time.Duration {
+	var interval time.Duration
+	for _, ci := range metrics {
+		if ci.series == nil || ci.series.expiration <= 0 {
+			continue
+		}
+		if interval == 0 || ci.series.expiration < interval {
+			interval = ci.series.expiration
+		}
+	}
+	return interval / 2
 }
 
 func (h *handler) HandleMetric(_ context.Context, vals []*metric.Instance) error {
 	var result *multierror.Error
 
+	now := time.Now()
 	for _, val := range vals {
 		ci := h.metrics[val.Name]
 		if ci == nil {
 			result = multierror.Append(result, fmt.Errorf("could not find metric info from adapter config for %s", val.Name))
 			continue
 		}
+		amt, err := promValue(val.Value)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("could not get value for metric %s: %v", val.Name, err))
+			continue
+		}
+		labels := promLabels(val.Dimensions)
+		if ci.series != nil && !ci.series.track(labels, now) {
+			seriesOverflow.WithLabelValues(ci.name).Inc()
+			continue
+		}
+
 		collector := ci.c
 		switch ci.kind {
 		case config.GAUGE:
 			vec := collector.(*prometheus.GaugeVec)
-			amt, err := promValue(val.Value)
-			if err != nil {
-				result = multierror.Append(result, fmt.Errorf("could not get value for metric %s: %v", val.Name, err))
-				continue
-			}
-			vec.With(promLabels(val.Dimensions)).Set(amt)
+			vec.With(labels).Set(amt)
 		case config.COUNTER:
 			vec := collector.(*prometheus.CounterVec)
-			amt, err := promValue(val.Value)
-			if err != nil {
-				result = multierror.Append(result, fmt.Errorf("could not get value for metric %s: %v", val.Name, err))
-				continue
-			}
-			vec.With(promLabels(val.Dimensions)).Add(amt)
+			vec.With(labels).Add(amt)
 		case config.DISTRIBUTION:
 			vec := collector.(*prometheus.HistogramVec)
-			amt, err := promValue(val.Value)
-			if err != nil {
-				result = multierror.Append(result, fmt.Errorf("could not get value for metric %s: %v", val.Name, err))
-				continue
-			}
-			vec.With(promLabels(val.Dimensions)).Observe(amt)
+			vec.With(labels).Observe(amt)
+		case config.SUMMARY:
+			vec := collector.(*prometheus.SummaryVec)
+			vec.With(labels).Observe(amt)
 		}
 	}
 
 	return result.ErrorOrNil()
 }
 
-func (h *handler) Close() error { return h.srv.Close() }
+// expireSeries periodically removes the expired label sets of the metrics, until the handler is closed.
+func (h *handler) expireSeries(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			h.expire(now)
+		case <-h.done:
+			return
+		}
+	}
+}
+
+func (h *handler) expire(now time.Time) {
+	for _, ci := range h.metrics {
+		if ci.series != nil {
+			ci.series.expire(now, ci.c.(deleter).Delete)
+		}
+	}
+}
+
+func (h *handler) Close() error {
+	close(h.done)
+	return h.srv.Close()
+}
 
 func newCounterVec(name, desc string, labels []string) *prometheus.CounterVec {
 	if desc == "" {
@@ -277,6 +434,29 @@ func newHistogramVec(name, desc string, labels []string, bucketDef *config.Param
 	return c
 }
 
+func newSummaryVec(name, desc string, labels []string, def *config.Params_MetricInfo_SummaryDefinition) *prometheus.SummaryVec {
+	if desc == "" {
+		desc = name
+	}
+	opts := prometheus.SummaryOpts{
+		Namespace:  namespace,
+		Name:       safeName(name),
+		Help:       desc,
+		Objectives: defaultObjectives,
+	}
+	if def != nil {
+		if len(def.Objectives) > 0 {
+			opts.Objectives = make(map[float64]float64, len(def.Objectives))
+			for _, o := range def.Objectives {
+				opts.Objectives[o.Quantile] = o.Error
+			}
+		}
+		opts.MaxAge = def.MaxAge
+		opts.AgeBuckets = def.AgeBuckets
+	}
+	return prometheus.NewSummaryVec(opts, labelNames(labels))
+}
+
 func buckets(def *config.Params_MetricInfo_BucketsDefinition) []float64 {
 	switch def.GetDefinition().(type) {
 	case *config.Params_MetricInfo_BucketsDefinition_ExplicitBuckets:
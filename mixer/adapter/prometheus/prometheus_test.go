@@ -48,11 +48,9 @@ func (testServer) Port() int    { return 0 }
 func (testServer) Close() error { return nil }
 
 func newBuilder(s server) *builder {
-	return &builder{
-		srv:      s,
-		registry: prometheus.NewPedanticRegistry(),
-		metrics:  make(map[string]*cinfo),
-	}
+	b := &builder{srv: s}
+	b.clearState()
+	return b
 }
 
 var (
@@ -133,6 +131,24 @@ var (
 		LabelNames: []string{"bool", "string", "email"},
 	}
 
+	summary = &config.Params_MetricInfo{
+		InstanceName: "summary_of_happiness",
+		Description:  "fun with quantiles",
+		Kind:         config.SUMMARY,
+		LabelNames:   []string{"bool", "string", "email"},
+		Summary: &config.Params_MetricInfo_SummaryDefinition{
+			Objectives: []*config.Params_MetricInfo_SummaryDefinition_Objective{{Quantile: 0.5, Error: 0.05}},
+			MaxAge:     time.Minute,
+			AgeBuckets: 3,
+		},
+	}
+
+	summaryNoLabels = &config.Params_MetricInfo{
+		InstanceName: "summary_of_the_elder",
+		Kind:         config.SUMMARY,
+		LabelNames:   []string{},
+	}
+
 	unknown = &config.Params_MetricInfo{
 		InstanceName: "unknown",
 		Description:  "unknown",
@@ -190,6 +206,7 @@ func TestBuild(t *testing.T) {
 		{"With Labels", []*config.Params_MetricInfo{counter, histogram}},
 		{"counter With Labels modified", []*config.Params_MetricInfo{counterModified}},
 		{"No Descriptions", []*config.Params_MetricInfo{counterNoLabelsNoDesc, gaugeNoLabelsNoDesc, histogramNoLabelsNoDesc}},
+		{"Summaries", []*config.Params_MetricInfo{summary, summaryNoLabels}},
 	}
 
 	for _, v := range tests {
@@ -204,6 +221,47 @@ func TestBuild(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric *config.Params_MetricInfo
+		field  string
+	}{
+		{"negative expiration", &config.Params_MetricInfo{Kind: config.GAUGE, Expiration: -time.Second}, "metrics.expiration"},
+		{"negative max series", &config.Params_MetricInfo{Kind: config.GAUGE, MaxSeries: -1}, "metrics.maxSeries"},
+		{"quantile out of range", &config.Params_MetricInfo{Kind: config.SUMMARY,
+			Summary: &config.Params_MetricInfo_SummaryDefinition{
+				Objectives: []*config.Params_MetricInfo_SummaryDefinition_Objective{{Quantile: 1, Error: 0.01}}}},
+			"metrics.summary.objectives"},
+		{"error out of range", &config.Params_MetricInfo{Kind: config.SUMMARY,
+			Summary: &config.Params_MetricInfo_SummaryDefinition{
+				Objectives: []*config.Params_MetricInfo_SummaryDefinition_Objective{{Quantile: 0.5, Error: -1}}}},
+			"metrics.summary.objectives"},
+		{"negative max age", &config.Params_MetricInfo{Kind: config.SUMMARY,
+			Summary: &config.Params_MetricInfo_SummaryDefinition{MaxAge: -time.Minute}}, "metrics.summary.maxAge"},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			b := newBuilder(&testServer{})
+			b.SetAdapterConfig(makeConfig(v.metric))
+			ce := b.Validate()
+			if ce == nil || len(ce.Multi.Errors) != 1 {
+				t.Fatalf("Validate() => %v; want a single error", ce)
+			}
+			if got := ce.Multi.Errors[0].(adapter.ConfigError).Field; got != v.field {
+				t.Errorf("Validate() => error for field %s; want %s", got, v.field)
+			}
+		})
+	}
+
+	b := newBuilder(&testServer{})
+	b.SetAdapterConfig(makeConfig(counter, histogram, summary, summaryNoLabels))
+	if ce := b.Validate(); ce != nil {
+		t.Errorf("Validate() => unexpected error: %v", ce)
+	}
+}
+
 func TestBucket(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -363,6 +421,8 @@ func TestProm_Record(t *testing.T) {
 		{"String", []*config.Params_MetricInfo{gaugeNoLabels}, []*metric.Instance{newGaugeVal(gaugeVal.Name, "8.243543")}},
 		{"histogram int64", []*config.Params_MetricInfo{histogramNoLabels},
 			[]*metric.Instance{newHistogramVal(histogramNoLabels.InstanceName, int64(8))}},
+		{"summary", []*config.Params_MetricInfo{summary},
+			[]*metric.Instance{newSummaryVal(summary.InstanceName, float64(12.5))}},
 	}
 
 	for _, v := range tests {
@@ -404,6 +464,11 @@ func TestProm_Record(t *testing.T) {
 						t.Errorf("Error writing metric value to proto: %v", err)
 						continue
 					}
+				case *prometheus.SummaryVec:
+					if err := c.(*prometheus.SummaryVec).With(promLabels(adapterVal.Dimensions)).(prometheus.Metric).Write(m); err != nil {
+						t.Errorf("Error writing metric value to proto: %v", err)
+						continue
+					}
 				}
 
 				got := metricValue(m)
@@ -436,6 +501,8 @@ func TestProm_RecordFailures(t *testing.T) {
 			[]*metric.Instance{newCounterVal(counterNoLabels.InstanceName, "not a value")}},
 		{"Text String (Histogram)", []*config.Params_MetricInfo{histogram},
 			[]*metric.Instance{newHistogramVal(histogramVal.Name, "not a value")}},
+		{"Text String (Summary)", []*config.Params_MetricInfo{summary},
+			[]*metric.Instance{newSummaryVal(summary.InstanceName, "not a value")}},
 	}
 
 	for _, v := range tests {
@@ -454,6 +521,238 @@ func TestProm_RecordFailures(t *testing.T) {
 	}
 }
 
+func TestProm_Expiration(t *testing.T) {
+	f := newBuilder(&testServer{})
+	expiring := &config.Params_MetricInfo{
+		InstanceName: "expiring_gauge",
+		Kind:         config.GAUGE,
+		LabelNames:   []string{"pod"},
+		Expiration:   time.Minute,
+	}
+	f.SetAdapterConfig(makeConfig(expiring, gaugeNoLabels))
+	a, err := f.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Build() => unexpected error: %v", err)
+	}
+	defer func() { _ = a.Close() }()
+	h := a.(*handler)
+
+	if got := expiryCheckInterval(h.metrics); got != 30*time.Second {
+		t.Errorf("expiryCheckInterval() => %v; want %v", got, 30*time.Second)
+	}
+
+	start := time.Now()
+	vals := []*metric.Instance{
+		newPodVal(expiring.InstanceName, "a"),
+		newPodVal(expiring.InstanceName, "b"),
+		newGaugeVal(gaugeNoLabels.InstanceName, int64(1)),
+	}
+	if err = h.HandleMetric(context.Background(), vals); err != nil {
+		t.Fatalf("HandleMetric() => unexpected error: %v", err)
+	}
+	if err = h.HandleMetric(context.Background(), vals[1:2]); err != nil {
+		t.Fatalf("HandleMetric() => unexpected error: %v", err)
+	}
+	after := time.Now()
+
+	h.expire(start.Add(30 * time.Second))
+	if got := seriesCount(t, f.registry, "istio_expiring_gauge"); got != 2 {
+		t.Errorf("label sets before expiration => %d; want 2", got)
+	}
+
+	// only updated label sets are kept, and metrics without expiration are left alone.
+	h.metrics[expiring.InstanceName].series.series[seriesKey(prometheus.Labels{"pod": "b"})].updated = after.Add(time.Minute)
+	h.expire(after.Add(time.Minute))
+	if got := seriesCount(t, f.registry, "istio_expiring_gauge"); got != 1 {
+		t.Errorf("label sets after expiration => %d; want 1", got)
+	}
+	if got := seriesCount(t, f.registry, "istio_funky::gauge"); got != 1 {
+		t.Errorf("label sets of metric without expiration => %d; want 1", got)
+	}
+
+	// an expired label set is recorded again when updated.
+	if err = h.HandleMetric(context.Background(), vals[:1]); err != nil {
+		t.Fatalf("HandleMetric() => unexpected error: %v", err)
+	}
+	if got := seriesCount(t, f.registry, "istio_expiring_gauge"); got != 2 {
+		t.Errorf("label sets after update => %d; want 2", got)
+	}
+}
+
+func TestProm_MaxSeries(t *testing.T) {
+	f := newBuilder(&testServer{})
+	capped := &config.Params_MetricInfo{
+		InstanceName: "capped_counter",
+		Kind:         config.COUNTER,
+		LabelNames:   []string{"pod"},
+		MaxSeries:    2,
+	}
+	f.SetAdapterConfig(makeConfig(capped))
+	a, err := f.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Build() => unexpected error: %v", err)
+	}
+	h := a.(*handler)
+
+	overflow := seriesOverflow.WithLabelValues("istio_capped_counter")
+	before := counterValue(t, overflow)
+
+	vals := []*metric.Instance{
+		newPodVal(capped.InstanceName, "a"),
+		newPodVal(capped.InstanceName, "b"),
+		newPodVal(capped.InstanceName, "c"),
+		newPodVal(capped.InstanceName, "a"),
+		newPodVal(capped.InstanceName, "d"),
+	}
+	if err = h.HandleMetric(context.Background(), vals); err != nil {
+		t.Fatalf("HandleMetric() => unexpected error: %v", err)
+	}
+
+	if got := seriesCount(t, f.registry, "istio_capped_counter"); got != 2 {
+		t.Errorf("label sets => %d; want 2", got)
+	}
+	if got := counterValue(t, overflow) - before; got != 2 {
+		t.Errorf("overflow => %v; want 2", got)
+	}
+}
+
+func TestBuild_Redefinition(t *testing.T) {
+	f := newBuilder(&testServer{})
+	hist := &config.Params_MetricInfo{
+		InstanceName: "redefined_histogram",
+		Kind:         config.DISTRIBUTION,
+		LabelNames:   []string{},
+		Buckets: &config.Params_MetricInfo_BucketsDefinition{
+			Definition: &config.Params_MetricInfo_BucketsDefinition_ExplicitBuckets{
+				ExplicitBuckets: &config.Params_MetricInfo_BucketsDefinition_Explicit{Bounds: []float64{1, 2}}}},
+	}
+	f.SetAdapterConfig(makeConfig(counterNoLabels, hist))
+	a, err := f.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Build() => unexpected error: %v", err)
+	}
+	vals := []*metric.Instance{newCounterVal(counterNoLabels.InstanceName, int64(3)), newHistogramVal(hist.InstanceName, int64(1))}
+	if err = a.(metric.Handler).HandleMetric(context.Background(), vals); err != nil {
+		t.Fatalf("HandleMetric() => unexpected error: %v", err)
+	}
+	oldCounter := f.metrics[counterNoLabels.InstanceName]
+	oldHist := f.metrics[hist.InstanceName]
+
+	// change the buckets of the histogram, and its description.
+	redefined := *hist
+	redefined.Description = "new buckets"
+	redefined.Buckets = &config.Params_MetricInfo_BucketsDefinition{
+		Definition: &config.Params_MetricInfo_BucketsDefinition_ExplicitBuckets{
+			ExplicitBuckets: &config.Params_MetricInfo_BucketsDefinition_Explicit{Bounds: []float64{5, 10, 20}}}}
+	f.SetAdapterConfig(makeConfig(counterNoLabels, &redefined))
+	if _, err = f.Build(context.Background(), test.NewEnv(t)); err != nil {
+		t.Fatalf("Build() => unexpected error: %v", err)
+	}
+
+	if f.metrics[counterNoLabels.InstanceName] != oldCounter {
+		t.Error("Build() => unchanged counter was not reused")
+	}
+	if f.metrics[hist.InstanceName] == oldHist {
+		t.Error("Build() => redefined histogram was reused")
+	}
+
+	families, err := f.registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() => unexpected error: %v", err)
+	}
+	for _, mf := range families {
+		switch mf.GetName() {
+		case "istio_the_counter":
+			if got := mf.Metric[0].GetCounter().GetValue(); got != 3 {
+				t.Errorf("counter => %v; want 3", got)
+			}
+		case "istio_redefined_histogram":
+			t.Errorf("redefined histogram => %v; want no observations", mf)
+		}
+	}
+
+	m := new(dto.Metric)
+	if err = f.metrics[hist.InstanceName].c.(*prometheus.HistogramVec).With(prometheus.Labels{}).(prometheus.Metric).Write(m); err != nil {
+		t.Fatalf("Write() => unexpected error: %v", err)
+	}
+	if got := len(m.GetHistogram().GetBucket()); got != 3 {
+		t.Errorf("buckets => %d; want 3", got)
+	}
+}
+
+func TestBuild_SharedRegistry(t *testing.T) {
+	f := newBuilder(&testServer{})
+
+	// handlers of different configurations export their metrics together.
+	handlers := make([]metric.Handler, 0, 2)
+	for _, m := range []*config.Params_MetricInfo{counterNoLabels, gaugeNoLabels} {
+		f.SetAdapterConfig(makeConfig(m))
+		a, err := f.Build(context.Background(), test.NewEnv(t))
+		if err != nil {
+			t.Fatalf("Build() => unexpected error: %v", err)
+		}
+		handlers = append(handlers, a.(metric.Handler))
+	}
+
+	if err := handlers[0].HandleMetric(context.Background(),
+		[]*metric.Instance{newCounterVal(counterNoLabels.InstanceName, int64(1))}); err != nil {
+		t.Fatalf("HandleMetric() => unexpected error: %v", err)
+	}
+	if err := handlers[1].HandleMetric(context.Background(),
+		[]*metric.Instance{newGaugeVal(gaugeNoLabels.InstanceName, int64(1))}); err != nil {
+		t.Fatalf("HandleMetric() => unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"istio_the_counter", "istio_funky::gauge"} {
+		if got := seriesCount(t, f.registry, name); got != 1 {
+			t.Errorf("label sets of %s => %d; want 1", name, got)
+		}
+	}
+}
+
+func TestBuild_SharedSeries(t *testing.T) {
+	f := newBuilder(&testServer{})
+	podsA := &config.Params_MetricInfo{
+		InstanceName: "pods_a",
+		Name:         "pods",
+		Kind:         config.GAUGE,
+		LabelNames:   []string{"pod"},
+		Expiration:   time.Minute,
+	}
+	podsB := *podsA
+	podsB.InstanceName = "pods_b"
+
+	handlers := make([]*handler, 0, 2)
+	for _, m := range []*config.Params_MetricInfo{podsA, &podsB} {
+		f.SetAdapterConfig(makeConfig(m))
+		a, err := f.Build(context.Background(), test.NewEnv(t))
+		if err != nil {
+			t.Fatalf("Build() => unexpected error: %v", err)
+		}
+		handlers = append(handlers, a.(*handler))
+	}
+
+	a, b := handlers[0].metrics[podsA.InstanceName], handlers[1].metrics[podsB.InstanceName]
+	if a.c != b.c || a.series != b.series {
+		t.Fatal("Build() => identical metrics do not share their collector and label sets")
+	}
+
+	if err := handlers[0].HandleMetric(context.Background(), []*metric.Instance{newPodVal(podsA.InstanceName, "a")}); err != nil {
+		t.Fatalf("HandleMetric() => unexpected error: %v", err)
+	}
+	if err := handlers[1].HandleMetric(context.Background(), []*metric.Instance{newPodVal(podsB.InstanceName, "a")}); err != nil {
+		t.Fatalf("HandleMetric() => unexpected error: %v", err)
+	}
+
+	// a label set kept updated through one handler is not expired by the other.
+	after := time.Now()
+	b.series.series[seriesKey(prometheus.Labels{"pod": "a"})].updated = after.Add(time.Minute)
+	handlers[0].expire(after.Add(time.Minute))
+	if got := seriesCount(t, f.registry, "istio_pods"); got != 1 {
+		t.Errorf("label sets => %d; want 1", got)
+	}
+}
+
 type marshaler struct{}
 
 func (m marshaler) Marshal() ([]byte, error) {
@@ -475,6 +774,9 @@ func metricValue(m *dto.Metric) float64 {
 	if c := m.GetHistogram(); c != nil {
 		return *c.SampleSum
 	}
+	if c := m.GetSummary(); c != nil {
+		return *c.SampleSum
+	}
 	if c := m.GetUntyped(); c != nil {
 		return *c.Value
 	}
@@ -505,6 +807,47 @@ func newHistogramVal(name string, val interface{}) *metric.Instance {
 	}
 }
 
+func newSummaryVal(name string, val interface{}) *metric.Instance {
+	return &metric.Instance{
+		Name:  name,
+		Value: val,
+		Dimensions: map[string]interface{}{
+			"bool":   true,
+			"string": "testing",
+			"email":  "test@istio.io",
+		},
+	}
+}
+
+func newPodVal(name, pod string) *metric.Instance {
+	return &metric.Instance{
+		Name:       name,
+		Value:      int64(1),
+		Dimensions: map[string]interface{}{"pod": pod},
+	}
+}
+
+func seriesCount(t *testing.T, registry *prometheus.Registry, name string) int {
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() => unexpected error: %v", err)
+	}
+	for _, mf := range families {
+		if mf.GetName() == name {
+			return len(mf.Metric)
+		}
+	}
+	return 0
+}
+
+func counterValue(t *testing.T, c prometheus.Counter) float64 {
+	m := new(dto.Metric)
+	if err := c.Write(m); err != nil {
+		t.Fatalf("Write() => unexpected error: %v", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
 func makeConfig(metrics ...*config.Params_MetricInfo) *config.Params {
 	return &config.Params{Metrics: metrics}
 }
@@ -0,0 +1,105 @@
+// Copyright 2018 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// seriesTracker keeps track of the label sets of a metric, to expire
+	// the ones that are no longer updated and to cap their number.
+	seriesTracker struct {
+		expiration time.Duration
+		maxSeries  int
+
+		lock   sync.Mutex // protects series
+		series map[string]*seriesInfo
+	}
+
+	seriesInfo struct {
+		labels  prometheus.Labels
+		updated time.Time
+	}
+)
+
+func newSeriesTracker(expiration time.Duration, maxSeries int) *seriesTracker {
+	return &seriesTracker{
+		expiration: expiration,
+		maxSeries:  maxSeries,
+		series:     make(map[string]*seriesInfo),
+	}
+}
+
+// track records an update of the label set. It returns false, without recording
+// the update, if the label set is new and the metric already has maxSeries label sets.
+func (s *seriesTracker) track(labels prometheus.Labels, now time.Time) bool {
+	key := seriesKey(labels)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if si := s.series[key]; si != nil {
+		si.updated = now
+		return true
+	}
+	if s.maxSeries > 0 && len(s.series) >= s.maxSeries {
+		return false
+	}
+	s.series[key] = &seriesInfo{labels: labels, updated: now}
+	return true
+}
+
+// expire calls remove for the label sets that were not updated within the expiration,
+// and stops tracking them.
+func (s *seriesTracker) expire(now time.Time, remove func(prometheus.Labels) bool) {
+	if s.expiration <= 0 {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for key, si := range s.series {
+		if now.Sub(si.updated) >= s.expiration {
+			// removed under the lock, so that a concurrent update of the label set is not lost.
+			remove(si.labels)
+			delete(s.series, key)
+		}
+	}
+}
+
+// seriesKey returns a string identifying the label set.
+func seriesKey(labels prometheus.Labels) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(0)
+		b.WriteString(labels[name])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
@@ -20,6 +20,7 @@ import (
 	rbacproto "istio.io/api/rbac/v1alpha1"
 	"istio.io/istio/mixer/pkg/adapter/test"
 	"istio.io/istio/mixer/template/authorization"
+	"istio.io/istio/pkg/test/rbacfixture"
 )
 
 func setupRBACStore() *configStore {
@@ -228,3 +229,43 @@ func TestRBACStore_CheckPermission(t *testing.T) {
 		}
 	}
 }
+
+// TestRBACStore_Fixture checks the policies shared with the Pilot RBAC filter plugin.
+func TestRBACStore_Fixture(t *testing.T) {
+	rn := make(rolesByName)
+	for _, role := range rbacfixture.Roles() {
+		rn[role.Name] = newRoleInfo(role.Spec)
+		for name, binding := range role.Bindings {
+			rn[role.Name].setBinding(name, binding)
+		}
+	}
+	s := &configStore{roles: rolesMapByNamespace{rbacfixture.Namespace: rn}}
+
+	cases := append(rbacfixture.Cases(), rbacfixture.EmptyNamespaceCases()...)
+	for _, c := range cases {
+		instance := &authorization.Instance{
+			Subject: &authorization.Subject{
+				User:       c.User,
+				Properties: map[string]interface{}{"namespace": c.SourceNamespace},
+			},
+			Action: &authorization.Action{
+				Namespace:  c.ServiceNamespace(),
+				Service:    c.Service,
+				Path:       c.Path,
+				Method:     c.Method,
+				Properties: make(map[string]interface{}),
+			},
+		}
+		for k, v := range c.Labels {
+			instance.Action.Properties[k] = v
+		}
+
+		result, err := s.CheckPermission(instance, test.NewEnv(t))
+		if err != nil {
+			t.Errorf("%+v: %v", c.Request, err)
+		}
+		if result != c.Allowed {
+			t.Errorf("%+v: got allowed %v, want %v", c.Request, result, c.Allowed)
+		}
+	}
+}
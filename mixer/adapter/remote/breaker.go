@@ -0,0 +1,85 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker is the circuit breaker of a handler. It opens after a number of consecutive failed calls, and
+// then fails calls until the sleep window elapsed. A single call is then let through to probe the
+// out-of-process adapter: the breaker closes when it succeeds, and opens again when it fails.
+type breaker struct {
+	// threshold is the number of consecutive failures that opens the breaker, 0 when disabled.
+	threshold int
+	window    time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(threshold int, window time.Duration, now func() time.Time) *breaker {
+	return &breaker{
+		threshold: threshold,
+		window:    window,
+		now:       now,
+	}
+}
+
+// allow returns whether a call can be made.
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.window {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record records the outcome of an allowed call, and returns whether it opened the breaker.
+func (b *breaker) record(err error) bool {
+	if b.threshold <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err == nil {
+		b.failures = 0
+		return false
+	}
+
+	b.failures++
+	if b.failures < b.threshold {
+		return false
+	}
+	b.openedAt = b.now()
+	return b.failures == b.threshold
+}
@@ -0,0 +1,189 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/adapter/util"
+	"istio.io/istio/mixer/pkg/template"
+)
+
+// The generated Go instances of a template and the InstanceMsg protos of its Handle<Template>Service have
+// the same field names. The instances are encoded field by field, mapping the Go types of the template
+// field types back to their protos.
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	durationType     = reflect.TypeOf(time.Duration(0))
+	ipType           = reflect.TypeOf(net.IP{})
+	dnsNameType      = reflect.TypeOf(adapter.DNSName(""))
+	emailAddressType = reflect.TypeOf(adapter.EmailAddress(""))
+	uriType          = reflect.TypeOf(adapter.URI(""))
+	valueType        = reflect.TypeOf(&v1beta1.Value{})
+)
+
+// newRequest creates the Handle<Template>Request message of the template, and encodes the instances into
+// the given field of the request.
+func newRequest(info *template.Info, field string, instances interface{}) (proto.Message, error) {
+	t := proto.MessageType(info.RemoteHandleRequest)
+	if t == nil {
+		return nil, fmt.Errorf("request message '%s' of template '%s' is not registered", info.RemoteHandleRequest, info.Name)
+	}
+
+	req := reflect.New(t.Elem()).Interface().(proto.Message)
+	if err := setField(req, field, instances); err != nil {
+		return nil, fmt.Errorf("unable to encode instances of template '%s': %v", info.Name, err)
+	}
+	return req, nil
+}
+
+// setField encodes the value into the named field of the message.
+func setField(msg proto.Message, field string, value interface{}) error {
+	f := reflect.ValueOf(msg).Elem().FieldByName(field)
+	if !f.IsValid() {
+		return fmt.Errorf("%T has no field %s", msg, field)
+	}
+	return encode(f, reflect.ValueOf(value), field)
+}
+
+// instanceName returns the name of a generated Go instance.
+func instanceName(instance interface{}) string {
+	v := reflect.Indirect(reflect.ValueOf(instance))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	if name := v.FieldByName("Name"); name.IsValid() && name.Kind() == reflect.String {
+		return name.String()
+	}
+	return ""
+}
+
+// encode sets dst to the proto encoding of src. path is the field path of dst, used in errors.
+func encode(dst, src reflect.Value, path string) error {
+	if src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return nil
+		}
+		src = src.Elem()
+	}
+
+	if dst.Type() == valueType {
+		// Fields of type istio.mixer.adapter.model.v1beta1.Value are dynamically typed.
+		v, err := util.NewValue(src.Interface())
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		dst.Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	var msg interface{}
+	switch src.Type() {
+	case timeType:
+		ts, err := types.TimestampProto(src.Interface().(time.Time))
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		msg = &v1beta1.TimeStamp{Value: ts}
+	case durationType:
+		msg = &v1beta1.Duration{Value: types.DurationProto(src.Interface().(time.Duration))}
+	case ipType:
+		msg = &v1beta1.IPAddress{Value: []byte(src.Interface().(net.IP))}
+	case dnsNameType:
+		msg = &v1beta1.DNSName{Value: src.String()}
+	case emailAddressType:
+		msg = &v1beta1.EmailAddress{Value: src.String()}
+	case uriType:
+		msg = &v1beta1.Uri{Value: src.String()}
+	}
+	if msg != nil {
+		src = reflect.ValueOf(msg)
+	}
+
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch {
+	case src.Kind() == reflect.Ptr && dst.Kind() == reflect.Ptr:
+		if src.IsNil() {
+			return nil
+		}
+		v := reflect.New(dst.Type().Elem())
+		if err := encode(v.Elem(), src.Elem(), path); err != nil {
+			return err
+		}
+		dst.Set(v)
+
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			name := dst.Type().Field(i).Name
+			if strings.HasPrefix(name, "XXX_") {
+				continue
+			}
+			f := src.FieldByName(name)
+			if !f.IsValid() {
+				continue
+			}
+			if err := encode(dst.Field(i), f, path+"."+name); err != nil {
+				return err
+			}
+		}
+
+	case src.Kind() == reflect.Map && dst.Kind() == reflect.Map:
+		if src.IsNil() {
+			return nil
+		}
+		m := reflect.MakeMap(dst.Type())
+		for _, k := range src.MapKeys() {
+			e := src.MapIndex(k)
+			if e.Kind() == reflect.Interface && e.IsNil() {
+				continue
+			}
+			v := reflect.New(dst.Type().Elem()).Elem()
+			if err := encode(v, e, fmt.Sprintf("%s[%v]", path, k.Interface())); err != nil {
+				return err
+			}
+			m.SetMapIndex(k.Convert(dst.Type().Key()), v)
+		}
+		dst.Set(m)
+
+	case src.Kind() == reflect.Slice && dst.Kind() == reflect.Slice:
+		if src.IsNil() {
+			return nil
+		}
+		s := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := encode(s.Index(i), src.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+
+	default:
+		return fmt.Errorf("%s: cannot encode %v as %v", path, src.Type(), dst.Type())
+	}
+	return nil
+}
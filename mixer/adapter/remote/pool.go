@@ -0,0 +1,60 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"sync/atomic"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc"
+)
+
+// pool is a fixed set of connections to an out-of-process adapter, used in round robin.
+type pool struct {
+	conns []*grpc.ClientConn
+	next  uint32
+}
+
+// newPool opens size connections to the address. Connections are established in the background, and
+// re-established by gRPC when they break.
+func newPool(address string, size int) (*pool, error) {
+	p := &pool{}
+	for i := 0; i < size; i++ {
+		conn, err := grpc.Dial(address, grpc.WithInsecure())
+		if err != nil {
+			_ = p.Close()
+			return nil, err
+		}
+		p.conns = append(p.conns, conn)
+	}
+	return p, nil
+}
+
+// get returns the next connection.
+func (p *pool) get() *grpc.ClientConn {
+	n := atomic.AddUint32(&p.next, 1)
+	return p.conns[n%uint32(len(p.conns))]
+}
+
+// Close closes all the connections.
+func (p *pool) Close() error {
+	var result *multierror.Error
+	for _, conn := range p.conns {
+		if err := conn.Close(); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result.ErrorOrNil()
+}
@@ -0,0 +1,213 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate $GOPATH/src/istio.io/istio/bin/mixer_codegen.sh -f mixer/adapter/remote/config/config.proto
+
+// Package remote provides an adapter that dispatches instances to out-of-process adapters, through the
+// Handle<Template>Service gRPC services generated for each template. It lets adapters be shipped
+// without being compiled into Mixer.
+package remote // import "istio.io/istio/mixer/adapter/remote"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+
+	"istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/adapter/remote/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/template"
+)
+
+type (
+	builder struct {
+		adapterConfig *config.Params
+		templates     []*template.Info
+	}
+
+	handler struct {
+		address string
+		timeout time.Duration
+		conns   *pool
+		breaker *breaker
+		env     adapter.Env
+	}
+)
+
+// ensure our types implement the requisite interfaces
+var _ template.RemoteHandlerBuilder = &builder{}
+var _ template.RemoteHandler = &handler{}
+
+///////////////// Configuration-time Methods ///////////////
+
+func (b *builder) SetAdapterConfig(cfg adapter.Config) {
+	b.adapterConfig = cfg.(*config.Params)
+}
+
+func (b *builder) SetRemoteTemplates(templates []*template.Info) {
+	b.templates = templates
+}
+
+func (b *builder) Validate() (ce *adapter.ConfigErrors) {
+	ac := b.adapterConfig
+	if ac.Address == "" {
+		ce = ce.Appendf("address", "must be specified")
+	}
+	if ac.Timeout <= 0 {
+		ce = ce.Appendf("timeout", "must be greater than 0, got %v", ac.Timeout)
+	}
+	if ac.Connections <= 0 {
+		ce = ce.Appendf("connections", "must be greater than 0, got %d", ac.Connections)
+	}
+	if ac.CircuitBreaker.ConsecutiveErrors < 0 {
+		ce = ce.Appendf("circuitBreaker.consecutiveErrors", "must not be negative, got %d", ac.CircuitBreaker.ConsecutiveErrors)
+	}
+	if ac.CircuitBreaker.ConsecutiveErrors > 0 && ac.CircuitBreaker.SleepWindow <= 0 {
+		ce = ce.Appendf("circuitBreaker.sleepWindow", "must be greater than 0, got %v", ac.CircuitBreaker.SleepWindow)
+	}
+	for _, t := range b.templates {
+		if t.RemoteHandleMethod == "" || t.RemoteHandleRequest == "" {
+			ce = ce.Appendf("templates", "instances of template '%s' cannot be dispatched to out-of-process adapters", t.Name)
+		} else if proto.MessageType(t.RemoteHandleRequest) == nil {
+			ce = ce.Appendf("templates", "request message '%s' of template '%s' is not registered", t.RemoteHandleRequest, t.Name)
+		}
+	}
+	return
+}
+
+func (b *builder) Build(_ context.Context, env adapter.Env) (adapter.Handler, error) {
+	ac := b.adapterConfig
+	conns, err := newPool(ac.Address, int(ac.Connections))
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to out-of-process adapter at %s: %v", ac.Address, err)
+	}
+
+	return &handler{
+		address: ac.Address,
+		timeout: ac.Timeout,
+		conns:   conns,
+		breaker: newBreaker(int(ac.CircuitBreaker.ConsecutiveErrors), ac.CircuitBreaker.SleepWindow, time.Now),
+		env:     env,
+	}, nil
+}
+
+////////////////// Request-time Methods //////////////////////////
+
+func (h *handler) HandleRemoteCheck(ctx context.Context, info *template.Info, instance interface{}) (adapter.CheckResult, error) {
+	req, err := newRequest(info, "Instance", instance)
+	if err != nil {
+		return adapter.CheckResult{}, err
+	}
+
+	res := &v1beta1.CheckResult{}
+	if err = h.invoke(ctx, info.RemoteHandleMethod, req, res); err != nil {
+		return adapter.CheckResult{}, err
+	}
+
+	return adapter.CheckResult{
+		Status:        res.Status,
+		ValidDuration: res.ValidDuration,
+		ValidUseCount: res.ValidUseCount,
+	}, nil
+}
+
+func (h *handler) HandleRemoteReport(ctx context.Context, info *template.Info, instances []interface{}) error {
+	req, err := newRequest(info, "Instances", instances)
+	if err != nil {
+		return err
+	}
+
+	return h.invoke(ctx, info.RemoteHandleMethod, req, &v1beta1.ReportResult{})
+}
+
+func (h *handler) HandleRemoteQuota(ctx context.Context, info *template.Info, instance interface{},
+	args adapter.QuotaArgs) (adapter.QuotaResult, error) {
+	req, err := newRequest(info, "Instance", instance)
+	if err != nil {
+		return adapter.QuotaResult{}, err
+	}
+
+	name := instanceName(instance)
+	if err = setField(req, "DedupId", args.DeduplicationID); err != nil {
+		return adapter.QuotaResult{}, err
+	}
+	if err = setField(req, "QuotaRequest", &v1beta1.QuotaRequest{
+		Quotas: map[string]v1beta1.QuotaRequest_QuotaParams{
+			name: {Amount: args.QuotaAmount, BestEffort: args.BestEffort},
+		},
+	}); err != nil {
+		return adapter.QuotaResult{}, err
+	}
+
+	res := &v1beta1.QuotaResult{}
+	if err = h.invoke(ctx, info.RemoteHandleMethod, req, res); err != nil {
+		return adapter.QuotaResult{}, err
+	}
+
+	result := res.Quotas[name]
+	return adapter.QuotaResult{
+		ValidDuration: result.ValidDuration,
+		Amount:        result.GrantedAmount,
+	}, nil
+}
+
+// invoke calls the method of the out-of-process adapter, within the deadline of the handler, unless the
+// circuit breaker is open.
+func (h *handler) invoke(ctx context.Context, method string, req, res proto.Message) error {
+	if !h.breaker.allow() {
+		return fmt.Errorf("circuit breaker open for out-of-process adapter at %s", h.address)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := grpc.Invoke(ctx, method, req, res, h.conns.get())
+	if h.breaker.record(err) {
+		h.env.Logger().Warningf("circuit breaker opened for out-of-process adapter at %s: %v", h.address, err)
+	}
+	if err != nil {
+		return fmt.Errorf("call to out-of-process adapter at %s failed: %v", h.address, err)
+	}
+	return nil
+}
+
+func (h *handler) Close() error {
+	return h.conns.Close()
+}
+
+////////////////// Bootstrap //////////////////////////
+
+// GetInfo returns the adapter.Info specific to this adapter.
+func GetInfo() adapter.Info {
+	return adapter.Info{
+		Name:        "remote",
+		Impl:        "istio.io/istio/mixer/adapter/remote",
+		Description: "Dispatches instances to out-of-process adapters over gRPC",
+		// Instances of any check, report or quota template are dispatched to the out-of-process
+		// adapter, through the template.RemoteHandler interface.
+		SupportedTemplates: []string{},
+		NewBuilder:         func() adapter.HandlerBuilder { return &builder{} },
+		DefaultConfig: &config.Params{
+			Timeout:     time.Second,
+			Connections: 1,
+			CircuitBreaker: config.Params_CircuitBreaker{
+				ConsecutiveErrors: 5,
+				SleepWindow:       30 * time.Second,
+			},
+		},
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"strings"
+	"testing"
+
+	istio_mixer_v1 "istio.io/api/mixer/v1"
+	adapter_integration "istio.io/istio/mixer/pkg/adapter/test"
+	"istio.io/istio/mixer/test/remoteadapter"
+)
+
+const (
+	adapterConfig = `
+apiVersion: "config.istio.io/v1alpha2"
+kind: remote
+metadata:
+  name: billing
+  namespace: istio-system
+spec:
+  address: __ADAPTER_ADDRESS__
+  timeout: 5s
+
+---
+
+apiVersion: "config.istio.io/v1alpha2"
+kind: listentry
+metadata:
+  name: source
+  namespace: istio-system
+spec:
+  value: source.name | ""
+
+---
+
+apiVersion: "config.istio.io/v1alpha2"
+kind: metric
+metadata:
+  name: requestcount
+  namespace: istio-system
+spec:
+  value: "1"
+  dimensions:
+    source: source.name | "unknown"
+
+---
+
+apiVersion: "config.istio.io/v1alpha2"
+kind: quota
+metadata:
+  name: requestcount
+  namespace: istio-system
+spec:
+  dimensions:
+    source: source.name | "unknown"
+
+---
+
+apiVersion: "config.istio.io/v1alpha2"
+kind: rule
+metadata:
+  name: billing
+  namespace: istio-system
+spec:
+  actions:
+  - handler: billing.remote
+    instances:
+    - source.listentry
+    - requestcount.metric
+    - requestcount.quota
+`
+)
+
+func TestOutOfProcessAdapter(t *testing.T) {
+	s, err := remoteadapter.NewServer("127.0.0.1:0", []string{"src1"}, 100)
+	if err != nil {
+		t.Fatalf("Unable to start the out-of-process adapter: %v", err)
+	}
+	s.Run()
+	defer func() { _ = s.Close() }()
+
+	adapter_integration.RunTest(
+		t,
+		GetInfo,
+		adapter_integration.Scenario{
+			ParallelCalls: []adapter_integration.Call{
+				{
+					CallKind: adapter_integration.CHECK,
+					Attrs:    map[string]interface{}{"source.name": "src1"},
+				},
+				{
+					CallKind: adapter_integration.CHECK,
+					Attrs:    map[string]interface{}{"source.name": "src2"},
+				},
+				{
+					CallKind: adapter_integration.CHECK,
+					Attrs:    map[string]interface{}{"source.name": "src1"},
+					Quotas: map[string]istio_mixer_v1.CheckRequest_QuotaParams{
+						"requestcount": {
+							Amount:     150,
+							BestEffort: true,
+						},
+					},
+				},
+				{
+					CallKind: adapter_integration.REPORT,
+					Attrs:    map[string]interface{}{"source.name": "src1"},
+				},
+			},
+			GetState: func(interface{}) (interface{}, error) {
+				return s.Metrics(), nil
+			},
+			Configs: []string{
+				strings.Replace(adapterConfig, "__ADAPTER_ADDRESS__", s.Addr().String(), -1),
+			},
+			Want: `{
+			 "AdapterState": [
+			  {
+			   "Name": "requestcount.metric.istio-system",
+			   "Value": 1,
+			   "Dimensions": {
+			    "source": "src1"
+			   }
+			  }
+			 ],
+			 "Returns": [
+			  {
+			   "Check": {
+			    "Status": {},
+			    "ValidDuration": 10000000000,
+			    "ValidUseCount": 100
+			   }
+			  },
+			  {
+			   "Check": {
+			    "Status": {
+			     "code": 7,
+			     "message": "billing.remote.istio-system:src2 is not whitelisted"
+			    },
+			    "ValidDuration": 10000000000,
+			    "ValidUseCount": 100
+			   }
+			  },
+			  {
+			   "Quota": {
+			    "requestcount": {
+			     "ValidDuration": 10000000000,
+			     "Amount": 100
+			    }
+			   }
+			  },
+			  {}
+			 ]
+			}`,
+		},
+	)
+}
@@ -0,0 +1,295 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	rpc "github.com/gogo/googleapis/google/rpc"
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/adapter/remote/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/adapter/test"
+	"istio.io/istio/mixer/pkg/template"
+	"istio.io/istio/mixer/template/listentry"
+	"istio.io/istio/mixer/template/metric"
+	"istio.io/istio/mixer/template/quota"
+	"istio.io/istio/mixer/test/remoteadapter"
+)
+
+func templateInfo(name, method, request string) *template.Info {
+	return &template.Info{Name: name, RemoteHandleMethod: method, RemoteHandleRequest: request}
+}
+
+var (
+	metricInfo    = templateInfo(metric.TemplateName, metric.RemoteHandleMethod, metric.RemoteHandleRequest)
+	listEntryInfo = templateInfo(listentry.TemplateName, listentry.RemoteHandleMethod, listentry.RemoteHandleRequest)
+	quotaInfo     = templateInfo(quota.TemplateName, quota.RemoteHandleMethod, quota.RemoteHandleRequest)
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name      string
+		params    func(*config.Params)
+		templates []*template.Info
+		errors    []string
+	}{
+		{"defaults", func(p *config.Params) { p.Address = "localhost:9070" }, []*template.Info{metricInfo, quotaInfo}, nil},
+		{"no address", func(p *config.Params) {}, nil, []string{"address"}},
+		{"no timeout", func(p *config.Params) {
+			p.Address = "localhost:9070"
+			p.Timeout = 0
+		}, nil, []string{"timeout"}},
+		{"no connections", func(p *config.Params) {
+			p.Address = "localhost:9070"
+			p.Connections = 0
+		}, nil, []string{"connections"}},
+		{"breaker disabled", func(p *config.Params) {
+			p.Address = "localhost:9070"
+			p.CircuitBreaker = config.Params_CircuitBreaker{}
+		}, nil, nil},
+		{"no sleep window", func(p *config.Params) {
+			p.Address = "localhost:9070"
+			p.CircuitBreaker.SleepWindow = 0
+		}, nil, []string{"circuitBreaker.sleepWindow"}},
+		{"template without service", func(p *config.Params) { p.Address = "localhost:9070" },
+			[]*template.Info{{Name: "apa"}}, []string{"template 'apa' cannot be dispatched"}},
+		{"unregistered request", func(p *config.Params) { p.Address = "localhost:9070" },
+			[]*template.Info{templateInfo("foo", "/foo.HandleFooService/HandleFoo", "foo.HandleFooRequest")},
+			[]string{"request message 'foo.HandleFooRequest' of template 'foo' is not registered"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			info := GetInfo()
+			params := *info.DefaultConfig.(*config.Params)
+			c.params(&params)
+
+			b := info.NewBuilder().(*builder)
+			b.SetAdapterConfig(&params)
+			b.SetRemoteTemplates(c.templates)
+
+			ce := b.Validate()
+			if len(c.errors) == 0 {
+				if ce != nil {
+					t.Fatalf("Validate() => %v, want success", ce)
+				}
+				return
+			}
+			if ce == nil {
+				t.Fatalf("Validate() succeeded, want errors %v", c.errors)
+			}
+			for _, want := range c.errors {
+				if !strings.Contains(ce.Error(), want) {
+					t.Errorf("Validate() => %v, want an error containing %q", ce, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	start := time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+	instance := &metric.Instance{
+		Name:  "requestcount.metric.istio-system",
+		Value: int64(1),
+		Dimensions: map[string]interface{}{
+			"source":  "src1",
+			"ip":      net.ParseIP("10.0.0.1").To4(),
+			"start":   start,
+			"latency": 10 * time.Millisecond,
+			"dns":     adapter.DNSName("svc.cluster.local"),
+			"missing": nil,
+		},
+		MonitoredResourceType: "pod",
+	}
+
+	req, err := newRequest(metricInfo, "Instances", []interface{}{instance})
+	if err != nil {
+		t.Fatalf("newRequest() => %v", err)
+	}
+
+	ts, _ := types.TimestampProto(start)
+	want := &metric.HandleMetricRequest{
+		Instances: []*metric.InstanceMsg{{
+			Name:  "requestcount.metric.istio-system",
+			Value: &v1beta1.Value{Value: &v1beta1.Value_Int64Value{Int64Value: 1}},
+			Dimensions: map[string]*v1beta1.Value{
+				"source": {Value: &v1beta1.Value_StringValue{StringValue: "src1"}},
+				"ip":     {Value: &v1beta1.Value_IpAddressValue{IpAddressValue: &v1beta1.IPAddress{Value: []byte{10, 0, 0, 1}}}},
+				"start":  {Value: &v1beta1.Value_TimestampValue{TimestampValue: &v1beta1.TimeStamp{Value: ts}}},
+				"latency": {Value: &v1beta1.Value_DurationValue{
+					DurationValue: &v1beta1.Duration{Value: types.DurationProto(10 * time.Millisecond)}}},
+				"dns": {Value: &v1beta1.Value_DnsNameValue{DnsNameValue: &v1beta1.DNSName{Value: "svc.cluster.local"}}},
+			},
+			MonitoredResourceType: "pod",
+		}},
+	}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("newRequest() =>\n%v\nwant\n%v", req, want)
+	}
+
+	if _, err = newRequest(metricInfo, "Instances", []interface{}{&metric.Instance{Value: struct{}{}}}); err == nil {
+		t.Error("newRequest() succeeded for an unsupported value type, want error")
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newBreaker(2, 10*time.Second, func() time.Time { return now })
+	failure := errors.New("unavailable")
+
+	steps := []struct {
+		advance time.Duration
+		allow   bool
+		result  error
+		opened  bool
+	}{
+		{allow: true, result: failure},
+		{allow: true, result: nil},
+		{allow: true, result: failure},
+		{allow: true, result: failure, opened: true},
+		{advance: 5 * time.Second, allow: false},
+		// The sleep window elapsed, a single probe is let through.
+		{advance: 5 * time.Second, allow: true, result: failure},
+		{allow: false},
+		{advance: 10 * time.Second, allow: true, result: nil},
+		{allow: true, result: nil},
+	}
+
+	for i, s := range steps {
+		now = now.Add(s.advance)
+		if got := b.allow(); got != s.allow {
+			t.Fatalf("step %d: allow() => %v, want %v", i, got, s.allow)
+		}
+		if !s.allow {
+			continue
+		}
+		if got := b.record(s.result); got != s.opened {
+			t.Fatalf("step %d: record(%v) => %v, want %v", i, s.result, got, s.opened)
+		}
+	}
+
+	disabled := newBreaker(0, 0, time.Now)
+	for i := 0; i < 10; i++ {
+		disabled.record(failure)
+	}
+	if !disabled.allow() {
+		t.Error("allow() => false for a disabled breaker, want true")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	s, err := remoteadapter.NewServer("127.0.0.1:0", []string{"src1"}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Run()
+	defer func() { _ = s.Close() }()
+
+	info := GetInfo()
+	params := *info.DefaultConfig.(*config.Params)
+	params.Address = s.Addr().String()
+	params.Connections = 2
+
+	b := info.NewBuilder().(*builder)
+	b.SetAdapterConfig(&params)
+	b.SetRemoteTemplates([]*template.Info{listEntryInfo, metricInfo, quotaInfo})
+	if ce := b.Validate(); ce != nil {
+		t.Fatalf("Validate() => %v", ce)
+	}
+	h, err := b.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Build() => %v", err)
+	}
+	defer func() { _ = h.Close() }()
+	rh := h.(template.RemoteHandler)
+
+	cr, err := rh.HandleRemoteCheck(context.Background(), listEntryInfo, &listentry.Instance{Name: "source", Value: "src2"})
+	if err != nil {
+		t.Fatalf("HandleRemoteCheck() => %v", err)
+	}
+	if cr.Status.Code != int32(rpc.PERMISSION_DENIED) || cr.ValidUseCount != 100 {
+		t.Errorf("HandleRemoteCheck() => %v, want a denial valid for 100 uses", cr)
+	}
+
+	err = rh.HandleRemoteReport(context.Background(), metricInfo, []interface{}{
+		&metric.Instance{Name: "requestcount", Value: int64(1), Dimensions: map[string]interface{}{"source": "src1"}},
+	})
+	if err != nil {
+		t.Fatalf("HandleRemoteReport() => %v", err)
+	}
+	wantMetrics := []remoteadapter.Metric{{Name: "requestcount", Value: int64(1), Dimensions: map[string]interface{}{"source": "src1"}}}
+	if got := s.Metrics(); !reflect.DeepEqual(got, wantMetrics) {
+		t.Errorf("Metrics() => %v, want %v", got, wantMetrics)
+	}
+
+	qr, err := rh.HandleRemoteQuota(context.Background(), quotaInfo, &quota.Instance{Name: "requestcount"},
+		adapter.QuotaArgs{QuotaAmount: 150, BestEffort: true})
+	if err != nil {
+		t.Fatalf("HandleRemoteQuota() => %v", err)
+	}
+	if qr.Amount != 100 {
+		t.Errorf("HandleRemoteQuota() => %v, want 100 granted", qr)
+	}
+}
+
+func TestHandler_Unavailable(t *testing.T) {
+	// Reserve an address nothing listens on.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := l.Addr().String()
+	_ = l.Close()
+
+	info := GetInfo()
+	params := *info.DefaultConfig.(*config.Params)
+	params.Address = address
+	params.Timeout = 100 * time.Millisecond
+	params.CircuitBreaker.ConsecutiveErrors = 2
+
+	b := info.NewBuilder().(*builder)
+	b.SetAdapterConfig(&params)
+	b.SetRemoteTemplates([]*template.Info{metricInfo})
+	env := test.NewEnv(t)
+	h, err := b.Build(context.Background(), env)
+	if err != nil {
+		t.Fatalf("Build() => %v", err)
+	}
+	defer func() { _ = h.Close() }()
+	rh := h.(template.RemoteHandler)
+
+	for i := 0; i < 2; i++ {
+		if err = rh.HandleRemoteReport(context.Background(), metricInfo, nil); err == nil {
+			t.Fatal("HandleRemoteReport() succeeded, want the call to fail")
+		}
+	}
+	err = rh.HandleRemoteReport(context.Background(), metricInfo, nil)
+	if err == nil || !strings.Contains(err.Error(), "circuit breaker open") {
+		t.Errorf("HandleRemoteReport() => %v, want the circuit breaker to be open", err)
+	}
+	if logs := strings.Join(env.GetLogs(), "\n"); !strings.Contains(logs, "circuit breaker opened") {
+		t.Errorf("logs %q do not mention the circuit breaker", logs)
+	}
+}
@@ -29,6 +29,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "servicecontrolreport"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'servicecontrolreport' instances.
+const RemoteHandleMethod = "/servicecontrolreport.HandleServicecontrolReportService/HandleServicecontrolReport"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "servicecontrolreport.HandleServicecontrolReportRequest"
+
 // Instance is constructed by Mixer for the 'servicecontrolreport' template.
 //
 // A template used by Google Service Control (servicecontrol) adapter. The adapter
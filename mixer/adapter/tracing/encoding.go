@@ -0,0 +1,180 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/uber/jaeger-client-go/thrift-gen/jaeger"
+
+	"istio.io/istio/mixer/pkg/adapter"
+)
+
+type (
+	// encoder encodes spans in the format expected by a collector.
+	encoder interface {
+		// contentType of the encoded spans.
+		contentType() string
+
+		// encode returns the bodies of the requests exporting the spans.
+		encode(spans []*span) ([][]byte, error)
+	}
+
+	// zipkinEncoder encodes spans in the Zipkin v2 JSON format.
+	zipkinEncoder struct{}
+
+	// jaegerEncoder encodes spans as Jaeger Thrift batches, one per service.
+	jaegerEncoder struct{}
+
+	zipkinSpan struct {
+		TraceID       string            `json:"traceId"`
+		ID            string            `json:"id"`
+		ParentID      string            `json:"parentId,omitempty"`
+		Name          string            `json:"name,omitempty"`
+		Timestamp     int64             `json:"timestamp,omitempty"`
+		Duration      int64             `json:"duration,omitempty"`
+		LocalEndpoint *zipkinEndpoint   `json:"localEndpoint,omitempty"`
+		Tags          map[string]string `json:"tags,omitempty"`
+	}
+
+	zipkinEndpoint struct {
+		ServiceName string `json:"serviceName,omitempty"`
+	}
+)
+
+func (zipkinEncoder) contentType() string {
+	return "application/json"
+}
+
+func (zipkinEncoder) encode(spans []*span) ([][]byte, error) {
+	zspans := make([]*zipkinSpan, 0, len(spans))
+	for _, s := range spans {
+		zs := &zipkinSpan{
+			TraceID:       fmt.Sprintf("%016x", s.traceIDLow),
+			ID:            fmt.Sprintf("%016x", s.id),
+			Name:          s.name,
+			Timestamp:     s.start.UnixNano() / int64(time.Microsecond),
+			Duration:      micros(s.duration),
+			LocalEndpoint: &zipkinEndpoint{ServiceName: s.service},
+		}
+		if s.traceIDHigh != 0 {
+			zs.TraceID = fmt.Sprintf("%016x%016x", s.traceIDHigh, s.traceIDLow)
+		}
+		if s.parentID != 0 {
+			zs.ParentID = fmt.Sprintf("%016x", s.parentID)
+		}
+		if len(s.tags) > 0 {
+			zs.Tags = make(map[string]string, len(s.tags))
+			for k, v := range s.tags {
+				zs.Tags[k] = adapter.Stringify(v)
+			}
+		}
+		zspans = append(zspans, zs)
+	}
+
+	body, err := json.Marshal(zspans)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{body}, nil
+}
+
+func (jaegerEncoder) contentType() string {
+	return "application/x-thrift"
+}
+
+func (jaegerEncoder) encode(spans []*span) ([][]byte, error) {
+	// Jaeger batches carry the spans of a single process, which is the service.
+	batches := make(map[string]*jaeger.Batch)
+	for _, s := range spans {
+		batch, found := batches[s.service]
+		if !found {
+			batch = &jaeger.Batch{Process: &jaeger.Process{ServiceName: s.service}}
+			batches[s.service] = batch
+		}
+
+		js := &jaeger.Span{
+			TraceIdLow:    int64(s.traceIDLow),
+			TraceIdHigh:   int64(s.traceIDHigh),
+			SpanId:        int64(s.id),
+			ParentSpanId:  int64(s.parentID),
+			OperationName: s.name,
+			Flags:         1, // sampled
+			StartTime:     s.start.UnixNano() / int64(time.Microsecond),
+			Duration:      micros(s.duration),
+			Tags:          jaegerTags(s.tags),
+		}
+		batch.Spans = append(batch.Spans, js)
+	}
+
+	services := make([]string, 0, len(batches))
+	for service := range batches {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	bodies := make([][]byte, 0, len(batches))
+	for _, service := range services {
+		buf := thrift.NewTMemoryBuffer()
+		if err := batches[service].Write(thrift.NewTBinaryProtocolTransport(buf)); err != nil {
+			return nil, err
+		}
+		bodies = append(bodies, buf.Bytes())
+	}
+	return bodies, nil
+}
+
+func jaegerTags(tags map[string]interface{}) []*jaeger.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	jtags := make([]*jaeger.Tag, 0, len(tags))
+	for _, k := range keys {
+		t := &jaeger.Tag{Key: k}
+		switch v := tags[k].(type) {
+		case bool:
+			t.VType = jaeger.TagType_BOOL
+			t.VBool = &v
+		case int64:
+			t.VType = jaeger.TagType_LONG
+			t.VLong = &v
+		case float64:
+			t.VType = jaeger.TagType_DOUBLE
+			t.VDouble = &v
+		default:
+			s := adapter.Stringify(v)
+			t.VType = jaeger.TagType_STRING
+			t.VStr = &s
+		}
+		jtags = append(jtags, t)
+	}
+	return jtags
+}
+
+// micros returns the duration in microseconds, the unit of timestamps and durations of both Zipkin and Jaeger.
+func micros(d time.Duration) int64 {
+	return int64(d / time.Microsecond)
+}
@@ -0,0 +1,210 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"istio.io/istio/mixer/adapter/tracing/config"
+	"istio.io/istio/mixer/pkg/adapter"
+)
+
+// exporter buffers spans and sends them to a collector in batches, from a background daemon.
+type exporter struct {
+	url    string
+	enc    encoder
+	client *http.Client
+	logger adapter.Logger
+
+	batchSize        int
+	maxBufferedSpans int
+	maxRetries       int
+	retryBackoff     time.Duration
+
+	// Guards buffer and dropped
+	mu      sync.Mutex
+	buffer  []*span
+	dropped int
+
+	// flushCh signals the daemon that a full batch is buffered.
+	flushCh chan struct{}
+
+	// done is closed to stop the daemon, which closes stopped when it returns.
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newExporter(ac *config.Params, logger adapter.Logger) *exporter {
+	var enc encoder = zipkinEncoder{}
+	if ac.Format == config.JAEGER {
+		enc = jaegerEncoder{}
+	}
+
+	return &exporter{
+		url:              ac.CollectorUrl,
+		enc:              enc,
+		client:           &http.Client{Timeout: ac.Timeout},
+		logger:           logger,
+		batchSize:        int(ac.BatchSize),
+		maxBufferedSpans: int(ac.MaxBufferedSpans),
+		maxRetries:       int(ac.MaxRetries),
+		retryBackoff:     ac.RetryBackoff,
+		flushCh:          make(chan struct{}, 1),
+		done:             make(chan struct{}),
+		stopped:          make(chan struct{}),
+	}
+}
+
+// start schedules the daemon flushing the buffered spans, at least once per interval.
+func (e *exporter) start(env adapter.Env, interval time.Duration) {
+	env.ScheduleDaemon(func() {
+		defer close(e.stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				e.flush()
+			case <-e.flushCh:
+				e.flush()
+			case <-e.done:
+				return
+			}
+		}
+	})
+}
+
+// add buffers the spans, dropping the ones that do not fit in the buffer.
+func (e *exporter) add(spans []*span) {
+	if len(spans) == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	if room := e.maxBufferedSpans - len(e.buffer); len(spans) > room {
+		e.dropped += len(spans) - room
+		spans = spans[:room]
+	}
+	e.buffer = append(e.buffer, spans...)
+	full := len(e.buffer) >= e.batchSize
+	e.mu.Unlock()
+
+	if full {
+		select {
+		case e.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// flush sends all the buffered spans.
+func (e *exporter) flush() {
+	e.mu.Lock()
+	spans := e.buffer
+	dropped := e.dropped
+	e.buffer = nil
+	e.dropped = 0
+	e.mu.Unlock()
+
+	if dropped > 0 {
+		e.logger.Warningf("Dropped %d spans: the buffer of %d spans is full", dropped, e.maxBufferedSpans)
+	}
+
+	for len(spans) > 0 {
+		n := e.batchSize
+		if n > len(spans) {
+			n = len(spans)
+		}
+		if err := e.send(spans[:n]); err != nil {
+			_ = e.logger.Errorf("Unable to export %d spans to %s: %v", n, e.url, err)
+		}
+		spans = spans[n:]
+	}
+}
+
+// send exports a batch of spans.
+func (e *exporter) send(spans []*span) error {
+	bodies, err := e.enc.encode(spans)
+	if err != nil {
+		return err
+	}
+
+	var result *multierror.Error
+	for _, body := range bodies {
+		if err := e.post(body); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result.ErrorOrNil()
+}
+
+// post sends a request to the collector, retrying with exponential backoff while it fails with a retryable
+// error. It does not retry once the exporter is closed.
+func (e *exporter) post(body []byte) error {
+	backoff := e.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := e.postOnce(body)
+		if err == nil || !retryable || attempt >= e.maxRetries {
+			return err
+		}
+
+		e.logger.Infof("Retrying export to %s in %v: %v", e.url, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-e.done:
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// postOnce sends a request to the collector, and returns whether it is worth retrying if it fails.
+func (e *exporter) postOnce(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", e.enc.contentType())
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		retryable := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return retryable, fmt.Errorf("collector responded with %s", resp.Status)
+	}
+	return false, nil
+}
+
+// close stops the daemon, and sends the buffered spans.
+func (e *exporter) close() {
+	close(e.done)
+	<-e.stopped
+	e.flush()
+}
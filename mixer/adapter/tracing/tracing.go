@@ -0,0 +1,278 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate $GOPATH/src/istio.io/istio/bin/mixer_codegen.sh -f mixer/adapter/tracing/config/config.proto
+
+// Package tracing provides an adapter that exports tracespan instances to a Zipkin or Jaeger collector.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/url"
+	"strconv"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"istio.io/istio/mixer/adapter/tracing/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/template/tracespan"
+)
+
+type (
+	builder struct {
+		adapterConfig *config.Params
+		types         map[string]*tracespan.Type
+	}
+
+	handler struct {
+		serviceName    string
+		serviceNameTag string
+		sampler        sampler
+		exporter       *exporter
+	}
+
+	// span is a tracespan instance, with its IDs decoded.
+	span struct {
+		traceIDHigh uint64
+		traceIDLow  uint64
+		id          uint64
+		parentID    uint64
+		name        string
+		service     string
+		start       time.Time
+		duration    time.Duration
+		tags        map[string]interface{}
+	}
+
+	// sampler decides whether traces are sampled, based on the low bits of their ID, which are random.
+	sampler struct {
+		all      bool
+		boundary uint64
+	}
+)
+
+// ensure types implement the requisite interfaces
+var _ tracespan.HandlerBuilder = &builder{}
+var _ tracespan.Handler = &handler{}
+
+///////////////// Configuration-time Methods ///////////////
+
+// adapter.HandlerBuilder#Build
+func (b *builder) Build(_ context.Context, env adapter.Env) (adapter.Handler, error) {
+	ac := b.adapterConfig
+
+	e := newExporter(ac, env.Logger())
+	e.start(env, ac.FlushInterval)
+
+	return &handler{
+		serviceName:    ac.ServiceName,
+		serviceNameTag: ac.ServiceNameTag,
+		sampler:        newSampler(ac.SampleProbability),
+		exporter:       e,
+	}, nil
+}
+
+// adapter.HandlerBuilder#SetAdapterConfig
+func (b *builder) SetAdapterConfig(cfg adapter.Config) {
+	b.adapterConfig = cfg.(*config.Params)
+}
+
+// adapter.HandlerBuilder#Validate
+func (b *builder) Validate() (ce *adapter.ConfigErrors) {
+	ac := b.adapterConfig
+
+	if ac.CollectorUrl == "" {
+		ce = ce.Appendf("collectorUrl", "collector URL must be specified")
+	} else if u, err := url.Parse(ac.CollectorUrl); err != nil {
+		ce = ce.Appendf("collectorUrl", "collector URL is malformed: %v", err)
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		ce = ce.Appendf("collectorUrl", "collector URL must be an http or https URL, got %q", ac.CollectorUrl)
+	}
+
+	if _, found := config.Params_Format_name[int32(ac.Format)]; !found {
+		ce = ce.Appendf("format", "unknown format %v", ac.Format)
+	}
+
+	if ac.ServiceName == "" {
+		ce = ce.Appendf("serviceName", "service name must be specified")
+	}
+
+	if ac.SampleProbability < 0 || ac.SampleProbability > 1 {
+		ce = ce.Appendf("sampleProbability", "sample probability must be between 0 and 1, got %v", ac.SampleProbability)
+	}
+
+	if ac.BatchSize <= 0 {
+		ce = ce.Appendf("batchSize", "batch size must be > 0, got %d", ac.BatchSize)
+	}
+
+	if ac.FlushInterval <= 0 {
+		ce = ce.Appendf("flushInterval", "flush interval must be > 0, got %v", ac.FlushInterval)
+	}
+
+	if ac.MaxBufferedSpans < ac.BatchSize {
+		ce = ce.Appendf("maxBufferedSpans", "max buffered spans must be >= the batch size, got %d", ac.MaxBufferedSpans)
+	}
+
+	if ac.MaxRetries < 0 {
+		ce = ce.Appendf("maxRetries", "max retries must be >= 0, got %d", ac.MaxRetries)
+	}
+
+	if ac.RetryBackoff < 0 {
+		ce = ce.Appendf("retryBackoff", "retry backoff must be >= 0, got %v", ac.RetryBackoff)
+	}
+
+	if ac.Timeout <= 0 {
+		ce = ce.Appendf("timeout", "timeout must be > 0, got %v", ac.Timeout)
+	}
+
+	return ce
+}
+
+// tracespan.HandlerBuilder#SetTraceSpanTypes
+func (b *builder) SetTraceSpanTypes(types map[string]*tracespan.Type) {
+	b.types = types
+}
+
+////////////////// Request-time Methods //////////////////////////
+
+// tracespan.Handler#HandleTraceSpan
+func (h *handler) HandleTraceSpan(_ context.Context, insts []*tracespan.Instance) error {
+	var result *multierror.Error
+	spans := make([]*span, 0, len(insts))
+	for _, inst := range insts {
+		s, err := h.newSpan(inst)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+		if h.sampler.sampled(s.traceIDLow) {
+			spans = append(spans, s)
+		}
+	}
+
+	h.exporter.add(spans)
+	return result.ErrorOrNil()
+}
+
+// newSpan decodes the IDs of a tracespan instance, and resolves the service it is reported for.
+func (h *handler) newSpan(inst *tracespan.Instance) (*span, error) {
+	s := &span{
+		name:     inst.SpanName,
+		service:  h.serviceName,
+		start:    inst.StartTime,
+		duration: inst.EndTime.Sub(inst.StartTime),
+		tags:     inst.SpanTags,
+	}
+
+	var err error
+	if s.traceIDHigh, s.traceIDLow, err = parseTraceID(inst.TraceId); err != nil {
+		return nil, fmt.Errorf("instance %s: invalid trace ID %q: %v", inst.Name, inst.TraceId, err)
+	}
+
+	// The span ID is optional, but both Zipkin and Jaeger require one.
+	if inst.SpanId == "" {
+		s.id = uint64(rand.Int63())
+	} else if s.id, err = strconv.ParseUint(inst.SpanId, 16, 64); err != nil {
+		return nil, fmt.Errorf("instance %s: invalid span ID %q: %v", inst.Name, inst.SpanId, err)
+	}
+
+	if inst.ParentSpanId != "" {
+		if s.parentID, err = strconv.ParseUint(inst.ParentSpanId, 16, 64); err != nil {
+			return nil, fmt.Errorf("instance %s: invalid parent span ID %q: %v", inst.Name, inst.ParentSpanId, err)
+		}
+	}
+
+	if v, found := inst.SpanTags[h.serviceNameTag]; found && h.serviceNameTag != "" {
+		if service := adapter.Stringify(v); service != "" {
+			s.service = service
+		}
+
+		s.tags = make(map[string]interface{}, len(inst.SpanTags)-1)
+		for k, v := range inst.SpanTags {
+			if k != h.serviceNameTag {
+				s.tags[k] = v
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// parseTraceID decodes a 64 or 128 bit hex encoded trace ID.
+func parseTraceID(id string) (high uint64, low uint64, err error) {
+	if id == "" || len(id) > 32 {
+		return 0, 0, fmt.Errorf("must have between 1 and 32 hex digits")
+	}
+
+	if len(id) > 16 {
+		if high, err = strconv.ParseUint(id[:len(id)-16], 16, 64); err != nil {
+			return 0, 0, err
+		}
+		id = id[len(id)-16:]
+	}
+
+	if low, err = strconv.ParseUint(id, 16, 64); err != nil {
+		return 0, 0, err
+	}
+	return high, low, nil
+}
+
+func newSampler(probability float64) sampler {
+	boundary := probability * math.Exp2(64)
+	if boundary >= math.Exp2(64) {
+		return sampler{all: true}
+	}
+	return sampler{boundary: uint64(boundary)}
+}
+
+func (s sampler) sampled(traceIDLow uint64) bool {
+	return s.all || traceIDLow < s.boundary
+}
+
+// adapter.Handler#Close
+func (h *handler) Close() error {
+	h.exporter.close()
+	return nil
+}
+
+////////////////// Bootstrap //////////////////////////
+
+// GetInfo returns the adapter.Info specific to this adapter.
+func GetInfo() adapter.Info {
+	return adapter.Info{
+		Name:        "tracing",
+		Impl:        "istio.io/istio/mixer/adapter/tracing",
+		Description: "Exports trace spans to a Zipkin or Jaeger collector",
+		SupportedTemplates: []string{
+			tracespan.TemplateName,
+		},
+		NewBuilder: func() adapter.HandlerBuilder { return &builder{} },
+		DefaultConfig: &config.Params{
+			CollectorUrl:      "http://zipkin.istio-system:9411/api/v2/spans",
+			Format:            config.ZIPKIN,
+			ServiceName:       "istio-mesh",
+			SampleProbability: 1,
+			BatchSize:         100,
+			FlushInterval:     time.Second,
+			MaxBufferedSpans:  10000,
+			MaxRetries:        3,
+			RetryBackoff:      100 * time.Millisecond,
+			Timeout:           5 * time.Second,
+		},
+	}
+}
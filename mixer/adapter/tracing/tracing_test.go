@@ -0,0 +1,488 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/uber/jaeger-client-go/thrift-gen/jaeger"
+
+	"istio.io/istio/mixer/adapter/tracing/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/adapter/test"
+	"istio.io/istio/mixer/template/tracespan"
+)
+
+// collector is an in-process stand-in for a Zipkin or Jaeger collector.
+type collector struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	bodies   [][]byte
+	types    []string
+	statuses []int // statuses of the next responses, 202 when empty
+	received chan struct{}
+}
+
+func newCollector(statuses ...int) *collector {
+	c := &collector{
+		statuses: statuses,
+		received: make(chan struct{}, 100),
+	}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		c.mu.Lock()
+		status := http.StatusAccepted
+		if len(c.statuses) > 0 {
+			status, c.statuses = c.statuses[0], c.statuses[1:]
+		}
+		if status == http.StatusAccepted {
+			c.bodies = append(c.bodies, body)
+			c.types = append(c.types, r.Header.Get("Content-Type"))
+		}
+		c.mu.Unlock()
+
+		w.WriteHeader(status)
+		c.received <- struct{}{}
+	}))
+	return c
+}
+
+// wait waits for the collector to receive n requests.
+func (c *collector) wait(t *testing.T, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-c.received:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("collector received %d requests, want %d", i, n)
+		}
+	}
+}
+
+func (c *collector) accepted() ([][]byte, []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bodies, c.types
+}
+
+func (c *collector) zipkinSpans(t *testing.T) []zipkinSpan {
+	bodies, _ := c.accepted()
+
+	var all []zipkinSpan
+	for _, body := range bodies {
+		var spans []zipkinSpan
+		if err := json.Unmarshal(body, &spans); err != nil {
+			t.Fatalf("invalid Zipkin JSON %q: %v", body, err)
+		}
+		all = append(all, spans...)
+	}
+	return all
+}
+
+func (c *collector) jaegerBatches(t *testing.T) []*jaeger.Batch {
+	bodies, _ := c.accepted()
+
+	var all []*jaeger.Batch
+	for _, body := range bodies {
+		buf := thrift.NewTMemoryBuffer()
+		_, _ = buf.Write(body)
+		batch := jaeger.NewBatch()
+		if err := batch.Read(thrift.NewTBinaryProtocolTransport(buf)); err != nil {
+			t.Fatalf("invalid Jaeger Thrift: %v", err)
+		}
+		all = append(all, batch)
+	}
+	return all
+}
+
+func params(url string) *config.Params {
+	p := *GetInfo().DefaultConfig.(*config.Params)
+	p.CollectorUrl = url
+	p.FlushInterval = time.Hour
+	p.RetryBackoff = time.Millisecond
+	return &p
+}
+
+func build(t *testing.T, p *config.Params) *handler {
+	b := GetInfo().NewBuilder().(*builder)
+	b.SetAdapterConfig(p)
+	b.SetTraceSpanTypes(map[string]*tracespan.Type{"span": {}})
+	if err := b.Validate(); err != nil {
+		t.Fatalf("Validate() => %v", err)
+	}
+
+	h, err := b.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Build() => %v", err)
+	}
+	return h.(*handler)
+}
+
+var start = time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func instance(traceID, spanID, parentSpanID string, tags map[string]interface{}) *tracespan.Instance {
+	return &tracespan.Instance{
+		Name:         "span",
+		TraceId:      traceID,
+		SpanId:       spanID,
+		ParentSpanId: parentSpanID,
+		SpanName:     "/reviews",
+		StartTime:    start,
+		EndTime:      start.Add(1500 * time.Microsecond),
+		SpanTags:     tags,
+	}
+}
+
+func TestGetInfo(t *testing.T) {
+	info := GetInfo()
+	if !reflect.DeepEqual(info.SupportedTemplates, []string{tracespan.TemplateName}) {
+		t.Errorf("SupportedTemplates: %v, want only %s", info.SupportedTemplates, tracespan.TemplateName)
+	}
+
+	b := info.NewBuilder()
+	b.SetAdapterConfig(info.DefaultConfig)
+	if err := b.Validate(); err != nil {
+		t.Errorf("Validate() of the default config => %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(p *config.Params)
+		field  string
+	}{
+		{"no URL", func(p *config.Params) { p.CollectorUrl = "" }, "collectorUrl"},
+		{"malformed URL", func(p *config.Params) { p.CollectorUrl = "http://[::1" }, "collectorUrl"},
+		{"not HTTP", func(p *config.Params) { p.CollectorUrl = "udp://localhost:6831" }, "collectorUrl"},
+		{"unknown format", func(p *config.Params) { p.Format = 42 }, "format"},
+		{"no service name", func(p *config.Params) { p.ServiceName = "" }, "serviceName"},
+		{"negative probability", func(p *config.Params) { p.SampleProbability = -0.1 }, "sampleProbability"},
+		{"probability above 1", func(p *config.Params) { p.SampleProbability = 1.1 }, "sampleProbability"},
+		{"no batch size", func(p *config.Params) { p.BatchSize = 0 }, "batchSize"},
+		{"no flush interval", func(p *config.Params) { p.FlushInterval = 0 }, "flushInterval"},
+		{"buffer smaller than batch", func(p *config.Params) { p.MaxBufferedSpans = p.BatchSize - 1 }, "maxBufferedSpans"},
+		{"negative retries", func(p *config.Params) { p.MaxRetries = -1 }, "maxRetries"},
+		{"negative backoff", func(p *config.Params) { p.RetryBackoff = -time.Second }, "retryBackoff"},
+		{"no timeout", func(p *config.Params) { p.Timeout = 0 }, "timeout"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := params("http://localhost:9411/api/v2/spans")
+			c.modify(p)
+
+			b := GetInfo().NewBuilder().(*builder)
+			b.SetAdapterConfig(p)
+			ce := b.Validate()
+			if ce == nil || len(ce.Multi.Errors) != 1 || ce.Multi.Errors[0].(adapter.ConfigError).Field != c.field {
+				t.Fatalf("Validate() => %v, want a single error for %s", ce, c.field)
+			}
+		})
+	}
+}
+
+func TestZipkin(t *testing.T) {
+	c := newCollector()
+	defer c.Close()
+
+	p := params(c.URL)
+	p.BatchSize = 2
+	p.ServiceNameTag = "destination.service"
+	h := build(t, p)
+	defer func() { _ = h.Close() }()
+
+	err := h.HandleTraceSpan(context.Background(), []*tracespan.Instance{
+		instance("463ac35c9f6413ad48485a3953bb6124", "a2fb4a1d1a96d312", "0020000000000001", map[string]interface{}{
+			"http.status_code":    int64(200),
+			"destination.service": "reviews.default.svc.cluster.local",
+		}),
+		instance("463ac35c9f6413ad", "b2fb4a1d1a96d312", "", nil),
+	})
+	if err != nil {
+		t.Fatalf("HandleTraceSpan() => %v", err)
+	}
+
+	// The batch is full, so it is sent without waiting for the flush interval.
+	c.wait(t, 1)
+
+	_, types := c.accepted()
+	if types[0] != "application/json" {
+		t.Errorf("Content-Type: %s, want application/json", types[0])
+	}
+
+	want := []zipkinSpan{
+		{
+			TraceID:       "463ac35c9f6413ad48485a3953bb6124",
+			ID:            "a2fb4a1d1a96d312",
+			ParentID:      "0020000000000001",
+			Name:          "/reviews",
+			Timestamp:     start.UnixNano() / 1000,
+			Duration:      1500,
+			LocalEndpoint: &zipkinEndpoint{ServiceName: "reviews.default.svc.cluster.local"},
+			Tags:          map[string]string{"http.status_code": "200"},
+		},
+		{
+			TraceID:       "463ac35c9f6413ad",
+			ID:            "b2fb4a1d1a96d312",
+			Name:          "/reviews",
+			Timestamp:     start.UnixNano() / 1000,
+			Duration:      1500,
+			LocalEndpoint: &zipkinEndpoint{ServiceName: "istio-mesh"},
+		},
+	}
+	if got := c.zipkinSpans(t); !reflect.DeepEqual(got, want) {
+		t.Errorf("spans:\n%+v\nwant:\n%+v", got, want)
+	}
+}
+
+func TestJaeger(t *testing.T) {
+	c := newCollector()
+	defer c.Close()
+
+	p := params(c.URL)
+	p.Format = config.JAEGER
+	p.ServiceNameTag = "destination.service"
+	h := build(t, p)
+
+	err := h.HandleTraceSpan(context.Background(), []*tracespan.Instance{
+		instance("463ac35c9f6413ad48485a3953bb6124", "a2fb4a1d1a96d312", "0020000000000001", map[string]interface{}{
+			"http.status_code":    int64(200),
+			"http.method":         "GET",
+			"destination.service": "reviews",
+		}),
+		instance("463ac35c9f6413ad", "b2fb4a1d1a96d312", "", nil),
+	})
+	if err != nil {
+		t.Fatalf("HandleTraceSpan() => %v", err)
+	}
+
+	// Closing the handler sends the buffered spans, one batch per service.
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() => %v", err)
+	}
+	c.wait(t, 2)
+
+	_, types := c.accepted()
+	if types[0] != "application/x-thrift" {
+		t.Errorf("Content-Type: %s, want application/x-thrift", types[0])
+	}
+
+	batches := c.jaegerBatches(t)
+	if len(batches) != 2 || batches[0].Process.ServiceName != "istio-mesh" || batches[1].Process.ServiceName != "reviews" {
+		t.Fatalf("batches: %v, want one for istio-mesh and one for reviews", batches)
+	}
+
+	status := int64(200)
+	method := "GET"
+	want := &jaeger.Span{
+		TraceIdLow:    0x48485a3953bb6124,
+		TraceIdHigh:   0x463ac35c9f6413ad,
+		SpanId:        -0x5d04b5e2e5692cee, // a2fb4a1d1a96d312
+		ParentSpanId:  0x0020000000000001,
+		OperationName: "/reviews",
+		Flags:         1,
+		StartTime:     start.UnixNano() / 1000,
+		Duration:      1500,
+		Tags: []*jaeger.Tag{
+			{Key: "http.method", VType: jaeger.TagType_STRING, VStr: &method},
+			{Key: "http.status_code", VType: jaeger.TagType_LONG, VLong: &status},
+		},
+	}
+	if len(batches[1].Spans) != 1 || !reflect.DeepEqual(batches[1].Spans[0], want) {
+		t.Errorf("spans: %v, want %v", batches[1].Spans, want)
+	}
+	if len(batches[0].Spans) != 1 || batches[0].Spans[0].TraceIdLow != 0x463ac35c9f6413ad || batches[0].Spans[0].TraceIdHigh != 0 {
+		t.Errorf("spans: %v, want the span of the 64 bit trace", batches[0].Spans)
+	}
+}
+
+func TestFlushInterval(t *testing.T) {
+	c := newCollector()
+	defer c.Close()
+
+	p := params(c.URL)
+	p.FlushInterval = 10 * time.Millisecond
+	h := build(t, p)
+	defer func() { _ = h.Close() }()
+
+	if err := h.HandleTraceSpan(context.Background(), []*tracespan.Instance{instance("1", "2", "", nil)}); err != nil {
+		t.Fatalf("HandleTraceSpan() => %v", err)
+	}
+
+	c.wait(t, 1)
+	if got := c.zipkinSpans(t); len(got) != 1 || got[0].ID != "0000000000000002" {
+		t.Errorf("spans: %+v, want the buffered span", got)
+	}
+}
+
+func TestSampling(t *testing.T) {
+	cases := []struct {
+		probability float64
+		traceIDLow  uint64
+		sampled     bool
+	}{
+		{0, 0, false},
+		{0, 1<<64 - 1, false},
+		{1, 0, true},
+		{1, 1<<64 - 1, true},
+		{0.5, 1<<63 - 1, true},
+		{0.5, 1 << 63, false},
+		{0.25, 1<<62 - 1, true},
+		{0.25, 1 << 62, false},
+	}
+
+	for _, c := range cases {
+		if got := newSampler(c.probability).sampled(c.traceIDLow); got != c.sampled {
+			t.Errorf("newSampler(%v).sampled(%x) => %v, want %v", c.probability, c.traceIDLow, got, c.sampled)
+		}
+	}
+}
+
+func TestSampling_Handler(t *testing.T) {
+	c := newCollector()
+	defer c.Close()
+
+	p := params(c.URL)
+	p.SampleProbability = 0.5
+	h := build(t, p)
+
+	// All the spans of a trace share the sampling decision.
+	err := h.HandleTraceSpan(context.Background(), []*tracespan.Instance{
+		instance("1", "1", "", nil),
+		instance("1", "2", "1", nil),
+		instance("ffffffffffffffff", "3", "", nil),
+		instance("ffffffffffffffff", "4", "3", nil),
+	})
+	if err != nil {
+		t.Fatalf("HandleTraceSpan() => %v", err)
+	}
+	_ = h.Close()
+	c.wait(t, 1)
+
+	got := c.zipkinSpans(t)
+	if len(got) != 2 || got[0].TraceID != "0000000000000001" || got[1].TraceID != "0000000000000001" {
+		t.Errorf("spans: %+v, want the spans of trace 1", got)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	cases := []struct {
+		name     string
+		statuses []int
+		requests int
+		accepted int
+	}{
+		{"unavailable", []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}, 3, 1},
+		{"too many requests", []int{http.StatusTooManyRequests}, 2, 1},
+		{"retries exhausted", []int{500, 500, 500, 500}, 4, 0},
+		{"bad request", []int{http.StatusBadRequest}, 1, 0},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			c := newCollector(cs.statuses...)
+			defer c.Close()
+
+			p := params(c.URL)
+			p.BatchSize = 1
+			p.MaxRetries = 3
+			h := build(t, p)
+			defer func() { _ = h.Close() }()
+
+			if err := h.HandleTraceSpan(context.Background(), []*tracespan.Instance{instance("1", "2", "", nil)}); err != nil {
+				t.Fatalf("HandleTraceSpan() => %v", err)
+			}
+
+			c.wait(t, cs.requests)
+			// Give the exporter a chance to send an unexpected request.
+			time.Sleep(50 * time.Millisecond)
+			if extra := len(c.received); extra != 0 {
+				t.Errorf("collector received %d more requests than %d", extra, cs.requests)
+			}
+			if bodies, _ := c.accepted(); len(bodies) != cs.accepted {
+				t.Errorf("collector accepted %d requests, want %d", len(bodies), cs.accepted)
+			}
+		})
+	}
+}
+
+func TestMaxBufferedSpans(t *testing.T) {
+	c := newCollector()
+	defer c.Close()
+
+	p := params(c.URL)
+	p.BatchSize = 2
+	p.MaxBufferedSpans = 2
+	env := test.NewEnv(t)
+	e := newExporter(p, env.Logger())
+
+	// The daemon is not started, so nothing is sent until the exporter is flushed.
+	for i := 0; i < 3; i++ {
+		e.add([]*span{{traceIDLow: 1, id: uint64(i + 1)}})
+	}
+	e.flush()
+	c.wait(t, 1)
+
+	if got := c.zipkinSpans(t); len(got) != 2 {
+		t.Errorf("spans: %+v, want the first 2", got)
+	}
+	if logs := strings.Join(env.GetLogs(), "\n"); !strings.Contains(logs, "Dropped 1 spans") {
+		t.Errorf("logs: %s, want the dropped span to be logged", logs)
+	}
+}
+
+func TestInvalidInstances(t *testing.T) {
+	c := newCollector()
+	defer c.Close()
+
+	h := build(t, params(c.URL))
+
+	err := h.HandleTraceSpan(context.Background(), []*tracespan.Instance{
+		instance("", "1", "", nil),
+		instance("463ac35c9f6413ad48485a3953bb61240", "1", "", nil),
+		instance("xyz", "1", "", nil),
+		instance("1", "xyz", "", nil),
+		instance("1", "1", "xyz", nil),
+		instance("1", "", "", nil),
+	})
+	if err == nil {
+		t.Fatalf("HandleTraceSpan() succeeded, want errors for the invalid IDs")
+	}
+	for _, want := range []string{"invalid trace ID \"\"", "invalid trace ID \"463ac", "invalid trace ID \"xyz\"",
+		"invalid span ID \"xyz\"", "invalid parent span ID \"xyz\""} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("HandleTraceSpan() => %v, want %s", err, want)
+		}
+	}
+
+	// The valid span is exported, with a generated span ID.
+	_ = h.Close()
+	c.wait(t, 1)
+	if got := c.zipkinSpans(t); len(got) != 1 || got[0].ID == "" || got[0].ID == "0000000000000000" {
+		t.Errorf("spans: %+v, want the span without a span ID", got)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+
+	"istio.io/istio/mixer/adapter/webhook/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/checkcache"
+)
+
+// resultCache keeps the results of the service, keyed by fields of the instances.
+type resultCache struct {
+	keyFields []string
+	results   *checkcache.Cache
+}
+
+func newResultCache(c *config.Params_Cache) *resultCache {
+	return &resultCache{
+		keyFields: c.KeyFields,
+		results:   checkcache.New(c.MaxEntries, 0),
+	}
+}
+
+// key returns the cache key of an instance: its template, its name and the key fields, or all its fields if no
+// key field is configured.
+func (c *resultCache) key(template string, inst fields) string {
+	parts := []interface{}{template, inst["name"]}
+	if len(c.keyFields) == 0 {
+		parts = append(parts, inst)
+	}
+	for _, f := range c.keyFields {
+		parts = append(parts, inst.lookup(f))
+	}
+
+	// The fields only hold JSON values, and the keys of maps are sorted.
+	b, _ := json.Marshal(parts)
+	return string(b)
+}
+
+// get returns the cached result for the key, with its remaining valid duration and use count.
+func (c *resultCache) get(key string) (adapter.CheckResult, bool) {
+	return c.results.Get(key)
+}
+
+// set caches a result of the service, for its valid duration and use count. Getting the result from the service
+// counts as one use.
+func (c *resultCache) set(key string, result adapter.CheckResult) {
+	c.results.Set(key, result)
+}
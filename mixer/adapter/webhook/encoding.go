@@ -0,0 +1,126 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"math"
+	"strings"
+
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/template/authorization"
+	"istio.io/istio/mixer/template/checknothing"
+	"istio.io/istio/mixer/template/listentry"
+)
+
+type (
+	// fields are the fields of an instance, as JSON values.
+	fields map[string]interface{}
+
+	// checkRequest is the body of the requests sent to the service.
+	checkRequest struct {
+		Template string `json:"template"`
+		Instance fields `json:"instance"`
+	}
+
+	// checkResponse is the body of the successful responses of the service.
+	checkResponse struct {
+		Code          int32  `json:"code"`
+		Message       string `json:"message"`
+		ValidDuration string `json:"validDuration"`
+		ValidUseCount *int32 `json:"validUseCount"`
+	}
+)
+
+// keyRoots are the top-level fields of the instances that can be used as cache keys.
+var keyRoots = map[string]bool{
+	"subject": true,
+	"action":  true,
+	"value":   true,
+}
+
+func authorizationFields(inst *authorization.Instance) fields {
+	f := fields{"name": inst.Name}
+	if s := inst.Subject; s != nil {
+		f["subject"] = fields{
+			"user":       s.User,
+			"groups":     s.Groups,
+			"properties": jsonValues(s.Properties),
+		}
+	}
+	if a := inst.Action; a != nil {
+		f["action"] = fields{
+			"namespace":  a.Namespace,
+			"service":    a.Service,
+			"method":     a.Method,
+			"path":       a.Path,
+			"properties": jsonValues(a.Properties),
+		}
+	}
+	return f
+}
+
+func checkNothingFields(inst *checknothing.Instance) fields {
+	return fields{"name": inst.Name}
+}
+
+func listEntryFields(inst *listentry.Instance) fields {
+	return fields{"name": inst.Name, "value": inst.Value}
+}
+
+// jsonValue returns the value as a JSON string, number or boolean.
+func jsonValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case nil, string, int64, bool:
+		return v
+	case float64:
+		// JSON has no representation for these.
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return adapter.Stringify(v)
+		}
+		return v
+	}
+	return adapter.Stringify(v)
+}
+
+func jsonValues(m map[string]interface{}) fields {
+	values := make(fields, len(m))
+	for k, v := range m {
+		values[k] = jsonValue(v)
+	}
+	return values
+}
+
+// lookup returns the value of a field, given as a dot separated path, for example action.properties.source.
+func (f fields) lookup(path string) interface{} {
+	for {
+		// the keys of the properties can contain dots.
+		if v, found := f[path]; found {
+			return v
+		}
+		i := strings.IndexByte(path, '.')
+		if i < 0 {
+			return nil
+		}
+		next, ok := f[path[:i]].(fields)
+		if !ok {
+			return nil
+		}
+		f, path = next, path[i+1:]
+	}
+}
+
+func validKeyField(path string) bool {
+	return keyRoots[strings.SplitN(path, ".", 2)[0]]
+}
@@ -0,0 +1,329 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate $GOPATH/src/istio.io/istio/bin/mixer_codegen.sh -f mixer/adapter/webhook/config/config.proto
+
+// Package webhook provides an adapter that delegates policy checks to an HTTP service.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	rpc "github.com/gogo/googleapis/google/rpc"
+
+	"istio.io/istio/mixer/adapter/webhook/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/adapter/util"
+	"istio.io/istio/mixer/pkg/status"
+	"istio.io/istio/mixer/template/authorization"
+	"istio.io/istio/mixer/template/checknothing"
+	"istio.io/istio/mixer/template/listentry"
+)
+
+type (
+	builder struct {
+		adapterConfig *config.Params
+	}
+
+	handler struct {
+		url      string
+		headers  map[string]string
+		failOpen bool
+
+		// valid duration and use count of the results that do not specify them.
+		validDuration time.Duration
+		validUseCount int32
+
+		client    *http.Client
+		transport *http.Transport
+
+		// nil when the results are not cached.
+		cache  *resultCache
+		logger adapter.Logger
+	}
+)
+
+// maxResponseSize is the maximum number of bytes of the responses of the service that are read.
+const maxResponseSize = 64 * 1024
+
+// ensure types implement the requisite interfaces
+var _ authorization.HandlerBuilder = &builder{}
+var _ authorization.Handler = &handler{}
+var _ checknothing.HandlerBuilder = &builder{}
+var _ checknothing.Handler = &handler{}
+var _ listentry.HandlerBuilder = &builder{}
+var _ listentry.Handler = &handler{}
+
+///////////////// Configuration-time Methods ///////////////
+
+// adapter.HandlerBuilder#Build
+func (b *builder) Build(_ context.Context, env adapter.Env) (adapter.Handler, error) {
+	ac := b.adapterConfig
+
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if ac.Tls != nil {
+		tlsConfig, err := util.NewTLSConfig(util.TLSOptions{
+			CaFile:             ac.Tls.CaFile,
+			CertFile:           ac.Tls.CertFile,
+			KeyFile:            ac.Tls.KeyFile,
+			ServerName:         ac.Tls.ServerName,
+			InsecureSkipVerify: ac.Tls.InsecureSkipVerify,
+		})
+		if err != nil {
+			return nil, env.Logger().Errorf("Unable to configure TLS: %v", err)
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	h := &handler{
+		url:           ac.Url,
+		headers:       ac.Headers,
+		failOpen:      ac.FailOpen,
+		validDuration: ac.CachingInterval,
+		validUseCount: ac.CachingUseCount,
+		client:        &http.Client{Transport: transport, Timeout: ac.Timeout},
+		transport:     transport,
+		logger:        env.Logger(),
+	}
+	if ac.Cache != nil {
+		h.cache = newResultCache(ac.Cache)
+	}
+	return h, nil
+}
+
+// adapter.HandlerBuilder#SetAdapterConfig
+func (b *builder) SetAdapterConfig(cfg adapter.Config) {
+	b.adapterConfig = cfg.(*config.Params)
+}
+
+// adapter.HandlerBuilder#Validate
+func (b *builder) Validate() (ce *adapter.ConfigErrors) {
+	ac := b.adapterConfig
+
+	if u, err := url.Parse(ac.Url); err != nil {
+		ce = ce.Appendf("url", "url %q is malformed: %v", ac.Url, err)
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		ce = ce.Appendf("url", "url %q must be an http or https URL", ac.Url)
+	} else if u.Host == "" {
+		ce = ce.Appendf("url", "url %q has no host", ac.Url)
+	}
+
+	if ac.Timeout <= 0 {
+		ce = ce.Appendf("timeout", "timeout must be > 0, got %v", ac.Timeout)
+	}
+
+	if ac.CachingInterval < 0 {
+		ce = ce.Appendf("cachingInterval", "caching interval must be >= 0, got %v", ac.CachingInterval)
+	}
+
+	if ac.CachingUseCount < 0 {
+		ce = ce.Appendf("cachingUseCount", "caching use count must be >= 0, got %d", ac.CachingUseCount)
+	}
+
+	if ac.Tls != nil && (ac.Tls.CertFile == "") != (ac.Tls.KeyFile == "") {
+		ce = ce.Appendf("tls", "both the client certificate and its key must be specified")
+	}
+
+	if ac.Cache != nil {
+		if ac.Cache.MaxEntries <= 0 {
+			ce = ce.Appendf("cache.maxEntries", "max entries must be > 0, got %d", ac.Cache.MaxEntries)
+		}
+		for _, f := range ac.Cache.KeyFields {
+			if !validKeyField(f) {
+				ce = ce.Appendf("cache.keyFields", "unknown field %q", f)
+			}
+		}
+	}
+
+	return ce
+}
+
+// authorization.HandlerBuilder#SetAuthorizationTypes
+func (b *builder) SetAuthorizationTypes(map[string]*authorization.Type) {}
+
+// checknothing.HandlerBuilder#SetCheckNothingTypes
+func (b *builder) SetCheckNothingTypes(map[string]*checknothing.Type) {}
+
+// listentry.HandlerBuilder#SetListEntryTypes
+func (b *builder) SetListEntryTypes(map[string]*listentry.Type) {}
+
+////////////////// Request-time Methods //////////////////////////
+
+// authorization.Handler#HandleAuthorization
+func (h *handler) HandleAuthorization(ctx context.Context, inst *authorization.Instance) (adapter.CheckResult, error) {
+	return h.check(ctx, authorization.TemplateName, authorizationFields(inst)), nil
+}
+
+// checknothing.Handler#HandleCheckNothing
+func (h *handler) HandleCheckNothing(ctx context.Context, inst *checknothing.Instance) (adapter.CheckResult, error) {
+	return h.check(ctx, checknothing.TemplateName, checkNothingFields(inst)), nil
+}
+
+// listentry.Handler#HandleListEntry
+func (h *handler) HandleListEntry(ctx context.Context, inst *listentry.Instance) (adapter.CheckResult, error) {
+	return h.check(ctx, listentry.TemplateName, listEntryFields(inst)), nil
+}
+
+// check returns the result of the service for the instance, from the cache if possible. Failures to get the result
+// allow or deny the request, according to the configuration.
+func (h *handler) check(ctx context.Context, template string, inst fields) adapter.CheckResult {
+	var key string
+	if h.cache != nil {
+		key = h.cache.key(template, inst)
+		if result, found := h.cache.get(key); found {
+			return result
+		}
+	}
+
+	result, err := h.post(ctx, template, inst)
+	if err != nil {
+		h.logger.Warningf("Unable to check %s instance %s with %s: %v", template, inst["name"], h.url, err)
+		if h.failOpen {
+			return adapter.CheckResult{Status: status.OK}
+		}
+		return adapter.CheckResult{Status: status.WithMessage(rpc.UNAVAILABLE, fmt.Sprintf("webhook: %v", err))}
+	}
+
+	if h.cache != nil {
+		h.cache.set(key, result)
+	}
+	return result
+}
+
+// post sends the instance to the service, and returns the result of the check from its response.
+func (h *handler) post(ctx context.Context, template string, inst fields) (adapter.CheckResult, error) {
+	body, err := json.Marshal(&checkRequest{Template: template, Instance: inst})
+	if err != nil {
+		return adapter.CheckResult{}, fmt.Errorf("unable to encode instance: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return adapter.CheckResult{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range h.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := h.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return adapter.CheckResult{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return adapter.CheckResult{}, fmt.Errorf("unable to read response: %v", err)
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return h.parseResult(b)
+	case resp.StatusCode == http.StatusUnauthorized:
+		return h.denial(rpc.UNAUTHENTICATED, resp.StatusCode, b), nil
+	case resp.StatusCode == http.StatusForbidden:
+		return h.denial(rpc.PERMISSION_DENIED, resp.StatusCode, b), nil
+	}
+	return adapter.CheckResult{}, fmt.Errorf("unexpected response status %s", resp.Status)
+}
+
+// parseResult returns the result held by the body of a successful response.
+func (h *handler) parseResult(body []byte) (adapter.CheckResult, error) {
+	result := adapter.CheckResult{
+		Status:        status.OK,
+		ValidDuration: h.validDuration,
+		ValidUseCount: h.validUseCount,
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return result, nil
+	}
+
+	var resp checkResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return adapter.CheckResult{}, fmt.Errorf("invalid response: %v", err)
+	}
+
+	if _, found := rpc.Code_name[resp.Code]; !found {
+		return adapter.CheckResult{}, fmt.Errorf("invalid response: unknown code %d", resp.Code)
+	}
+	result.Status = status.WithMessage(rpc.Code(resp.Code), resp.Message)
+
+	if resp.ValidDuration != "" {
+		d, err := time.ParseDuration(resp.ValidDuration)
+		if err != nil || d < 0 {
+			return adapter.CheckResult{}, fmt.Errorf("invalid response: invalid valid duration %q", resp.ValidDuration)
+		}
+		result.ValidDuration = d
+	}
+
+	if resp.ValidUseCount != nil {
+		if *resp.ValidUseCount < 0 {
+			return adapter.CheckResult{}, fmt.Errorf("invalid response: invalid valid use count %d", *resp.ValidUseCount)
+		}
+		result.ValidUseCount = *resp.ValidUseCount
+	}
+
+	return result, nil
+}
+
+// denial returns the result of a response that denies the request, with its body as message.
+func (h *handler) denial(code rpc.Code, statusCode int, body []byte) adapter.CheckResult {
+	msg := strings.TrimSpace(string(body))
+	if msg == "" {
+		msg = http.StatusText(statusCode)
+	}
+	return adapter.CheckResult{
+		Status:        status.WithMessage(code, msg),
+		ValidDuration: h.validDuration,
+		ValidUseCount: h.validUseCount,
+	}
+}
+
+// adapter.Handler#Close
+func (h *handler) Close() error {
+	h.transport.CloseIdleConnections()
+	return nil
+}
+
+////////////////// Bootstrap //////////////////////////
+
+// GetInfo returns the adapter.Info specific to this adapter.
+func GetInfo() adapter.Info {
+	return adapter.Info{
+		Name:        "webhook",
+		Impl:        "istio.io/istio/mixer/adapter/webhook",
+		Description: "Delegates policy checks to an HTTP service",
+		SupportedTemplates: []string{
+			authorization.TemplateName,
+			checknothing.TemplateName,
+			listentry.TemplateName,
+		},
+		NewBuilder: func() adapter.HandlerBuilder { return &builder{} },
+		DefaultConfig: &config.Params{
+			Timeout:         time.Second,
+			CachingInterval: 60 * time.Second,
+			CachingUseCount: 10000,
+		},
+	}
+}
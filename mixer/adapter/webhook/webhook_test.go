@@ -0,0 +1,577 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rpc "github.com/gogo/googleapis/google/rpc"
+
+	"istio.io/istio/mixer/adapter/webhook/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/adapter/test"
+	"istio.io/istio/mixer/template/authorization"
+	"istio.io/istio/mixer/template/checknothing"
+	"istio.io/istio/mixer/template/listentry"
+)
+
+// service is a stand-in for a policy service, which records the requests it receives.
+type service struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []checkRequest
+	headers  []http.Header
+
+	calls int32
+
+	// response returns the status code and body of the response to a request.
+	response func(req checkRequest) (int, string)
+}
+
+func newService(t *testing.T, response func(req checkRequest) (int, string)) *service {
+	s := &service{response: response}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { s.serve(t, w, r) }))
+	return s
+}
+
+func (s *service) serve(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&s.calls, 1)
+	if r.Method != http.MethodPost {
+		t.Errorf("method => %s, want POST", r.Method)
+	}
+	var req checkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		t.Errorf("invalid request: %v", err)
+	}
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.headers = append(s.headers, r.Header)
+	s.mu.Unlock()
+
+	code, body := s.response(req)
+	w.WriteHeader(code)
+	_, _ = w.Write([]byte(body))
+}
+
+func respond(code int, body string) func(checkRequest) (int, string) {
+	return func(checkRequest) (int, string) { return code, body }
+}
+
+func newHandler(t *testing.T, ac *config.Params) *handler {
+	b := GetInfo().NewBuilder().(*builder)
+	b.SetAdapterConfig(ac)
+	if err := b.Validate(); err != nil {
+		t.Fatalf("Validate() => %v", err)
+	}
+	h, err := b.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Build() => %v", err)
+	}
+	return h.(*handler)
+}
+
+func newConfig(url string) *config.Params {
+	ac := GetInfo().DefaultConfig.(*config.Params)
+	ac.Url = url
+	return ac
+}
+
+var authz = &authorization.Instance{
+	Name: "authz",
+	Subject: &authorization.Subject{
+		User:       "alice",
+		Groups:     "admins",
+		Properties: map[string]interface{}{"source.ip": net.ParseIP("10.0.0.1"), "port": int64(8080)},
+	},
+	Action: &authorization.Action{
+		Namespace: "default",
+		Service:   "ratings",
+		Method:    "GET",
+		Path:      "/ratings",
+	},
+}
+
+func TestGetInfo(t *testing.T) {
+	info := GetInfo()
+	if info.Name != "webhook" {
+		t.Errorf("GetInfo().Name => %s, want webhook", info.Name)
+	}
+	want := []string{authorization.TemplateName, checknothing.TemplateName, listentry.TemplateName}
+	if !reflect.DeepEqual(info.SupportedTemplates, want) {
+		t.Errorf("GetInfo().SupportedTemplates => %v, want %v", info.SupportedTemplates, want)
+	}
+
+	b := info.NewBuilder()
+	b.SetAdapterConfig(newConfig("http://localhost:8080/check"))
+	if err := b.Validate(); err != nil {
+		t.Errorf("Validate() of the default config => %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		field  string
+		modify func(ac *config.Params)
+	}{
+		{"url", func(ac *config.Params) { ac.Url = "" }},
+		{"url", func(ac *config.Params) { ac.Url = "ftp://localhost/check" }},
+		{"url", func(ac *config.Params) { ac.Url = "http://%zz" }},
+		{"timeout", func(ac *config.Params) { ac.Timeout = 0 }},
+		{"cachingInterval", func(ac *config.Params) { ac.CachingInterval = -time.Second }},
+		{"cachingUseCount", func(ac *config.Params) { ac.CachingUseCount = -1 }},
+		{"tls", func(ac *config.Params) { ac.Tls = &config.Params_TLS{CertFile: "cert.pem"} }},
+		{"cache.maxEntries", func(ac *config.Params) { ac.Cache = &config.Params_Cache{} }},
+		{"cache.keyFields", func(ac *config.Params) {
+			ac.Cache = &config.Params_Cache{MaxEntries: 10, KeyFields: []string{"subject.user", "destination"}}
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.field, func(t *testing.T) {
+			ac := newConfig("https://policy.example.com/check")
+			c.modify(ac)
+			b := GetInfo().NewBuilder()
+			b.SetAdapterConfig(ac)
+			ce := b.Validate()
+			if ce == nil || len(ce.Multi.Errors) != 1 {
+				t.Fatalf("Validate() => %v, want a single error for %s", ce, c.field)
+			}
+			if got := ce.Multi.Errors[0].(adapter.ConfigError).Field; got != c.field {
+				t.Errorf("Validate() => error for %s, want %s", got, c.field)
+			}
+		})
+	}
+}
+
+func TestHandleAuthorization(t *testing.T) {
+	s := newService(t, respond(http.StatusOK, `{"code": 7, "message": "no way", "validDuration": "30s", "validUseCount": 5}`))
+	defer s.Close()
+
+	ac := newConfig(s.URL)
+	ac.Headers = map[string]string{"Authorization": "Bearer secret"}
+	h := newHandler(t, ac)
+	defer func() { _ = h.Close() }()
+
+	got, err := h.HandleAuthorization(context.Background(), authz)
+	if err != nil {
+		t.Fatalf("HandleAuthorization() => %v", err)
+	}
+	want := adapter.CheckResult{
+		Status:        rpc.Status{Code: int32(rpc.PERMISSION_DENIED), Message: "no way"},
+		ValidDuration: 30 * time.Second,
+		ValidUseCount: 5,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HandleAuthorization() => %v, want %v", got, want)
+	}
+
+	if len(s.requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(s.requests))
+	}
+	wantReq := checkRequest{
+		Template: authorization.TemplateName,
+		Instance: fields{
+			"name": "authz",
+			"subject": map[string]interface{}{
+				"user":       "alice",
+				"groups":     "admins",
+				"properties": map[string]interface{}{"source.ip": "10.0.0.1", "port": float64(8080)},
+			},
+			"action": map[string]interface{}{
+				"namespace":  "default",
+				"service":    "ratings",
+				"method":     "GET",
+				"path":       "/ratings",
+				"properties": map[string]interface{}{},
+			},
+		},
+	}
+	if !reflect.DeepEqual(s.requests[0], wantReq) {
+		t.Errorf("request => %v, want %v", s.requests[0], wantReq)
+	}
+	if got := s.headers[0].Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization header => %q, want %q", got, "Bearer secret")
+	}
+	if got := s.headers[0].Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type header => %q, want application/json", got)
+	}
+}
+
+func TestHandleCheckNothingAndListEntry(t *testing.T) {
+	s := newService(t, respond(http.StatusOK, ""))
+	defer s.Close()
+	h := newHandler(t, newConfig(s.URL))
+
+	want := adapter.CheckResult{ValidDuration: 60 * time.Second, ValidUseCount: 10000}
+	got, err := h.HandleCheckNothing(context.Background(), &checknothing.Instance{Name: "nothing"})
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("HandleCheckNothing() => %v, %v, want %v", got, err, want)
+	}
+	got, err = h.HandleListEntry(context.Background(), &listentry.Instance{Name: "entry", Value: "10.0.0.1"})
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("HandleListEntry() => %v, %v, want %v", got, err, want)
+	}
+
+	wantReqs := []checkRequest{
+		{Template: checknothing.TemplateName, Instance: fields{"name": "nothing"}},
+		{Template: listentry.TemplateName, Instance: fields{"name": "entry", "value": "10.0.0.1"}},
+	}
+	if !reflect.DeepEqual(s.requests, wantReqs) {
+		t.Errorf("requests => %v, want %v", s.requests, wantReqs)
+	}
+}
+
+func TestResponses(t *testing.T) {
+	cases := []struct {
+		name     string
+		code     int
+		body     string
+		failOpen bool
+		want     adapter.CheckResult
+	}{
+		{"empty", http.StatusOK, "", false,
+			adapter.CheckResult{ValidDuration: time.Minute, ValidUseCount: 10000}},
+		{"ok", http.StatusOK, `{"code": 0}`, false,
+			adapter.CheckResult{ValidDuration: time.Minute, ValidUseCount: 10000}},
+		{"no content", http.StatusNoContent, "", false,
+			adapter.CheckResult{ValidDuration: time.Minute, ValidUseCount: 10000}},
+		{"zero valid use count", http.StatusOK, `{"validUseCount": 0, "validDuration": "0s"}`, false,
+			adapter.CheckResult{}},
+		{"unauthorized", http.StatusUnauthorized, "who are you?\n", false,
+			adapter.CheckResult{Status: rpc.Status{Code: int32(rpc.UNAUTHENTICATED), Message: "who are you?"},
+				ValidDuration: time.Minute, ValidUseCount: 10000}},
+		{"forbidden", http.StatusForbidden, "", false,
+			adapter.CheckResult{Status: rpc.Status{Code: int32(rpc.PERMISSION_DENIED), Message: "Forbidden"},
+				ValidDuration: time.Minute, ValidUseCount: 10000}},
+		{"server error, fail open", http.StatusInternalServerError, "", true,
+			adapter.CheckResult{}},
+		{"server error, fail close", http.StatusInternalServerError, "", false,
+			adapter.CheckResult{Status: rpc.Status{Code: int32(rpc.UNAVAILABLE),
+				Message: "webhook: unexpected response status 500 Internal Server Error"}}},
+		{"invalid JSON", http.StatusOK, `{"code": `, false,
+			adapter.CheckResult{Status: rpc.Status{Code: int32(rpc.UNAVAILABLE),
+				Message: "webhook: invalid response: unexpected end of JSON input"}}},
+		{"unknown code", http.StatusOK, `{"code": 99}`, false,
+			adapter.CheckResult{Status: rpc.Status{Code: int32(rpc.UNAVAILABLE),
+				Message: "webhook: invalid response: unknown code 99"}}},
+		{"invalid valid duration", http.StatusOK, `{"validDuration": "soon"}`, false,
+			adapter.CheckResult{Status: rpc.Status{Code: int32(rpc.UNAVAILABLE),
+				Message: `webhook: invalid response: invalid valid duration "soon"`}}},
+		{"invalid valid use count", http.StatusOK, `{"validUseCount": -1}`, false,
+			adapter.CheckResult{Status: rpc.Status{Code: int32(rpc.UNAVAILABLE),
+				Message: "webhook: invalid response: invalid valid use count -1"}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := newService(t, respond(c.code, c.body))
+			defer s.Close()
+
+			ac := newConfig(s.URL)
+			ac.FailOpen = c.failOpen
+			h := newHandler(t, ac)
+
+			got, err := h.HandleCheckNothing(context.Background(), &checknothing.Instance{Name: "nothing"})
+			if err != nil {
+				t.Fatalf("HandleCheckNothing() => %v", err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("HandleCheckNothing() => %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	s := newService(t, func(checkRequest) (int, string) {
+		<-release
+		return http.StatusOK, ""
+	})
+	defer s.Close()
+	defer close(release)
+
+	ac := newConfig(s.URL)
+	ac.Timeout = 10 * time.Millisecond
+	h := newHandler(t, ac)
+
+	got, _ := h.HandleCheckNothing(context.Background(), &checknothing.Instance{Name: "nothing"})
+	if got.Status.Code != int32(rpc.UNAVAILABLE) {
+		t.Errorf("HandleCheckNothing() => %v, want UNAVAILABLE", got)
+	}
+
+	// the deadline of the context is honored as well.
+	ac.Timeout = time.Minute
+	h = newHandler(t, ac)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	got, _ = h.HandleCheckNothing(ctx, &checknothing.Instance{Name: "nothing"})
+	if got.Status.Code != int32(rpc.UNAVAILABLE) {
+		t.Errorf("HandleCheckNothing() => %v, want UNAVAILABLE", got)
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	s := newService(t, respond(http.StatusOK, ""))
+	s.Close()
+
+	ac := newConfig(s.URL)
+	h := newHandler(t, ac)
+	got, err := h.HandleCheckNothing(context.Background(), &checknothing.Instance{Name: "nothing"})
+	if err != nil || got.Status.Code != int32(rpc.UNAVAILABLE) {
+		t.Errorf("HandleCheckNothing() => %v, %v, want UNAVAILABLE by default", got, err)
+	}
+
+	ac.FailOpen = true
+	h = newHandler(t, ac)
+	got, err = h.HandleCheckNothing(context.Background(), &checknothing.Instance{Name: "nothing"})
+	if err != nil || got.Status.Code != int32(rpc.OK) {
+		t.Errorf("HandleCheckNothing() => %v, %v, want OK when failing open", got, err)
+	}
+}
+
+func TestCache(t *testing.T) {
+	s := newService(t, func(req checkRequest) (int, string) {
+		if req.Instance["subject"].(map[string]interface{})["user"] == "mallory" {
+			return http.StatusForbidden, "go away"
+		}
+		return http.StatusOK, `{"validUseCount": 3}`
+	})
+	defer s.Close()
+
+	ac := newConfig(s.URL)
+	ac.Cache = &config.Params_Cache{MaxEntries: 10, KeyFields: []string{"subject.user", "action.path"}}
+	h := newHandler(t, ac)
+
+	check := func(user, method, path string) adapter.CheckResult {
+		inst := &authorization.Instance{
+			Name:    "authz",
+			Subject: &authorization.Subject{User: user},
+			Action:  &authorization.Action{Method: method, Path: path},
+		}
+		got, err := h.HandleAuthorization(context.Background(), inst)
+		if err != nil {
+			t.Fatalf("HandleAuthorization() => %v", err)
+		}
+		return got
+	}
+
+	if got := check("alice", "GET", "/ratings"); got.ValidUseCount != 3 {
+		t.Errorf("HandleAuthorization() => %v, want 3 uses", got)
+	}
+	// the method is not a key field.
+	if got := check("alice", "POST", "/ratings"); got.ValidUseCount != 2 || got.ValidDuration > time.Minute {
+		t.Errorf("HandleAuthorization() => %v, want the cached result with 2 uses left", got)
+	}
+	if calls := atomic.LoadInt32(&s.calls); calls != 1 {
+		t.Errorf("service called %d times, want 1", calls)
+	}
+
+	check("alice", "GET", "/reviews")
+	if got := check("mallory", "GET", "/ratings"); got.Status.Code != int32(rpc.PERMISSION_DENIED) {
+		t.Errorf("HandleAuthorization() => %v, want PERMISSION_DENIED", got)
+	}
+	if got := check("mallory", "GET", "/ratings"); got.Status.Code != int32(rpc.PERMISSION_DENIED) {
+		t.Errorf("HandleAuthorization() => %v, want the cached PERMISSION_DENIED", got)
+	}
+	if calls := atomic.LoadInt32(&s.calls); calls != 3 {
+		t.Errorf("service called %d times, want 3", calls)
+	}
+
+	// the result is used up.
+	check("alice", "GET", "/ratings")
+	check("alice", "GET", "/ratings")
+	if calls := atomic.LoadInt32(&s.calls); calls != 4 {
+		t.Errorf("service called %d times, want 4", calls)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	all := &resultCache{}
+	a := all.key(authorization.TemplateName, authorizationFields(authz))
+	b := all.key(authorization.TemplateName, authorizationFields(authz))
+	if a != b {
+		t.Errorf("key() => %s and %s for the same instance", a, b)
+	}
+	other := *authz
+	other.Action = &authorization.Action{Path: "/reviews"}
+	if all.key(authorization.TemplateName, authorizationFields(&other)) == a {
+		t.Error("key() => same key for instances with different fields")
+	}
+
+	c := &resultCache{keyFields: []string{"subject.properties.source.ip", "value"}}
+	want := `["authorization","authz","10.0.0.1",null]`
+	if got := c.key(authorization.TemplateName, authorizationFields(authz)); got != want {
+		t.Errorf("key() => %s, want %s", got, want)
+	}
+	want = `["listentry","entry",null,"x"]`
+	if got := c.key(listentry.TemplateName, listEntryFields(&listentry.Instance{Name: "entry", Value: "x"})); got != want {
+		t.Errorf("key() => %s, want %s", got, want)
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	serverCert, serverPEM := newCertificate(t, dir, "server")
+	_, clientPEM := newCertificate(t, dir, "client")
+	clientPool := x509.NewCertPool()
+	clientPool.AppendCertsFromPEM(clientPEM)
+
+	s := &service{response: respond(http.StatusOK, "")}
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { s.serve(t, w, r) }))
+	s.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	s.StartTLS()
+	defer s.Close()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	if err = ioutil.WriteFile(caFile, serverPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ac := newConfig(s.URL)
+	ac.Tls = &config.Params_TLS{
+		CaFile:     caFile,
+		CertFile:   filepath.Join(dir, "client.pem"),
+		KeyFile:    filepath.Join(dir, "client.key"),
+		ServerName: "server",
+	}
+	h := newHandler(t, ac)
+	got, _ := h.HandleCheckNothing(context.Background(), &checknothing.Instance{Name: "nothing"})
+	if got.Status.Code != int32(rpc.OK) {
+		t.Errorf("HandleCheckNothing() => %v, want OK", got)
+	}
+
+	// without the client certificate.
+	ac.Tls.CertFile, ac.Tls.KeyFile = "", ""
+	h = newHandler(t, ac)
+	got, _ = h.HandleCheckNothing(context.Background(), &checknothing.Instance{Name: "nothing"})
+	if got.Status.Code != int32(rpc.UNAVAILABLE) {
+		t.Errorf("HandleCheckNothing() => %v, want UNAVAILABLE", got)
+	}
+}
+
+func TestBuild_TLSErrors(t *testing.T) {
+	cases := []struct {
+		tls  *config.Params_TLS
+		want string
+	}{
+		{&config.Params_TLS{CaFile: "/does/not/exist"}, "unable to read CA certificates"},
+		{&config.Params_TLS{CaFile: "webhook_test.go"}, "no CA certificate found in"},
+		{&config.Params_TLS{CertFile: "/does/not/exist", KeyFile: "/does/not/exist"}, "unable to load client certificate"},
+	}
+
+	for _, c := range cases {
+		ac := newConfig("https://localhost:8443/check")
+		ac.Tls = c.tls
+		b := GetInfo().NewBuilder()
+		b.SetAdapterConfig(ac)
+		if _, err := b.Build(context.Background(), test.NewEnv(t)); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("Build() => %v, want an error containing %q", err, c.want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	f := authorizationFields(authz)
+	cases := map[string]interface{}{
+		"name":                         "authz",
+		"subject.user":                 "alice",
+		"subject.properties.source.ip": "10.0.0.1",
+		"subject.properties.port":      int64(8080),
+		"action.path":                  "/ratings",
+		"action.properties.missing":    nil,
+		"subject.user.missing":         nil,
+		"value":                        nil,
+	}
+	for path, want := range cases {
+		if got := f.lookup(path); got != want {
+			t.Errorf("lookup(%s) => %v, want %v", path, got, want)
+		}
+	}
+}
+
+// newCertificate writes a self-signed certificate for the name and its key to <name>.pem and <name>.key in the
+// directory, and returns the certificate and its PEM encoding.
+func newCertificate(t *testing.T, dir, name string) (tls.Certificate, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err = ioutil.WriteFile(filepath.Join(dir, name+".pem"), certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, name+".key"), keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, certPEM
+}
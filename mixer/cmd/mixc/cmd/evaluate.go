@@ -0,0 +1,80 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	mixerpb "istio.io/api/mixer/v1"
+	"istio.io/istio/mixer/cmd/shared"
+)
+
+func evaluateCmd(rootArgs *rootArgs, printf, fatalf shared.FormatFn) *cobra.Command {
+	monitoringAddress := "localhost:9093"
+
+	cmd := &cobra.Command{
+		Use:   "evaluate",
+		Short: "Shows how Mixer's configuration applies to a set of attributes, without invoking any adapter.",
+		Long: "Mixer evaluates the set of attributes against its current configuration, the\n" +
+			"same way it would for Check and Report calls. It returns, for each API method,\n" +
+			"the handlers that would be dispatched to, the result of the rule match\n" +
+			"expressions, and the instances that would be sent to the handlers, as JSON.\n" +
+			"No adapter is invoked.",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			evaluate(rootArgs, monitoringAddress, printf, fatalf)
+		}}
+
+	cmd.PersistentFlags().StringVarP(&monitoringAddress, "monitoring", "", monitoringAddress,
+		"Address and port of the monitoring endpoint of a running Mixer instance")
+
+	return cmd
+}
+
+func evaluate(rootArgs *rootArgs, monitoringAddress string, printf, fatalf shared.FormatFn) {
+	var attrs *mixerpb.CompressedAttributes
+	var err error
+
+	if attrs, err = parseAttributes(rootArgs); err != nil {
+		fatalf("%v", err)
+	}
+
+	var body []byte
+	if body, err = attrs.Marshal(); err != nil {
+		fatalf("Unable to encode the attributes: %v", err)
+	}
+
+	url := "http://" + monitoringAddress + "/debug/evaluate"
+	resp, err := http.Post(url, "application/octet-stream", bytes.NewReader(body))
+	if err != nil {
+		fatalf("Unable to reach %s: %v", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if body, err = ioutil.ReadAll(resp.Body); err != nil {
+		fatalf("Unable to read the evaluation: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		fatalf("Evaluation failed: %s", strings.TrimSpace(string(body)))
+	}
+
+	printf("%s", body)
+}
@@ -70,6 +70,11 @@ func addAttributeFlags(cmd *cobra.Command, rootArgs *rootArgs) {
 	cmd.PersistentFlags().IntVarP(&rootArgs.repeat, "repeat", "r", 1,
 		"Sends the specified number of requests in quick succession")
 
+	addAttributeValueFlags(cmd, rootArgs)
+}
+
+// addAttributeValueFlags adds the flags that specify the attributes of a request.
+func addAttributeValueFlags(cmd *cobra.Command, rootArgs *rootArgs) {
 	cmd.PersistentFlags().StringVarP(&rootArgs.attributes, "attributes", "a", "",
 		"List of name/value auto-sensed attributes specified as name1=value1,name2=value2,...")
 	cmd.PersistentFlags().StringVarP(&rootArgs.stringAttributes, "string_attributes", "s", "",
@@ -88,7 +93,6 @@ func addAttributeFlags(cmd *cobra.Command, rootArgs *rootArgs) {
 		"List of name/value bytes attributes specified as name1=b0:b1:b3,name2=b4:b5:b6,...")
 	cmd.PersistentFlags().StringVarP(&rootArgs.stringMapAttributes, "stringmap_attributes", "", "",
 		"List of name/value string map attributes specified as name1=k1:v1;k2:v2,name2=k3:v3...")
-
 }
 
 // GetRootCmd returns the root of the cobra command-tree.
@@ -116,15 +120,18 @@ func GetRootCmd(args []string, printf, fatalf shared.FormatFn) *cobra.Command {
 
 	cc := checkCmd(rootArgs, printf, fatalf)
 	rc := reportCmd(rootArgs, printf, fatalf)
+	ec := evaluateCmd(rootArgs, printf, fatalf)
 
 	addAttributeFlags(cc, rootArgs)
 	addAttributeFlags(rc, rootArgs)
+	addAttributeValueFlags(ec, rootArgs)
 
 	rootArgs.tracingOptions.AttachCobraFlags(cc)
 	rootArgs.tracingOptions.AttachCobraFlags(rc)
 
 	rootCmd.AddCommand(cc)
 	rootCmd.AddCommand(rc)
+	rootCmd.AddCommand(ec)
 	rootCmd.AddCommand(version.CobraCommand())
 	rootCmd.AddCommand(collateral.CobraCommand(rootCmd, &doc.GenManHeader{
 		Title:   "Istio Mixer Client",
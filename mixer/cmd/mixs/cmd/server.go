@@ -54,6 +54,22 @@ func serverCmd(info map[string]template.Info, adapters []adapter.InfoFn, printf,
 	serverCmd.PersistentFlags().BoolVarP(&sa.SingleThreaded, "singleThreaded", "", sa.SingleThreaded,
 		"If true, each request to Mixer will be executed in a single go routine (useful for debugging)")
 
+	serverCmd.PersistentFlags().IntVarP(&sa.ReportQueueSize, "reportQueueSize", "", sa.ReportQueueSize,
+		"Max number of report instances queued per handler. If 0, reports are dispatched synchronously. "+
+			"Preprocessing is not queued")
+	serverCmd.PersistentFlags().IntVarP(&sa.ReportBatchSize, "reportBatchSize", "", sa.ReportBatchSize,
+		"Number of queued report instances that triggers a dispatch to the handler")
+	serverCmd.PersistentFlags().DurationVarP(&sa.ReportFlushInterval, "reportFlushInterval", "", sa.ReportFlushInterval,
+		"Max amount of time report instances stay queued before being dispatched to the handler")
+	serverCmd.PersistentFlags().BoolVarP(&sa.ReportDropWhenFull, "reportDropWhenFull", "", sa.ReportDropWhenFull,
+		"If true, report instances are dropped when the queue of a handler is full, instead of blocking the report. "+
+			"Handlers override it with the istio-report-queue-full label")
+
+	serverCmd.PersistentFlags().IntVarP(&sa.CheckCacheSize, "checkCacheSize", "", sa.CheckCacheSize,
+		"Max number of check results cached. If 0, check results are not cached")
+	serverCmd.PersistentFlags().DurationVarP(&sa.CheckCacheMaxValidDuration, "checkCacheMaxValidDuration", "", sa.CheckCacheMaxValidDuration,
+		"Max amount of time check results stay cached, regardless of the valid duration returned by the adapters")
+
 	serverCmd.PersistentFlags().StringVarP(&sa.ConfigStoreURL, "configStoreURL", "", sa.ConfigStoreURL,
 		"URL of the config store. Use k8s://path_to_kubeconfig or fs:// for file system. If path_to_kubeconfig is empty, in-cluster kubeconfig is used.")
 
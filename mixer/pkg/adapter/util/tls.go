@@ -0,0 +1,71 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package util contains helpers shared by the adapters that talk to external services.
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// TLSOptions are the TLS settings of the connections of an adapter to an external service.
+type TLSOptions struct {
+	// CaFile is the path of the file containing the certificates of the certificate authorities that sign the
+	// certificate of the service. The system certificate authorities are used when empty.
+	CaFile string
+
+	// CertFile is the path of the file containing the client certificate, for mutual TLS.
+	CertFile string
+
+	// KeyFile is the path of the file containing the key of the client certificate.
+	KeyFile string
+
+	// ServerName is used to verify the certificate of the service, when it differs from its address.
+	ServerName string
+
+	// InsecureSkipVerify disables the verification of the certificate of the service.
+	InsecureSkipVerify bool
+}
+
+// NewTLSConfig returns the client TLS configuration for the options, loading the certificates they refer to.
+func NewTLSConfig(o TLSOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.CaFile != "" {
+		pem, err := ioutil.ReadFile(o.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA certificates: %v", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no CA certificate found in %s", o.CaFile)
+		}
+	}
+
+	if o.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	cfg, err := NewTLSConfig(TLSOptions{ServerName: "svc.example.com", InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("NewTLSConfig() => %v", err)
+	}
+	if cfg.ServerName != "svc.example.com" || !cfg.InsecureSkipVerify || cfg.RootCAs != nil || len(cfg.Certificates) != 0 {
+		t.Errorf("NewTLSConfig() => %+v", cfg)
+	}
+}
+
+func TestNewTLSConfig_Errors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	notPEM := filepath.Join(dir, "ca.pem")
+	if err = ioutil.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		opts TLSOptions
+		err  string
+	}{
+		{TLSOptions{CaFile: filepath.Join(dir, "missing.pem")}, "unable to read CA certificates"},
+		{TLSOptions{CaFile: notPEM}, "no CA certificate found"},
+		{TLSOptions{CertFile: notPEM, KeyFile: notPEM}, "unable to load client certificate"},
+	}
+
+	for _, c := range cases {
+		if _, err := NewTLSConfig(c.opts); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("NewTLSConfig(%+v) => %v, want %s", c.opts, err, c.err)
+		}
+	}
+}
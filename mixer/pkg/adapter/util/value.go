@@ -0,0 +1,74 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/adapter"
+)
+
+// UnsupportedTypeError is returned by NewValue for values that have no representation as a Value.
+type UnsupportedTypeError struct {
+	Value interface{}
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("unsupported value type %T", e.Value)
+}
+
+// NewValue encodes a dynamically typed value of an instance as an istio.mixer.adapter.model.v1beta1.Value.
+// It returns nil for nil values, and an *UnsupportedTypeError for values of other types than the value types
+// of the attributes.
+func NewValue(v interface{}) (*v1beta1.Value, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return &v1beta1.Value{Value: &v1beta1.Value_StringValue{StringValue: t}}, nil
+	case int64:
+		return &v1beta1.Value{Value: &v1beta1.Value_Int64Value{Int64Value: t}}, nil
+	case float64:
+		return &v1beta1.Value{Value: &v1beta1.Value_DoubleValue{DoubleValue: t}}, nil
+	case bool:
+		return &v1beta1.Value{Value: &v1beta1.Value_BoolValue{BoolValue: t}}, nil
+	case time.Time:
+		ts, err := types.TimestampProto(t)
+		if err != nil {
+			return nil, err
+		}
+		return &v1beta1.Value{Value: &v1beta1.Value_TimestampValue{TimestampValue: &v1beta1.TimeStamp{Value: ts}}}, nil
+	case time.Duration:
+		return &v1beta1.Value{Value: &v1beta1.Value_DurationValue{
+			DurationValue: &v1beta1.Duration{Value: types.DurationProto(t)}}}, nil
+	case net.IP:
+		return &v1beta1.Value{Value: &v1beta1.Value_IpAddressValue{IpAddressValue: &v1beta1.IPAddress{Value: t}}}, nil
+	case []byte:
+		return &v1beta1.Value{Value: &v1beta1.Value_IpAddressValue{IpAddressValue: &v1beta1.IPAddress{Value: t}}}, nil
+	case adapter.DNSName:
+		return &v1beta1.Value{Value: &v1beta1.Value_DnsNameValue{DnsNameValue: &v1beta1.DNSName{Value: string(t)}}}, nil
+	case adapter.EmailAddress:
+		return &v1beta1.Value{Value: &v1beta1.Value_EmailAddressValue{
+			EmailAddressValue: &v1beta1.EmailAddress{Value: string(t)}}}, nil
+	case adapter.URI:
+		return &v1beta1.Value{Value: &v1beta1.Value_UriValue{UriValue: &v1beta1.Uri{Value: string(t)}}}, nil
+	}
+	return nil, &UnsupportedTypeError{Value: v}
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/adapter"
+)
+
+func TestNewValue(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	ts, _ := types.TimestampProto(start)
+
+	cases := []struct {
+		value interface{}
+		want  *v1beta1.Value
+	}{
+		{nil, nil},
+		{"src1", &v1beta1.Value{Value: &v1beta1.Value_StringValue{StringValue: "src1"}}},
+		{int64(42), &v1beta1.Value{Value: &v1beta1.Value_Int64Value{Int64Value: 42}}},
+		{1.5, &v1beta1.Value{Value: &v1beta1.Value_DoubleValue{DoubleValue: 1.5}}},
+		{true, &v1beta1.Value{Value: &v1beta1.Value_BoolValue{BoolValue: true}}},
+		{start, &v1beta1.Value{Value: &v1beta1.Value_TimestampValue{TimestampValue: &v1beta1.TimeStamp{Value: ts}}}},
+		{10 * time.Millisecond, &v1beta1.Value{Value: &v1beta1.Value_DurationValue{
+			DurationValue: &v1beta1.Duration{Value: types.DurationProto(10 * time.Millisecond)}}}},
+		{net.ParseIP("10.0.0.1").To4(), &v1beta1.Value{Value: &v1beta1.Value_IpAddressValue{
+			IpAddressValue: &v1beta1.IPAddress{Value: []byte{10, 0, 0, 1}}}}},
+		{[]byte{10, 0, 0, 1}, &v1beta1.Value{Value: &v1beta1.Value_IpAddressValue{
+			IpAddressValue: &v1beta1.IPAddress{Value: []byte{10, 0, 0, 1}}}}},
+		{adapter.DNSName("svc.cluster.local"), &v1beta1.Value{Value: &v1beta1.Value_DnsNameValue{
+			DnsNameValue: &v1beta1.DNSName{Value: "svc.cluster.local"}}}},
+		{adapter.EmailAddress("a@example.com"), &v1beta1.Value{Value: &v1beta1.Value_EmailAddressValue{
+			EmailAddressValue: &v1beta1.EmailAddress{Value: "a@example.com"}}}},
+		{adapter.URI("http://example.com"), &v1beta1.Value{Value: &v1beta1.Value_UriValue{
+			UriValue: &v1beta1.Uri{Value: "http://example.com"}}}},
+	}
+
+	for _, c := range cases {
+		got, err := NewValue(c.value)
+		if err != nil {
+			t.Errorf("NewValue(%v) => %v", c.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("NewValue(%v) => %v, want %v", c.value, got, c.want)
+		}
+	}
+}
+
+func TestNewValue_Unsupported(t *testing.T) {
+	_, err := NewValue(map[string]string{"a": "b"})
+	if _, ok := err.(*UnsupportedTypeError); !ok {
+		t.Fatalf("NewValue() => %v, want an *UnsupportedTypeError", err)
+	}
+	if err.Error() != "unsupported value type map[string]string" {
+		t.Errorf("error: %q", err.Error())
+	}
+}
@@ -179,7 +179,8 @@ func (s *grpcServer) Check(legacyCtx legacyContext.Context, req *mixerpb.CheckRe
 
 var reportResp = &mixerpb.ReportResponse{}
 
-// Report is the entry point for the external Report method
+// Report is the entry point for the external Report method. The attributes are preprocessed and turned into
+// instances before Report returns, even when report queues are enabled; only the dispatch to handlers is deferred.
 func (s *grpcServer) Report(legacyCtx legacyContext.Context, req *mixerpb.ReportRequest) (*mixerpb.ReportResponse, error) {
 	if len(req.Attributes) == 0 {
 		// early out
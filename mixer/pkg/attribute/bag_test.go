@@ -721,6 +721,35 @@ func TestReferenceTracking(t *testing.T) {
 	b.Done()
 }
 
+func TestGetReferenceTracker(t *testing.T) {
+	pb := NewProtoBag(&mixerpb.CompressedAttributes{}, nil, nil)
+	defer pb.Done()
+
+	child := GetMutableBag(pb)
+	defer child.Done()
+
+	grandChild := GetMutableBag(child)
+	defer grandChild.Done()
+
+	for _, b := range []Bag{pb, child, grandChild} {
+		if rt, found := GetReferenceTracker(b); !found || rt != pb {
+			t.Errorf("GetReferenceTracker(%T) => %v, %v, want the proto bag", b, rt, found)
+		}
+	}
+
+	mb := GetMutableBag(nil)
+	defer mb.Done()
+
+	mbChild := GetMutableBag(mb)
+	defer mbChild.Done()
+
+	for _, b := range []Bag{mb, mbChild} {
+		if _, found := GetReferenceTracker(b); found {
+			t.Errorf("GetReferenceTracker(%T) => found, want not found", b)
+		}
+	}
+}
+
 func TestGlobalWordCount(t *testing.T) {
 	// ensure that a component with a larger global word list can
 	// produce an attribute message with a shorter word list to handle
@@ -65,6 +65,39 @@ func GetMutableBag(parent Bag) *MutableBag {
 	return mb
 }
 
+// ReferenceTracker is implemented by bags that keep track of the attributes referenced through them.
+type ReferenceTracker interface {
+	// GetReferencedAttributes returns the set of attributes that have been referenced.
+	GetReferencedAttributes(globalDict map[string]int32, globalWordCount int) mixerpb.ReferencedAttributes
+
+	// SnapshotReferencedAttributes grabs a snapshot of the currently referenced attributes.
+	SnapshotReferencedAttributes() ReferencedAttributeSnapshot
+
+	// RestoreReferencedAttributes sets the attributes being tracked to the ones of the snapshot.
+	RestoreReferencedAttributes(snap ReferencedAttributeSnapshot)
+}
+
+var _ ReferenceTracker = &ProtoBag{}
+
+// GetReferenceTracker returns the bag at the root of a chain of mutable bags, if it keeps track of the attributes
+// referenced through it. Note that only the references to the attributes of the root bag are tracked, not the ones
+// to attributes set on the mutable bags.
+func GetReferenceTracker(bag Bag) (ReferenceTracker, bool) {
+	for {
+		switch b := bag.(type) {
+		case ReferenceTracker:
+			return b, true
+		case *MutableBag:
+			if b.parent == nil {
+				return nil, false
+			}
+			bag = b.parent
+		default:
+			return nil, false
+		}
+	}
+}
+
 // GetFakeMutableBagForTesting returns a Mutable bag based on the specified map
 // Use this function only for testing purposes.
 func GetFakeMutableBagForTesting(v map[string]interface{}) *MutableBag {
@@ -0,0 +1,100 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkcache keeps check results for as long as they are valid, in duration and in number of uses.
+package checkcache
+
+import (
+	"sync/atomic"
+	"time"
+
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/pkg/cache"
+)
+
+// evictionInterval is the frequency at which expired results are evicted.
+const evictionInterval = time.Second
+
+// Cache is an LRU cache of check results.
+type Cache struct {
+	maxValidDuration time.Duration
+	entries          cache.ExpiringCache
+}
+
+// entry is a cached check result.
+type entry struct {
+	result     adapter.CheckResult
+	expiration time.Time
+
+	// remaining number of uses of the result.
+	uses int32
+}
+
+// New returns a cache that keeps up to maxEntries results. If maxValidDuration is not 0, results are kept at most
+// that long, regardless of their valid duration.
+func New(maxEntries int32, maxValidDuration time.Duration) *Cache {
+	return &Cache{
+		maxValidDuration: maxValidDuration,
+		entries:          cache.NewLRU(maxValidDuration, evictionInterval, maxEntries),
+	}
+}
+
+// Get returns the result cached for the key, with its remaining valid duration and use count. Getting the result
+// counts as one use.
+func (c *Cache) Get(key string) (adapter.CheckResult, bool) {
+	value, found := c.entries.Get(key)
+	if !found {
+		return adapter.CheckResult{}, false
+	}
+
+	e := value.(*entry)
+	remaining := time.Until(e.expiration)
+	uses := atomic.AddInt32(&e.uses, -1)
+	if remaining <= 0 || uses < 0 {
+		c.entries.Remove(key)
+		return adapter.CheckResult{}, false
+	}
+
+	r := e.result
+	r.ValidDuration = remaining
+	if uses+1 < r.ValidUseCount {
+		r.ValidUseCount = uses + 1
+	}
+	return r, true
+}
+
+// Set caches the result for its valid duration and use count. Producing the result counts as one use, so results
+// that are valid for a single use are not cached.
+func (c *Cache) Set(key string, result adapter.CheckResult) {
+	if result.ValidDuration <= 0 || result.ValidUseCount <= 1 {
+		return
+	}
+
+	expiration := result.ValidDuration
+	if c.maxValidDuration > 0 && expiration > c.maxValidDuration {
+		expiration = c.maxValidDuration
+	}
+
+	e := &entry{
+		result:     result,
+		expiration: time.Now().Add(expiration),
+		uses:       result.ValidUseCount - 1,
+	}
+	c.entries.SetWithExpiration(key, e, expiration)
+}
+
+// RemoveAll removes all the cached results.
+func (c *Cache) RemoveAll() {
+	c.entries.RemoveAll()
+}
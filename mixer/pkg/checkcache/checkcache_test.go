@@ -0,0 +1,77 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkcache
+
+import (
+	"testing"
+	"time"
+
+	rpc "github.com/gogo/googleapis/google/rpc"
+
+	"istio.io/istio/mixer/pkg/adapter"
+)
+
+var denied = rpc.Status{Code: int32(rpc.PERMISSION_DENIED), Message: "denied"}
+
+func TestCache_ValidUseCount(t *testing.T) {
+	c := New(10, 0)
+	c.Set("k", adapter.CheckResult{Status: denied, ValidDuration: time.Minute, ValidUseCount: 3})
+
+	for _, want := range []int32{2, 1} {
+		r, found := c.Get("k")
+		if !found || r.Status.Code != denied.Code || r.ValidUseCount != want || r.ValidDuration > time.Minute {
+			t.Fatalf("Get() => %+v, %v, want the cached result with %d uses", r, found, want)
+		}
+	}
+
+	if r, found := c.Get("k"); found {
+		t.Fatalf("Get() => %+v, want the result to be used up", r)
+	}
+}
+
+func TestCache_MaxValidDuration(t *testing.T) {
+	c := New(10, time.Millisecond)
+	c.Set("k", adapter.CheckResult{Status: denied, ValidDuration: time.Hour, ValidUseCount: 10})
+
+	time.Sleep(10 * time.Millisecond)
+	if r, found := c.Get("k"); found {
+		t.Fatalf("Get() => %+v, want the result to be expired", r)
+	}
+}
+
+func TestCache_NotCached(t *testing.T) {
+	cases := []adapter.CheckResult{
+		{ValidDuration: time.Minute, ValidUseCount: 1},
+		{ValidDuration: 0, ValidUseCount: 10},
+	}
+
+	c := New(10, 0)
+	for _, result := range cases {
+		c.Set("k", result)
+		if r, found := c.Get("k"); found {
+			t.Errorf("Set(%+v); Get() => %+v, want the result not to be cached", result, r)
+		}
+	}
+}
+
+func TestCache_RemoveAll(t *testing.T) {
+	c := New(10, 0)
+	c.Set("k", adapter.CheckResult{ValidDuration: time.Minute, ValidUseCount: 10})
+	c.RemoveAll()
+
+	if r, found := c.Get("k"); found {
+		t.Fatalf("Get() => %+v, want the cache to be empty", r)
+	}
+}
@@ -967,6 +967,185 @@ Attributes:
 `,
 	},
 
+	{
+		Name: "rule in dry-run mode",
+		Events1: []*store.Event{
+			{
+				Key: store.Key{
+					Name:      "handler1",
+					Namespace: "ns",
+					Kind:      "adapter1",
+				},
+				Type: store.Update,
+				Value: &store.Resource{
+					Spec: testParam1,
+				},
+			},
+			{
+				Key: store.Key{
+					Name:      "instance1",
+					Namespace: "ns",
+					Kind:      "check",
+				},
+				Type: store.Update,
+				Value: &store.Resource{
+					Spec: testParam2,
+				},
+			},
+			{
+				Key: store.Key{
+					Name:      "rule1",
+					Namespace: "ns",
+					Kind:      "rule",
+				},
+				Type: store.Update,
+				Value: &store.Resource{
+					Metadata: store.ResourceMeta{
+						Labels: map[string]string{istioDryRun: "true"},
+					},
+					Spec: &configpb.Rule{
+						Actions: []*configpb.Action{
+							{
+								Handler: "handler1.adapter1",
+								Instances: []string{
+									"instance1.check.ns",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		E: `
+ID: 1
+Templates:
+  Name: apa
+  Name: check
+  Name: quota
+  Name: report
+Adapters:
+  Name: adapter1
+  Name: adapter2
+Handlers:
+  Name:    handler1.adapter1.ns
+  Adapter: adapter1
+  Params:  value:"param1"
+Instances:
+  Name:     instance1.check.ns
+  Template: check
+  Params:   value:"param2"
+Rules:
+  Name:      rule1.rule.ns
+  Namespace: ns
+  Match:
+  ResourceType: ResourceType:{HTTP / Check Report Preprocess}
+  DryRun:    true
+  Actions:
+    Handler: handler1.adapter1.ns
+    Instances:
+      Name: instance1.check.ns
+Attributes:
+  template.attr: BOOL
+`,
+	},
+
+	{
+		Name: "handler with check cache disabled",
+		Events1: []*store.Event{
+			{
+				Key: store.Key{
+					Name:      "handler1",
+					Namespace: "ns",
+					Kind:      "adapter1",
+				},
+				Type: store.Update,
+				Value: &store.Resource{
+					Metadata: store.ResourceMeta{
+						Labels: map[string]string{istioCheckCache: "false"},
+					},
+					Spec: testParam1,
+				},
+			},
+		},
+		E: `
+ID: 1
+Templates:
+  Name: apa
+  Name: check
+  Name: quota
+  Name: report
+Adapters:
+  Name: adapter1
+  Name: adapter2
+Handlers:
+  Name:    handler1.adapter1.ns
+  Adapter: adapter1
+  Params:  value:"param1"
+  CheckCacheDisabled: true
+Instances:
+Rules:
+Attributes:
+  template.attr: BOOL
+`,
+	},
+
+	{
+		Name: "handler with report queue full policy",
+		Events1: []*store.Event{
+			{
+				Key: store.Key{
+					Name:      "handler1",
+					Namespace: "ns",
+					Kind:      "adapter1",
+				},
+				Type: store.Update,
+				Value: &store.Resource{
+					Metadata: store.ResourceMeta{
+						Labels: map[string]string{istioReportQueueFull: ReportQueueFullDrop},
+					},
+					Spec: testParam1,
+				},
+			},
+			{
+				Key: store.Key{
+					Name:      "handler2",
+					Namespace: "ns",
+					Kind:      "adapter1",
+				},
+				Type: store.Update,
+				Value: &store.Resource{
+					Metadata: store.ResourceMeta{
+						Labels: map[string]string{istioReportQueueFull: "discard"},
+					},
+					Spec: testParam1,
+				},
+			},
+		},
+		E: `
+ID: 1
+Templates:
+  Name: apa
+  Name: check
+  Name: quota
+  Name: report
+Adapters:
+  Name: adapter1
+  Name: adapter2
+Handlers:
+  Name:    handler1.adapter1.ns
+  Adapter: adapter1
+  Params:  value:"param1"
+  ReportQueueFull: drop
+  Name:    handler2.adapter1.ns
+  Adapter: adapter1
+  Params:  value:"param1"
+Instances:
+Rules:
+Attributes:
+  template.attr: BOOL
+`,
+	},
+
 	{
 		Name: "unknown instance in action is omitted",
 		Events1: []*store.Event{
@@ -33,3 +33,19 @@ const ContextProtocolTCP = "tcp"
 const ContextProtocolAttributeName = "context.protocol"
 
 const istioProtocol = "istio-protocol"
+
+// istioDryRun is the label that puts a rule in dry-run mode, when set to "true".
+const istioDryRun = "istio-dry-run"
+
+// istioCheckCache is the label that keeps the check results of a handler out of the check cache, when set to "false".
+const istioCheckCache = "istio-check-cache"
+
+// istioReportQueueFull is the label that overrides, for a handler, what happens to the report instances that do not
+// fit in its report queue. It is either ReportQueueFullDrop or ReportQueueFullBlock.
+const istioReportQueueFull = "istio-report-queue-full"
+
+// ReportQueueFullDrop drops the report instances that do not fit in the report queue of a handler.
+const ReportQueueFullDrop = "drop"
+
+// ReportQueueFullBlock blocks the report until the report queue of the handler has room for its instances.
+const ReportQueueFullBlock = "block"
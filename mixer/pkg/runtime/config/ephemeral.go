@@ -189,9 +189,18 @@ func (e *Ephemeral) processHandlerConfigs(counters Counters) map[string]*Handler
 		log.Debugf("Processing incoming handler config: name='%s'\n%s", adapterName, resource.Spec)
 
 		cfg := &Handler{
-			Name:    adapterName,
-			Adapter: info,
-			Params:  resource.Spec,
+			Name:               adapterName,
+			Adapter:            info,
+			Params:             resource.Spec,
+			CheckCacheDisabled: resource.Metadata.Labels[istioCheckCache] == "false",
+		}
+
+		switch policy := resource.Metadata.Labels[istioReportQueueFull]; policy {
+		case "":
+		case ReportQueueFullDrop, ReportQueueFullBlock:
+			cfg.ReportQueueFull = policy
+		default:
+			log.Warnf("Ignoring unknown report queue full policy of handler: name='%s', policy='%s'", adapterName, policy)
 		}
 
 		handlers[cfg.Name] = cfg
@@ -335,6 +344,7 @@ func (e *Ephemeral) processRuleConfigs(
 			Actions:      actions,
 			ResourceType: rt,
 			Match:        cfg.Match,
+			DryRun:       resource.Metadata.Labels[istioDryRun] == "true",
 		}
 
 		rules = append(rules, rule)
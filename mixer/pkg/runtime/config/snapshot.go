@@ -54,6 +54,13 @@ type (
 
 		// parameters used to construct the Handler.
 		Params proto.Message
+
+		// CheckCacheDisabled indicates that check results involving the Handler must not be cached.
+		CheckCacheDisabled bool
+
+		// ReportQueueFull is the policy applied when the report queue of the Handler is full: ReportQueueFullDrop,
+		// ReportQueueFullBlock, or empty for the server default.
+		ReportQueueFull string
 	}
 
 	// Instance configuration. Fully resolved.
@@ -82,6 +89,10 @@ type (
 		Actions []*Action
 
 		ResourceType ResourceType
+
+		// DryRun rules have their handlers invoked, but their results are only logged and counted. They never
+		// affect the response. Rules are put in dry-run mode with the istio-dry-run: "true" label.
+		DryRun bool
 	}
 
 	// Action configuration. Fully resolved.
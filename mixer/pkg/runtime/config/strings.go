@@ -108,6 +108,15 @@ func writeHandlers(w io.Writer, handlers map[string]*Handler) {
 
 		fmt.Fprintf(w, "  Params:  %+v", h.Params)
 		fmt.Fprintln(w)
+
+		if h.CheckCacheDisabled {
+			fmt.Fprintln(w, "  CheckCacheDisabled: true")
+		}
+
+		if h.ReportQueueFull != "" {
+			fmt.Fprintf(w, "  ReportQueueFull: %s", h.ReportQueueFull)
+			fmt.Fprintln(w)
+		}
 	}
 }
 
@@ -157,6 +166,10 @@ func writeRules(w io.Writer, rules []*Rule) {
 		fmt.Fprintf(w, "  ResourceType: %v", r.ResourceType)
 		fmt.Fprintln(w)
 
+		if r.DryRun {
+			fmt.Fprintln(w, "  DryRun:    true")
+		}
+
 		fmt.Fprintln(w, "  Actions:")
 		writeActions(w, r.Actions)
 	}
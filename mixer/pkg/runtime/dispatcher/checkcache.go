@@ -0,0 +1,272 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	mixerpb "istio.io/api/mixer/v1"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/attribute"
+	"istio.io/istio/mixer/pkg/checkcache"
+	"istio.io/istio/pkg/log"
+)
+
+// CheckCacheOptions controls the caching of check results. When enabled, the result of a check is reused for the
+// subsequent checks that have the same values for the attributes that were referenced to produce it, until it
+// expires.
+type CheckCacheOptions struct {
+	// MaxEntries is the maximum number of check results kept in the cache.
+	MaxEntries int32
+
+	// MaxValidDuration is the maximum amount of time a check result is kept in the cache, regardless of the valid
+	// duration returned by the adapters.
+	MaxValidDuration time.Duration
+}
+
+// Validate checks that the options are usable.
+func (o *CheckCacheOptions) Validate() error {
+	if o.MaxEntries <= 0 {
+		return fmt.Errorf("check cache size must be > 0, got %d", o.MaxEntries)
+	}
+	if o.MaxValidDuration <= 0 {
+		return fmt.Errorf("check cache max valid duration must be > 0, got %v", o.MaxValidDuration)
+	}
+	return nil
+}
+
+// maxCheckCacheShapes is the maximum number of distinct sets of referenced attributes the cache keeps track of.
+// The number of sets is normally bounded by the config, but this protects against runaway growth.
+const maxCheckCacheShapes = 256
+
+// checkCache keeps check results, keyed by the values of the attributes that were referenced to produce them.
+//
+// The results are looked up in two steps: every distinct set of referenced attributes seen so far (a shape) is used
+// to compute a key from the attributes of the request, and the first key that is found in the cache yields the
+// result. This is the same scheme the Mixer client uses for its own check cache.
+type checkCache struct {
+	results *checkcache.Cache
+
+	mu     sync.RWMutex
+	shapes []*checkCacheShape
+	known  map[string]bool
+}
+
+// checkCacheShape is a set of referenced attributes.
+type checkCacheShape struct {
+	// id of the shape, which is a unique textual representation of the referenced attributes.
+	id   string
+	refs []checkCacheRef
+}
+
+// checkCacheRef is an attribute, or a key of a string map attribute, that was referenced to produce a check result.
+type checkCacheRef struct {
+	name      string
+	mapKey    string
+	condition mixerpb.ReferencedAttributes_Condition
+}
+
+// stringMap is the interface of the string map attribute values.
+type stringMap interface {
+	Get(key string) (string, bool)
+}
+
+func newCheckCache(opts CheckCacheOptions) *checkCache {
+	return &checkCache{
+		results: checkcache.New(opts.MaxEntries, opts.MaxValidDuration),
+		known:   make(map[string]bool),
+	}
+}
+
+// get returns the cached result of a check with the same values for the referenced attributes as the bag, for the
+// routing table with the given id. Only the attributes of the matching shape remain referenced in the bag when the
+// result is found, and the references of the bag are left untouched otherwise.
+func (c *checkCache) get(bag attribute.Bag, routes int64) (*adapter.CheckResult, bool) {
+	tracker, ok := attribute.GetReferenceTracker(bag)
+	if !ok {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	shapes := c.shapes
+	c.mu.RUnlock()
+
+	snap := tracker.SnapshotReferencedAttributes()
+	for _, shape := range shapes {
+		// Looking up the values of a shape references its attributes, so restore the references of the bag before
+		// trying the next shape.
+		tracker.RestoreReferencedAttributes(snap)
+		snap = tracker.SnapshotReferencedAttributes()
+
+		key, found := shape.key(bag, routes)
+		if !found {
+			continue
+		}
+
+		r, found := c.results.Get(key)
+		if !found {
+			continue
+		}
+
+		checkCacheHits.Inc()
+		return &r, true
+	}
+
+	tracker.RestoreReferencedAttributes(snap)
+	checkCacheMisses.Inc()
+	return nil, false
+}
+
+// set caches the result of a check done with the given routing table, keyed by the values of the attributes
+// referenced through the bag. The check itself counts as one use of the result.
+func (c *checkCache) set(bag attribute.Bag, routes int64, result *adapter.CheckResult) {
+	if result.ValidDuration <= 0 || result.ValidUseCount <= 1 {
+		return
+	}
+
+	tracker, ok := attribute.GetReferenceTracker(bag)
+	if !ok {
+		return
+	}
+
+	shape := newCheckCacheShape(tracker.GetReferencedAttributes(nil, 0))
+	key, found := shape.key(bag, routes)
+	if !found {
+		// This can only happen if the bag changed since the check, but there is no point in caching the result then.
+		return
+	}
+
+	if !c.addShape(shape) {
+		return
+	}
+
+	c.results.Set(key, *result)
+}
+
+// addShape makes the shape known to the cache, and returns whether it is known.
+func (c *checkCache) addShape(shape *checkCacheShape) bool {
+	c.mu.RLock()
+	found := c.known[shape.id]
+	c.mu.RUnlock()
+	if found {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.known[shape.id] {
+		return true
+	}
+	if len(c.shapes) >= maxCheckCacheShapes {
+		log.Debugf("Not caching check result: too many distinct sets of referenced attributes: %d", len(c.shapes))
+		return false
+	}
+
+	// Copy on write, so that lookups can iterate over the shapes without holding the lock.
+	shapes := make([]*checkCacheShape, len(c.shapes), len(c.shapes)+1)
+	copy(shapes, c.shapes)
+	c.shapes = append(shapes, shape)
+	c.known[shape.id] = true
+	return true
+}
+
+// clear removes all the cached results and shapes.
+func (c *checkCache) clear() {
+	c.mu.Lock()
+	c.shapes = nil
+	c.known = make(map[string]bool)
+	c.mu.Unlock()
+
+	c.results.RemoveAll()
+}
+
+func newCheckCacheShape(ra mixerpb.ReferencedAttributes) *checkCacheShape {
+	word := func(index int32) string {
+		// All the words are in the message word list, since no global dictionary is used.
+		if slot := int(-index - 1); slot >= 0 && slot < len(ra.Words) {
+			return ra.Words[slot]
+		}
+		return ""
+	}
+
+	s := &checkCacheShape{
+		refs: make([]checkCacheRef, 0, len(ra.AttributeMatches)),
+	}
+	for _, am := range ra.AttributeMatches {
+		ref := checkCacheRef{
+			name:      word(am.Name),
+			condition: am.Condition,
+		}
+		if am.MapKey != 0 {
+			ref.mapKey = word(am.MapKey)
+		}
+		s.refs = append(s.refs, ref)
+	}
+
+	sort.Slice(s.refs, func(i, j int) bool {
+		if s.refs[i].name != s.refs[j].name {
+			return s.refs[i].name < s.refs[j].name
+		}
+		return s.refs[i].mapKey < s.refs[j].mapKey
+	})
+
+	var b bytes.Buffer
+	for _, ref := range s.refs {
+		fmt.Fprintf(&b, "%s\x00%s\x00%d\x00", ref.name, ref.mapKey, ref.condition)
+	}
+	s.id = b.String()
+
+	return s
+}
+
+// key returns the cache key for the values of the referenced attributes in the bag, if the presence of the attributes
+// in the bag matches the conditions of the shape.
+func (s *checkCacheShape) key(bag attribute.Bag, routes int64) (string, bool) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%d\x00%s", routes, s.id)
+
+	for _, ref := range s.refs {
+		v, found := bag.Get(ref.name)
+
+		if ref.mapKey != "" {
+			if !found {
+				return "", false
+			}
+			switch m := v.(type) {
+			case stringMap:
+				v, found = m.Get(ref.mapKey)
+			case map[string]string:
+				v, found = m[ref.mapKey]
+			default:
+				return "", false
+			}
+		}
+
+		if found != (ref.condition == mixerpb.EXACT) {
+			return "", false
+		}
+
+		if found {
+			fmt.Fprintf(&b, "%T:%v\x00", v, v)
+		}
+	}
+
+	return b.String(), true
+}
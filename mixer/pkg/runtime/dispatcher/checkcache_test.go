@@ -0,0 +1,273 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	rpc "github.com/gogo/googleapis/google/rpc"
+
+	mixerpb "istio.io/api/mixer/v1"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/attribute"
+	"istio.io/istio/mixer/pkg/lang/compiled"
+	"istio.io/istio/mixer/pkg/pool"
+	"istio.io/istio/mixer/pkg/runtime/handler"
+	"istio.io/istio/mixer/pkg/runtime/routing"
+	"istio.io/istio/mixer/pkg/runtime/testing/data"
+	"istio.io/istio/mixer/pkg/runtime/testing/util"
+)
+
+// handlerACheck1CheckCacheDisabled is data.HandlerACheck1, opted out of the check cache.
+var handlerACheck1CheckCacheDisabled = `
+apiVersion: "config.istio.io/v1alpha2"
+kind: acheck
+metadata:
+  name: hcheck1
+  namespace: istio-system
+  labels:
+    istio-check-cache: "false"
+spec:
+`
+
+var cacheableResult = adapter.CheckResult{
+	Status:        rpc.Status{Code: int32(rpc.PERMISSION_DENIED), Message: "denied"},
+	ValidDuration: time.Minute,
+	ValidUseCount: 2,
+}
+
+func newCachingDispatcher(t *testing.T, opts CheckCacheOptions, settings data.FakeTemplateSettings,
+	configs ...string) (*Impl, *data.Logger) {
+
+	d := New("ident", gp, false)
+	if err := d.EnableCheckCache(opts); err != nil {
+		t.Fatalf("EnableCheckCache() => %v", err)
+	}
+
+	l := &data.Logger{}
+	templates := data.BuildTemplates(l, settings)
+	adapters := data.BuildAdapters(l)
+
+	s := util.GetSnapshot(templates, adapters, data.ServiceConfig, data.JoinConfigs(configs...))
+	h := handler.NewTable(handler.Empty(), s, pool.NewGoroutinePool(1, false))
+	_ = d.ChangeRoute(routing.BuildTable(h, s, compiled.NewBuilder(s.Attributes), "istio-system", true))
+	l.Clear()
+
+	return d, l
+}
+
+// newProtoBag returns a bag that keeps track of the attributes referenced through it, like the ones of the API.
+func newProtoBag(values map[string]interface{}) *attribute.ProtoBag {
+	mb := attribute.GetFakeMutableBagForTesting(values)
+	var attrs mixerpb.CompressedAttributes
+	mb.ToProto(&attrs, nil, 0)
+	return attribute.NewProtoBag(&attrs, nil, nil)
+}
+
+func check(t *testing.T, d *Impl, values map[string]interface{}) *adapter.CheckResult {
+	bag := newProtoBag(values)
+	defer bag.Done()
+
+	r, err := d.Check(context.TODO(), bag)
+	if err != nil {
+		t.Fatalf("Check() => %v", err)
+	}
+	return r
+}
+
+func dispatchedChecks(l *data.Logger) int {
+	return strings.Count(l.String(), "DispatchCheck <= (SUCCESS)")
+}
+
+func TestCheckCacheOptions_Validate(t *testing.T) {
+	cases := []struct {
+		opts CheckCacheOptions
+		err  string
+	}{
+		{CheckCacheOptions{MaxEntries: 10, MaxValidDuration: time.Second}, ""},
+		{CheckCacheOptions{MaxValidDuration: time.Second}, "check cache size must be > 0, got 0"},
+		{CheckCacheOptions{MaxEntries: 10}, "check cache max valid duration must be > 0, got 0s"},
+	}
+
+	for _, c := range cases {
+		err := c.opts.Validate()
+		if c.err == "" && err != nil {
+			t.Errorf("%+v: Validate() => %v, want success", c.opts, err)
+		}
+		if c.err != "" && (err == nil || err.Error() != c.err) {
+			t.Errorf("%+v: Validate() => %v, want %q", c.opts, err, c.err)
+		}
+	}
+}
+
+func TestCheckCache_ReferencedAttributes(t *testing.T) {
+	d, l := newCachingDispatcher(t,
+		CheckCacheOptions{MaxEntries: 10, MaxValidDuration: time.Hour},
+		data.FakeTemplateSettings{Name: "tcheck", CheckResults: []adapter.CheckResult{cacheableResult, cacheableResult}},
+		data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1WithMatchClause)
+
+	foo := map[string]interface{}{"ident": "dest.istio-system", "destination.name": "foo1", "other": "a"}
+	r := check(t, d, foo)
+	if r.Status.Code != int32(rpc.PERMISSION_DENIED) {
+		t.Fatalf("Check() => %+v, want the result of the handler", r)
+	}
+
+	// Attributes that are not referenced by the config do not matter.
+	foo["other"] = "b"
+	r = check(t, d, foo)
+	if got := dispatchedChecks(l); got != 1 {
+		t.Fatalf("dispatched checks: %d, want the result to be cached", got)
+	}
+	if r.Status.Code != int32(rpc.PERMISSION_DENIED) || r.ValidUseCount != 1 || r.ValidDuration > time.Minute {
+		t.Fatalf("Check() => %+v, want the cached result", r)
+	}
+
+	// Referenced attributes do.
+	check(t, d, map[string]interface{}{"ident": "dest.istio-system", "destination.name": "foo2"})
+	if got := dispatchedChecks(l); got != 2 {
+		t.Fatalf("dispatched checks: %d, want a check for different referenced attributes", got)
+	}
+}
+
+func TestCheckCache_ValidUseCount(t *testing.T) {
+	d, l := newCachingDispatcher(t,
+		CheckCacheOptions{MaxEntries: 10, MaxValidDuration: time.Hour},
+		data.FakeTemplateSettings{Name: "tcheck", CheckResults: []adapter.CheckResult{cacheableResult, cacheableResult}},
+		data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1)
+
+	values := map[string]interface{}{"ident": "dest.istio-system"}
+	for i := 0; i < 4; i++ {
+		check(t, d, values)
+	}
+
+	// Each result is used by the check that dispatched it, and once from the cache.
+	if got := dispatchedChecks(l); got != 2 {
+		t.Fatalf("dispatched checks: %d, want 2", got)
+	}
+}
+
+func TestCheckCache_Expiration(t *testing.T) {
+	d, l := newCachingDispatcher(t,
+		CheckCacheOptions{MaxEntries: 10, MaxValidDuration: time.Millisecond},
+		data.FakeTemplateSettings{Name: "tcheck", CheckResults: []adapter.CheckResult{cacheableResult, cacheableResult}},
+		data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1)
+
+	values := map[string]interface{}{"ident": "dest.istio-system"}
+	check(t, d, values)
+	time.Sleep(10 * time.Millisecond)
+	check(t, d, values)
+
+	if got := dispatchedChecks(l); got != 2 {
+		t.Fatalf("dispatched checks: %d, want the cached result to expire", got)
+	}
+}
+
+func TestCheckCache_NotCached(t *testing.T) {
+	cases := []struct {
+		name    string
+		results []adapter.CheckResult
+		configs []string
+	}{
+		{
+			name:    "handler opted out",
+			results: []adapter.CheckResult{cacheableResult, cacheableResult},
+			configs: []string{handlerACheck1CheckCacheDisabled, data.InstanceCheck1, data.RuleCheck1},
+		},
+		{
+			name:    "dry-run rule",
+			results: []adapter.CheckResult{cacheableResult, cacheableResult},
+			configs: []string{data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1DryRun},
+		},
+		{
+			name:    "no valid duration",
+			results: []adapter.CheckResult{{ValidUseCount: 10}, {ValidUseCount: 10}},
+			configs: []string{data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d, l := newCachingDispatcher(t,
+				CheckCacheOptions{MaxEntries: 10, MaxValidDuration: time.Hour},
+				data.FakeTemplateSettings{Name: "tcheck", CheckResults: c.results},
+				c.configs...)
+
+			values := map[string]interface{}{"ident": "dest.istio-system"}
+			check(t, d, values)
+			check(t, d, values)
+
+			if got := dispatchedChecks(l); got != 2 {
+				t.Fatalf("dispatched checks: %d, want the result not to be cached", got)
+			}
+		})
+	}
+}
+
+func TestCheckCache_ChangeRoute(t *testing.T) {
+	d, l := newCachingDispatcher(t,
+		CheckCacheOptions{MaxEntries: 10, MaxValidDuration: time.Hour},
+		data.FakeTemplateSettings{Name: "tcheck", CheckResults: []adapter.CheckResult{cacheableResult}},
+		data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1)
+
+	values := map[string]interface{}{"ident": "dest.istio-system"}
+	check(t, d, values)
+
+	_ = d.ChangeRoute(routing.Empty())
+	if r := check(t, d, values); r != nil {
+		t.Fatalf("Check() => %+v, want no result from the new routing table", r)
+	}
+	if got := dispatchedChecks(l); got != 1 {
+		t.Fatalf("dispatched checks: %d, want 1", got)
+	}
+}
+
+func TestCheckCache_References(t *testing.T) {
+	d, _ := newCachingDispatcher(t,
+		CheckCacheOptions{MaxEntries: 10, MaxValidDuration: time.Hour},
+		data.FakeTemplateSettings{Name: "tcheck", CheckResults: []adapter.CheckResult{cacheableResult}},
+		data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1WithMatchClause)
+
+	values := map[string]interface{}{"ident": "dest.istio-system", "destination.name": "foo1"}
+	check(t, d, values)
+
+	// A cache hit references the same attributes as the check it replaces, so that clients can cache it too.
+	bag := newProtoBag(values)
+	defer bag.Done()
+	if _, err := d.Check(context.TODO(), bag); err != nil {
+		t.Fatalf("Check() => %v", err)
+	}
+
+	ra := bag.GetReferencedAttributes(nil, 0)
+	var names []string
+	for _, am := range ra.AttributeMatches {
+		names = append(names, ra.Words[-am.Name-1])
+	}
+	if len(names) != 3 || !contains(names, "ident") || !contains(names, "context.protocol") ||
+		!contains(names, "destination.name") {
+		t.Fatalf("referenced attributes: %v, want ident, context.protocol and destination.name", names)
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -40,6 +40,7 @@ import (
 	"istio.io/istio/mixer/pkg/runtime/config"
 	"istio.io/istio/mixer/pkg/runtime/routing"
 	"istio.io/istio/mixer/pkg/status"
+	"istio.io/istio/mixer/pkg/template"
 	"istio.io/istio/pkg/log"
 )
 
@@ -95,6 +96,12 @@ type Impl struct {
 	// pool of dispatch states
 	statePool *dispatchStatePool
 
+	// queues of report instances, if reports are dispatched asynchronously.
+	reportQueues *reportQueues
+
+	// cache of check results, if enabled.
+	checkCache *checkCache
+
 	gp *pool.GoroutinePool
 }
 
@@ -138,6 +145,34 @@ func New(identityAttribute string, handlerGP *pool.GoroutinePool, enableTracing
 	}
 }
 
+// EnableReportQueues makes Report return once the instances are queued, and dispatches them to the handlers
+// asynchronously, in batches. It must be called before any request is dispatched.
+func (d *Impl) EnableReportQueues(opts ReportQueueOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	d.reportQueues = newReportQueues(d, opts)
+	return nil
+}
+
+// StopReportQueues stops queueing report instances, and waits until the queued ones are dispatched. Subsequent
+// reports are dispatched synchronously.
+func (d *Impl) StopReportQueues() {
+	if d.reportQueues != nil {
+		d.reportQueues.stop()
+	}
+}
+
+// EnableCheckCache makes Check reuse the results of previous checks that referenced the same attribute values. It
+// must be called before any request is dispatched.
+func (d *Impl) EnableCheckCache(opts CheckCacheOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	d.checkCache = newCheckCache(opts)
+	return nil
+}
+
 // ChangeRoute changes the routing table on the Impl which, in turn, ends up creating a new RoutingContext.
 func (d *Impl) ChangeRoute(new *routing.Table) *RoutingContext {
 	newContext := &RoutingContext{
@@ -149,11 +184,22 @@ func (d *Impl) ChangeRoute(new *routing.Table) *RoutingContext {
 	d.context = newContext
 	d.contextLock.Unlock()
 
+	// The cached check results are keyed by routing table, so they cannot be used anymore.
+	if d.checkCache != nil {
+		d.checkCache.clear()
+	}
+
 	return old
 }
 
 // Check implementation of runtime.Impl.
 func (d *Impl) Check(ctx context.Context, bag attribute.Bag) (*adapter.CheckResult, error) {
+	if d.checkCache != nil {
+		if r, found := d.checkCache.get(bag, d.routesID()); found {
+			return r, nil
+		}
+	}
+
 	s := d.beginSession(ctx, tpb.TEMPLATE_VARIETY_CHECK, bag)
 
 	var r *adapter.CheckResult
@@ -163,6 +209,10 @@ func (d *Impl) Check(ctx context.Context, bag attribute.Bag) (*adapter.CheckResu
 		err = s.err
 	}
 
+	if d.checkCache != nil && err == nil && r != nil && !s.checkCacheDisabled {
+		d.checkCache.set(bag, s.routes, r)
+	}
+
 	d.completeSession(s)
 
 	return r, err
@@ -232,6 +282,7 @@ func (d *Impl) dispatch(session *session) error {
 	r := d.acquireRoutingContext()
 
 	destinations := r.Routes.GetDestinations(session.variety, namespace)
+	session.routes = r.Routes.ID()
 
 	// Update the context after ensuring that we will not short-circuit and return. This causes allocations.
 	session.ctx = d.updateContext(session.ctx, session.bag)
@@ -252,11 +303,18 @@ func (d *Impl) dispatch(session *session) error {
 			}
 			ndestinations++
 
+			// Results of handlers that opted out of caching, or of dry-run rules, which need to see every check,
+			// must not be cached.
+			if destination.CheckCacheDisabled || group.DryRun {
+				session.checkCacheDisabled = true
+			}
+
 			var state *dispatchState
 			// We dispatch multiple instances together at once to a handler for report calls. pre-acquire
 			// the state, so that we can use its instances field to stage the instance values before dispatch.
 			if session.variety == tpb.TEMPLATE_VARIETY_REPORT {
 				state = d.statePool.get(session, destination)
+				state.group = group
 			}
 
 			for j, input := range group.Builders {
@@ -275,6 +333,7 @@ func (d *Impl) dispatch(session *session) error {
 
 				// for other templates, dispatch for each instance individually.
 				state = d.statePool.get(session, destination)
+				state.group = group
 				state.instance = instance
 				if session.variety == tpb.TEMPLATE_VARIETY_ATTRIBUTE_GENERATOR {
 					state.mapper = group.Mappers[j]
@@ -287,6 +346,12 @@ func (d *Impl) dispatch(session *session) error {
 			}
 
 			if session.variety == tpb.TEMPLATE_VARIETY_REPORT {
+				// Queue the instances for an asynchronous dispatch, if enabled.
+				if d.reportQueues != nil && d.reportQueues.enqueue(session.ctx, r, destination, state.instances) {
+					d.statePool.put(state)
+					continue
+				}
+
 				// Do a multi-instance dispatch for report.
 				d.dispatchToHandler(state)
 			}
@@ -302,6 +367,13 @@ func (d *Impl) dispatch(session *session) error {
 		state := <-session.completed
 		session.activeDispatches--
 
+		// The results of dry-run rules never make it to the caller.
+		if state.group != nil && state.group.DryRun {
+			completeDryRun(session.variety, state)
+			d.statePool.put(state)
+			continue
+		}
+
 		// Aggregate errors
 		if state.err != nil {
 			err = multierror.Append(err, state.err)
@@ -371,6 +443,27 @@ func (d *Impl) dispatch(session *session) error {
 	return nil
 }
 
+// completeDryRun logs and counts the result of a dispatch for a dry-run rule, in lieu of returning it.
+func completeDryRun(variety tpb.TemplateVariety, s *dispatchState) {
+	rule := s.group.RuleName
+
+	if s.err != nil {
+		log.Warnf("dry-run dispatch failed: rule='%s', destination='%s', error='%v'",
+			rule, s.destination.FriendlyName, s.err)
+	}
+
+	if variety != tpb.TEMPLATE_VARIETY_CHECK {
+		return
+	}
+
+	deny := s.err != nil || !status.IsOK(s.checkResult.Status)
+	if s.err == nil && deny {
+		log.Infof("dry-run check would deny: rule='%s', destination='%s', status='%s'",
+			rule, s.destination.FriendlyName, status.String(s.checkResult.Status))
+	}
+	updateDryRunCounters(rule, s.destination.HandlerName, deny)
+}
+
 func (d *Impl) acquireRoutingContext() *RoutingContext {
 	d.contextLock.RLock()
 	ctx := d.context
@@ -379,6 +472,14 @@ func (d *Impl) acquireRoutingContext() *RoutingContext {
 	return ctx
 }
 
+// routesID returns the id of the current routing table.
+func (d *Impl) routesID() int64 {
+	d.contextLock.RLock()
+	id := d.context.Routes.ID()
+	d.contextLock.RUnlock()
+	return id
+}
+
 func (d *Impl) updateContext(ctx context.Context, bag attribute.Bag) context.Context {
 	data := &adapter.RequestData{}
 
@@ -439,6 +540,22 @@ func doDispatchToHandler(param interface{}) {
 
 	log.Debugf("begin dispatch: destination='%s'", s.destination.FriendlyName)
 
+	if remote, ok := s.destination.Handler.(template.RemoteHandler); ok {
+		dispatchToRemoteHandler(ctx, s, remote)
+	} else {
+		dispatchToLocalHandler(ctx, s)
+	}
+
+	log.Debugf("complete dispatch: destination='%s' {err:%v}", s.destination.FriendlyName, s.err)
+
+	s.completeSpan(span, time.Since(start), s.err)
+	s.session.completed <- s
+
+	reachedEnd = true
+}
+
+// dispatchToLocalHandler dispatches through the template specific Handler interfaces of the handler.
+func dispatchToLocalHandler(ctx context.Context, s *dispatchState) {
 	switch s.destination.Template.Variety {
 	case tpb.TEMPLATE_VARIETY_ATTRIBUTE_GENERATOR:
 		s.outputBag, s.err = s.destination.Template.DispatchGenAttrs(
@@ -459,13 +576,23 @@ func doDispatchToHandler(param interface{}) {
 	default:
 		panic(fmt.Sprintf("unknown variety type: '%v'", s.destination.Template.Variety))
 	}
+}
 
-	log.Debugf("complete dispatch: destination='%s' {err:%v}", s.destination.FriendlyName, s.err)
+// dispatchToRemoteHandler dispatches to a handler that forwards the instances to an out-of-process adapter.
+func dispatchToRemoteHandler(ctx context.Context, s *dispatchState, h template.RemoteHandler) {
+	switch s.destination.Template.Variety {
+	case tpb.TEMPLATE_VARIETY_CHECK:
+		s.checkResult, s.err = h.HandleRemoteCheck(ctx, s.destination.Template, s.instance)
 
-	s.completeSpan(span, time.Since(start), s.err)
-	s.session.completed <- s
+	case tpb.TEMPLATE_VARIETY_REPORT:
+		s.err = h.HandleRemoteReport(ctx, s.destination.Template, s.instances)
 
-	reachedEnd = true
+	case tpb.TEMPLATE_VARIETY_QUOTA:
+		s.quotaResult, s.err = h.HandleRemoteQuota(ctx, s.destination.Template, s.instance, s.quotaArgs)
+
+	default:
+		s.err = fmt.Errorf("template '%s' cannot be dispatched to out-of-process adapters", s.destination.Template.Name)
+	}
 }
 
 func (s *dispatchState) beginSpan(ctx context.Context) (opentracing.Span, context.Context, time.Time) {
@@ -224,6 +224,61 @@ ident                         : dest.istio-system
 `,
 	},
 
+	{
+		name: "CheckDryRun",
+		templates: []data.FakeTemplateSettings{{
+			Name: "tcheck",
+			CheckResults: []adapter.CheckResult{
+				{
+					Status: rpc.Status{
+						Code:    int32(rpc.PERMISSION_DENIED),
+						Message: "denied",
+					},
+				},
+			},
+		}},
+		config: []string{
+			data.HandlerACheck1,
+			data.InstanceCheck1,
+			data.RuleCheck1DryRun,
+		},
+		variety: tpb.TEMPLATE_VARIETY_CHECK,
+		log: `
+[tcheck] InstanceBuilderFn() => name: 'tcheck', bag: '---
+ident                         : dest.istio-system
+'
+[tcheck] InstanceBuilderFn() <= (SUCCESS)
+[tcheck] DispatchCheck => context exists: 'true'
+[tcheck] DispatchCheck => handler exists: 'true'
+[tcheck] DispatchCheck => instance:       '&Struct{Fields:map[string]*Value{},}'
+[tcheck] DispatchCheck <= (SUCCESS)
+`,
+	},
+
+	{
+		name: "CheckDryRunError",
+		templates: []data.FakeTemplateSettings{{
+			Name:                 "tcheck",
+			ErrorOnDispatchCheck: true,
+		}},
+		config: []string{
+			data.HandlerACheck1,
+			data.InstanceCheck1,
+			data.RuleCheck1DryRun,
+		},
+		variety: tpb.TEMPLATE_VARIETY_CHECK,
+		log: `
+[tcheck] InstanceBuilderFn() => name: 'tcheck', bag: '---
+ident                         : dest.istio-system
+'
+[tcheck] InstanceBuilderFn() <= (SUCCESS)
+[tcheck] DispatchCheck => context exists: 'true'
+[tcheck] DispatchCheck => handler exists: 'true'
+[tcheck] DispatchCheck => instance:       '&Struct{Fields:map[string]*Value{},}'
+[tcheck] DispatchCheck <= (ERROR)
+`,
+	},
+
 	{
 		name: "BasicCheckWithExpressions",
 		config: []string{
@@ -29,6 +29,7 @@ type dispatchState struct {
 	session *session
 
 	destination *routing.Destination
+	group       *routing.InstanceGroup
 	mapper      template.OutputMapperFn
 
 	inputBag  attribute.Bag
@@ -46,6 +47,7 @@ type dispatchState struct {
 func (s *dispatchState) clear() {
 	s.session = nil
 	s.destination = nil
+	s.group = nil
 	s.mapper = nil
 	s.inputBag = nil
 	s.quotaArgs = adapter.QuotaArgs{}
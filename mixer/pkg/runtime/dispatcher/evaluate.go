@@ -0,0 +1,58 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	tpb "istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/attribute"
+	"istio.io/istio/mixer/pkg/runtime/routing"
+)
+
+// Evaluation describes what the dispatcher would do with an attribute bag, for each API method.
+type Evaluation struct {
+	// RoutingTable is the id of the routing table the bag was evaluated against.
+	RoutingTable int64 `json:"routingTable"`
+
+	// Namespace the destinations were selected from, based on the identity attribute.
+	Namespace string `json:"namespace"`
+
+	// Preprocess destinations. Since handlers are not called, the attributes they would generate are not
+	// available to the other methods.
+	Preprocess []*routing.DestinationEvaluation `json:"preprocess"`
+	Check      []*routing.DestinationEvaluation `json:"check"`
+	Quota      []*routing.DestinationEvaluation `json:"quota"`
+	Report     []*routing.DestinationEvaluation `json:"report"`
+}
+
+// Evaluate evaluates the attribute bag against the current routing table, without dispatching to any handler.
+func (d *Impl) Evaluate(bag attribute.Bag) (*Evaluation, error) {
+	identityAttributeValue, err := getIdentityAttributeValue(bag, d.identityAttribute)
+	if err != nil {
+		return nil, err
+	}
+	namespace := getNamespace(identityAttributeValue)
+
+	r := d.acquireRoutingContext()
+	defer r.DecRef()
+
+	return &Evaluation{
+		RoutingTable: r.Routes.ID(),
+		Namespace:    namespace,
+		Preprocess:   r.Routes.Evaluate(tpb.TEMPLATE_VARIETY_ATTRIBUTE_GENERATOR, namespace, bag),
+		Check:        r.Routes.Evaluate(tpb.TEMPLATE_VARIETY_CHECK, namespace, bag),
+		Quota:        r.Routes.Evaluate(tpb.TEMPLATE_VARIETY_QUOTA, namespace, bag),
+		Report:       r.Routes.Evaluate(tpb.TEMPLATE_VARIETY_REPORT, namespace, bag),
+	}, nil
+}
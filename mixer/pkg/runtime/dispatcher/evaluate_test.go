@@ -0,0 +1,64 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"strings"
+	"testing"
+
+	"istio.io/istio/mixer/pkg/attribute"
+	"istio.io/istio/mixer/pkg/lang/compiled"
+	"istio.io/istio/mixer/pkg/pool"
+	"istio.io/istio/mixer/pkg/runtime/handler"
+	"istio.io/istio/mixer/pkg/runtime/routing"
+	"istio.io/istio/mixer/pkg/runtime/testing/data"
+	"istio.io/istio/mixer/pkg/runtime/testing/util"
+)
+
+func TestEvaluate(t *testing.T) {
+	d := New("ident", gp, false)
+
+	l := &data.Logger{}
+	templates := data.BuildTemplates(l)
+	adapters := data.BuildAdapters(l)
+	config := data.JoinConfigs(data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1,
+		data.HandlerAReport1, data.InstanceReport1, data.RuleReport1)
+
+	s := util.GetSnapshot(templates, adapters, data.ServiceConfig, config)
+	h := handler.NewTable(handler.Empty(), s, pool.NewGoroutinePool(1, false))
+	_ = d.ChangeRoute(routing.BuildTable(h, s, compiled.NewBuilder(s.Attributes), "istio-system", false))
+	l.Clear()
+
+	e, err := d.Evaluate(attribute.GetFakeMutableBagForTesting(map[string]interface{}{"ident": "dest.istio-system"}))
+	if err != nil {
+		t.Fatalf("Evaluate() => %v", err)
+	}
+
+	if e.Namespace != "istio-system" || len(e.Check) != 1 || len(e.Report) != 1 || len(e.Quota) != 0 || len(e.Preprocess) != 0 {
+		t.Fatalf("unexpected evaluation: %+v", e)
+	}
+	if len(e.Check[0].Groups) != 1 || len(e.Check[0].Groups[0].Instances) != 1 {
+		t.Fatalf("unexpected check evaluation: %+v", e.Check[0])
+	}
+
+	// Handlers are not dispatched to.
+	if strings.Contains(l.String(), "Dispatch") {
+		t.Errorf("handlers were dispatched to: %s", l.String())
+	}
+
+	if _, err = d.Evaluate(attribute.GetFakeMutableBagForTesting(nil)); err == nil {
+		t.Error("Evaluate() succeeded without the identity attribute, want error")
+	}
+}
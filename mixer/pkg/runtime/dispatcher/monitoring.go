@@ -15,6 +15,7 @@
 package dispatcher
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -24,6 +25,8 @@ var (
 	buckets           = []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
 	countBuckets      = []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 10, 15, 20}
 	requestLabelNames = []string{errorStr}
+	queueLabelNames   = []string{meshFunction, handlerName, adapterName}
+	dryRunLabelNames  = []string{ruleName, handlerName, wouldDeny}
 
 	requestCountVector = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "mixer",
@@ -58,6 +61,49 @@ var (
 		Help:      "Histogram of inputs dispatched per request, by Mixer.",
 		Buckets:   countBuckets,
 	})
+
+	reportQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "mixer",
+		Subsystem: "dispatcher",
+		Name:      "report_queue_depth",
+		Help:      "Number of report instances queued for dispatch to a handler, by Mixer.",
+	}, queueLabelNames)
+
+	reportQueueDrops = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "mixer",
+		Subsystem: "dispatcher",
+		Name:      "report_queue_dropped_instances",
+		Help:      "Total number of report instances dropped because the queue of a handler was full.",
+	}, queueLabelNames)
+
+	reportFlushDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "mixer",
+		Subsystem: "dispatcher",
+		Name:      "report_flush_duration",
+		Help:      "Histogram of times for flushing batches of queued report instances to a handler, by Mixer.",
+		Buckets:   buckets,
+	}, queueLabelNames)
+
+	dryRunCheckCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "mixer",
+		Subsystem: "dispatcher",
+		Name:      "dry_run_check_count",
+		Help:      "Total number of checks dispatched for dry-run rules, by whether the rule would have denied the request.",
+	}, dryRunLabelNames)
+
+	checkCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "mixer",
+		Subsystem: "dispatcher",
+		Name:      "check_cache_hits",
+		Help:      "Total number of checks answered from the check cache, by Mixer.",
+	})
+
+	checkCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "mixer",
+		Subsystem: "dispatcher",
+		Name:      "check_cache_misses",
+		Help:      "Total number of checks not found in the check cache, by Mixer.",
+	})
 )
 
 func init() {
@@ -65,6 +111,12 @@ func init() {
 	prometheus.MustRegister(destinationsPerRequest)
 	prometheus.MustRegister(instancesPerRequest)
 	prometheus.MustRegister(requestDuration)
+	prometheus.MustRegister(reportQueueDepth)
+	prometheus.MustRegister(reportQueueDrops)
+	prometheus.MustRegister(reportFlushDuration)
+	prometheus.MustRegister(dryRunCheckCount)
+	prometheus.MustRegister(checkCacheHits)
+	prometheus.MustRegister(checkCacheMisses)
 }
 
 // updateRequestCounters updates request related counters. Duration is the total request handling duration. Destinations
@@ -82,3 +134,9 @@ func updateRequestCounters(duration time.Duration, destinations int, inputs int,
 	instancesPerRequest.Observe(float64(inputs))
 	requestDuration.Observe(duration.Seconds())
 }
+
+// updateDryRunCounters counts a check dispatched for a dry-run rule. WouldDeny indicates whether the result of the
+// check would have denied the request, had the rule been enforced.
+func updateDryRunCounters(rule string, handler string, wouldDeny bool) {
+	dryRunCheckCount.WithLabelValues(rule, handler, strconv.FormatBool(wouldDeny)).Inc()
+}
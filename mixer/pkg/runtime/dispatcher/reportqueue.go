@@ -0,0 +1,432 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/runtime/config"
+	"istio.io/istio/mixer/pkg/runtime/routing"
+	"istio.io/istio/mixer/pkg/template"
+	"istio.io/istio/pkg/log"
+)
+
+// ReportQueueOptions controls the asynchronous dispatch of report instances. When enabled, Report returns as
+// soon as the instances of the request are queued, and the instances are dispatched to each handler in batches.
+// Only the dispatch to handlers is asynchronous: preprocessing and building the instances still happen on the
+// request.
+type ReportQueueOptions struct {
+	// QueueSize is the maximum number of instances queued for a handler.
+	QueueSize int
+
+	// BatchSize is the number of queued instances that triggers a flush to the handler. It is capped to QueueSize.
+	BatchSize int
+
+	// FlushInterval is the maximum amount of time instances stay queued before being flushed to the handler.
+	FlushInterval time.Duration
+
+	// DropWhenFull drops the instances that do not fit in the queue of a handler. Otherwise, Report blocks
+	// until the queue has room for them. Handlers override it with the istio-report-queue-full label.
+	DropWhenFull bool
+}
+
+// Validate checks that the options are usable.
+func (o *ReportQueueOptions) Validate() error {
+	if o.QueueSize <= 0 {
+		return fmt.Errorf("report queue size must be > 0, got %d", o.QueueSize)
+	}
+	if o.BatchSize < 0 {
+		return fmt.Errorf("report batch size must be >= 0, got %d", o.BatchSize)
+	}
+	if o.FlushInterval <= 0 {
+		return fmt.Errorf("report flush interval must be > 0, got %v", o.FlushInterval)
+	}
+	return nil
+}
+
+// reportQueues holds a queue per report destination of the routing tables in use.
+type reportQueues struct {
+	d    *Impl
+	opts ReportQueueOptions
+
+	mu      sync.Mutex
+	queues  map[*routing.Destination]*reportQueue
+	stopped bool
+
+	// closed when the queues are stopped.
+	done chan struct{}
+
+	// tracks the running queues.
+	wg sync.WaitGroup
+}
+
+// reportQueue buffers the instances of a single destination, and flushes them to the handler in batches.
+type reportQueue struct {
+	queues      *reportQueues
+	destination *routing.Destination
+
+	// the routing context the destination belongs to.
+	context *RoutingContext
+
+	// drops the instances that do not fit in the queue, instead of blocking.
+	dropWhenFull bool
+
+	mu      sync.Mutex
+	notFull *sync.Cond
+	pending []queuedReport
+	size    int
+	closed  bool
+
+	// signaled when a batch is ready.
+	ready chan struct{}
+
+	depth         prometheus.Gauge
+	drops         prometheus.Counter
+	flushDuration prometheus.Observer
+}
+
+// queuedReport is the set of instances of a single report request, destined to a handler.
+type queuedReport struct {
+	data      *adapter.RequestData
+	instances []interface{}
+
+	// the routing context the instances were built with.
+	context *RoutingContext
+
+	// the span of the request, if it is traced.
+	span opentracing.SpanContext
+}
+
+func newReportQueues(d *Impl, opts ReportQueueOptions) *reportQueues {
+	if opts.BatchSize <= 0 || opts.BatchSize > opts.QueueSize {
+		opts.BatchSize = opts.QueueSize
+	}
+
+	return &reportQueues{
+		d:      d,
+		opts:   opts,
+		queues: make(map[*routing.Destination]*reportQueue),
+		done:   make(chan struct{}),
+	}
+}
+
+// enqueue queues the instances for the destination, which belongs to the routing context. It returns false
+// if the queues are stopped, in which case the caller is expected to dispatch the instances itself.
+func (qs *reportQueues) enqueue(ctx context.Context, r *RoutingContext, destination *routing.Destination,
+	instances []interface{}) bool {
+
+	data, _ := adapter.RequestDataFromContext(ctx)
+	item := queuedReport{
+		data: data,
+		// the instances slice is reused by the caller.
+		instances: append([]interface{}(nil), instances...),
+		context:   r,
+	}
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		item.span = span.Context()
+	}
+
+	for {
+		q := qs.get(r, destination)
+		if q == nil {
+			return false
+		}
+		if q.enqueue(item) {
+			return true
+		}
+		// the queue was retired in the meantime, retry with a new one.
+	}
+}
+
+// get returns the queue of the destination, creating it if needed. It returns nil if the queues are stopped.
+func (qs *reportQueues) get(r *RoutingContext, destination *routing.Destination) *reportQueue {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	if qs.stopped {
+		return nil
+	}
+
+	q, found := qs.queues[destination]
+	if !found {
+		q = newReportQueue(qs, r, destination)
+		qs.queues[destination] = q
+		qs.wg.Add(1)
+		go q.run()
+	}
+	return q
+}
+
+// stop stops accepting instances and waits until the queued ones are flushed.
+func (qs *reportQueues) stop() {
+	qs.mu.Lock()
+	if qs.stopped {
+		qs.mu.Unlock()
+		return
+	}
+	qs.stopped = true
+	close(qs.done)
+	qs.mu.Unlock()
+
+	qs.wg.Wait()
+}
+
+// retire removes an empty queue whose routing context is no longer current.
+func (qs *reportQueues) retire(q *reportQueue) bool {
+	qs.d.contextLock.RLock()
+	current := qs.d.context
+	qs.d.contextLock.RUnlock()
+
+	if q.context == current {
+		return false
+	}
+
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.size > 0 {
+		return false
+	}
+
+	q.closed = true
+	q.notFull.Broadcast()
+	delete(qs.queues, q.destination)
+	return true
+}
+
+func newReportQueue(qs *reportQueues, r *RoutingContext, destination *routing.Destination) *reportQueue {
+	labels := prometheus.Labels{
+		meshFunction: destination.Template.Name,
+		handlerName:  destination.HandlerName,
+		adapterName:  destination.AdapterName,
+	}
+
+	dropWhenFull := qs.opts.DropWhenFull
+	switch destination.ReportQueueFull {
+	case config.ReportQueueFullDrop:
+		dropWhenFull = true
+	case config.ReportQueueFullBlock:
+		dropWhenFull = false
+	}
+
+	q := &reportQueue{
+		queues:        qs,
+		destination:   destination,
+		context:       r,
+		dropWhenFull:  dropWhenFull,
+		ready:         make(chan struct{}, 1),
+		depth:         reportQueueDepth.With(labels),
+		drops:         reportQueueDrops.With(labels),
+		flushDuration: reportFlushDuration.With(labels),
+	}
+	q.notFull = sync.NewCond(&q.mu)
+	return q
+}
+
+// enqueue adds the item to the queue, applying the queue full policy. It returns false if the queue is closed.
+func (q *reportQueue) enqueue(item queuedReport) bool {
+	n := len(item.instances)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// An item larger than the whole queue is still accepted by an empty queue.
+	for !q.closed && q.size > 0 && q.size+n > q.queues.opts.QueueSize {
+		if q.dropWhenFull {
+			q.drops.Add(float64(n))
+			log.Debugf("report queue full, dropping %d instances: destination='%s'", n, q.destination.FriendlyName)
+			return true
+		}
+		q.signal()
+		q.notFull.Wait()
+	}
+
+	if q.closed {
+		return false
+	}
+
+	// The items hold on to their routing context, so that its handlers are not closed before they are flushed.
+	item.context.IncRef()
+	q.pending = append(q.pending, item)
+	q.size += n
+	q.depth.Add(float64(n))
+
+	if q.size >= q.queues.opts.BatchSize {
+		q.signal()
+	}
+	return true
+}
+
+// signal wakes up the flusher, without blocking if it was already signaled.
+func (q *reportQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// run flushes the queue whenever a batch is ready or the flush interval elapses, until the queue is retired or
+// the queues are stopped.
+func (q *reportQueue) run() {
+	defer q.queues.wg.Done()
+
+	ticker := time.NewTicker(q.queues.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.ready:
+			q.flush()
+
+		case <-ticker.C:
+			q.flush()
+			if q.queues.retire(q) {
+				return
+			}
+
+		case <-q.queues.done:
+			q.mu.Lock()
+			q.closed = true
+			q.notFull.Broadcast()
+			q.mu.Unlock()
+
+			q.flush()
+			return
+		}
+	}
+}
+
+// flush dispatches all the pending instances, a batch at a time.
+func (q *reportQueue) flush() {
+	for {
+		batch := q.take()
+		if len(batch) == 0 {
+			return
+		}
+		q.dispatch(batch)
+	}
+}
+
+// take removes up to a batch worth of items from the queue.
+func (q *reportQueue) take() []queuedReport {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	i, n := 0, 0
+	for i < len(q.pending) && (i == 0 || n+len(q.pending[i].instances) <= q.queues.opts.BatchSize) {
+		n += len(q.pending[i].instances)
+		i++
+	}
+	if i == 0 {
+		return nil
+	}
+
+	batch := make([]queuedReport, i)
+	copy(batch, q.pending)
+	q.pending = append(q.pending[:0], q.pending[i:]...)
+	q.size -= n
+	q.depth.Sub(float64(n))
+	q.notFull.Broadcast()
+
+	return batch
+}
+
+// dispatch sends the instances of the batch to the handler. Since adapters rely on the request data of the
+// context, instances are dispatched together only if they came from requests with the same destination service.
+// The dispatch of a group is traced in a span that follows from the spans of the requests it holds instances of.
+func (q *reportQueue) dispatch(batch []queuedReport) {
+	start := time.Now()
+
+	var order []*adapter.RequestData
+	groups := make(map[string][]interface{})
+	refs := make(map[string][]opentracing.StartSpanOption)
+	for _, item := range batch {
+		key := ""
+		if item.data != nil {
+			key = item.data.DestinationService.FullName
+		}
+		if _, found := groups[key]; !found {
+			order = append(order, item.data)
+		}
+		groups[key] = append(groups[key], item.instances...)
+		if item.span != nil {
+			refs[key] = append(refs[key], opentracing.FollowsFrom(item.span))
+		}
+	}
+
+	for _, data := range order {
+		key := ""
+		ctx := context.Background()
+		if data != nil {
+			key = data.DestinationService.FullName
+			ctx = adapter.NewContextWithRequestData(ctx, data)
+		}
+
+		var span opentracing.Span
+		if len(refs[key]) > 0 {
+			span = opentracing.StartSpan(q.destination.FriendlyName, refs[key]...)
+			ctx = opentracing.ContextWithSpan(ctx, span)
+		}
+
+		dispatchStart := time.Now()
+		err := dispatchReport(ctx, q.destination, groups[key])
+		q.destination.Counters.Update(time.Since(dispatchStart), err != nil)
+		if err != nil {
+			log.Warnf("error dispatching queued report instances: destination='%s', error='%v'",
+				q.destination.FriendlyName, err)
+		}
+
+		if span != nil {
+			logToDispatchSpan(span, q.destination.Template.Name, q.destination.HandlerName, q.destination.AdapterName, err)
+			span.Finish()
+		}
+	}
+
+	q.flushDuration.Observe(time.Since(start).Seconds())
+
+	for _, item := range batch {
+		item.context.DecRef()
+	}
+}
+
+// dispatchReport dispatches the report instances to the handler of the destination.
+func dispatchReport(ctx context.Context, destination *routing.Destination, instances []interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic during handler dispatch: %v", r)
+			log.Errorf("%v", err)
+
+			if log.DebugEnabled() {
+				log.Debugf("stack dump for handler dispatch panic:\n%s", debug.Stack())
+			}
+		}
+	}()
+
+	if remote, ok := destination.Handler.(template.RemoteHandler); ok {
+		return remote.HandleRemoteReport(ctx, destination.Template, instances)
+	}
+	return destination.Template.DispatchReport(ctx, destination.Handler, instances)
+}
@@ -0,0 +1,348 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+
+	tpb "istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/attribute"
+	"istio.io/istio/mixer/pkg/lang/compiled"
+	"istio.io/istio/mixer/pkg/pool"
+	"istio.io/istio/mixer/pkg/runtime/handler"
+	"istio.io/istio/mixer/pkg/runtime/routing"
+	"istio.io/istio/mixer/pkg/runtime/testing/data"
+	"istio.io/istio/mixer/pkg/runtime/testing/util"
+)
+
+// handlerAReport1DropWhenFull is data.HandlerAReport1, dropping the instances that do not fit in its report queue.
+var handlerAReport1DropWhenFull = `
+apiVersion: "config.istio.io/v1alpha2"
+kind: areport
+metadata:
+  name: hreport1
+  namespace: istio-system
+  labels:
+    istio-report-queue-full: "drop"
+spec:
+`
+
+func newQueueingDispatcher(t *testing.T, opts ReportQueueOptions, settings data.FakeTemplateSettings) (*Impl, *data.Logger) {
+	return newQueueingDispatcherWithHandler(t, opts, settings, data.HandlerAReport1)
+}
+
+func newQueueingDispatcherWithHandler(t *testing.T, opts ReportQueueOptions, settings data.FakeTemplateSettings,
+	handlerConfig string) (*Impl, *data.Logger) {
+
+	d := New("ident", gp, false)
+	if err := d.EnableReportQueues(opts); err != nil {
+		t.Fatalf("EnableReportQueues() => %v", err)
+	}
+
+	l := &data.Logger{}
+	templates := data.BuildTemplates(l, settings)
+	adapters := data.BuildAdapters(l)
+	config := data.JoinConfigs(handlerConfig, data.InstanceReport1, data.RuleReport1)
+
+	s := util.GetSnapshot(templates, adapters, data.ServiceConfig, config)
+	h := handler.NewTable(handler.Empty(), s, pool.NewGoroutinePool(1, false))
+	_ = d.ChangeRoute(routing.BuildTable(h, s, compiled.NewBuilder(s.Attributes), "istio-system", true))
+	l.Clear()
+
+	return d, l
+}
+
+func report(t *testing.T, d *Impl) {
+	bag := attribute.GetFakeMutableBagForTesting(map[string]interface{}{"ident": "dest.istio-system"})
+	if err := d.Report(context.TODO(), bag); err != nil {
+		t.Fatalf("Report() => %v", err)
+	}
+}
+
+func waitForCall(t *testing.T, received chan struct{}) {
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler was not dispatched to")
+	}
+}
+
+func dispatchedInstances(l *data.Logger) []string {
+	var result []string
+	for _, line := range strings.Split(l.String(), "\n") {
+		if i := strings.Index(line, "DispatchReport => instances: "); i >= 0 {
+			result = append(result, line[i+len("DispatchReport => instances: "):])
+		}
+	}
+	return result
+}
+
+func TestReportQueueOptions_Validate(t *testing.T) {
+	cases := []struct {
+		opts ReportQueueOptions
+		err  string
+	}{
+		{ReportQueueOptions{QueueSize: 10, BatchSize: 5, FlushInterval: time.Second}, ""},
+		{ReportQueueOptions{QueueSize: 0, FlushInterval: time.Second}, "report queue size must be > 0, got 0"},
+		{ReportQueueOptions{QueueSize: 10, BatchSize: -1, FlushInterval: time.Second}, "report batch size must be >= 0, got -1"},
+		{ReportQueueOptions{QueueSize: 10}, "report flush interval must be > 0, got 0s"},
+	}
+
+	for _, c := range cases {
+		err := c.opts.Validate()
+		if c.err == "" && err != nil {
+			t.Errorf("%+v: Validate() => %v, want success", c.opts, err)
+		}
+		if c.err != "" && (err == nil || err.Error() != c.err) {
+			t.Errorf("%+v: Validate() => %v, want %q", c.opts, err, c.err)
+		}
+	}
+}
+
+func TestReportQueue_FlushOnBatchSize(t *testing.T) {
+	received := make(chan struct{}, 10)
+	d, l := newQueueingDispatcher(t,
+		ReportQueueOptions{QueueSize: 10, BatchSize: 2, FlushInterval: time.Hour},
+		data.FakeTemplateSettings{Name: "treport", ReceivedCallChannel: received})
+
+	report(t, d)
+	report(t, d)
+	waitForCall(t, received)
+	d.StopReportQueues()
+
+	got := dispatchedInstances(l)
+	if len(got) != 1 || strings.Count(got[0], "&Struct") != 2 {
+		t.Fatalf("dispatched instances: %v, want a single batch of 2 instances", got)
+	}
+}
+
+func TestReportQueue_FlushOnInterval(t *testing.T) {
+	received := make(chan struct{}, 10)
+	d, l := newQueueingDispatcher(t,
+		ReportQueueOptions{QueueSize: 10, BatchSize: 10, FlushInterval: 10 * time.Millisecond},
+		data.FakeTemplateSettings{Name: "treport", ReceivedCallChannel: received})
+
+	report(t, d)
+	waitForCall(t, received)
+	d.StopReportQueues()
+
+	if got := dispatchedInstances(l); len(got) != 1 {
+		t.Fatalf("dispatched instances: %v, want a single batch", got)
+	}
+}
+
+func TestReportQueue_DropWhenFull(t *testing.T) {
+	received := make(chan struct{}, 10)
+	commence := make(chan struct{})
+	d, l := newQueueingDispatcher(t,
+		ReportQueueOptions{QueueSize: 1, FlushInterval: time.Hour, DropWhenFull: true},
+		data.FakeTemplateSettings{Name: "treport", ReceivedCallChannel: received, CommenceSignalChannel: commence})
+
+	// The first report is taken by the flusher, which is held by the handler.
+	report(t, d)
+	waitForCall(t, received)
+
+	// The second one fills the queue, and the third one is dropped.
+	report(t, d)
+	report(t, d)
+
+	close(commence)
+	d.StopReportQueues()
+
+	if got := dispatchedInstances(l); len(got) != 2 {
+		t.Fatalf("dispatched instances: %v, want 2 batches", got)
+	}
+}
+
+func TestReportQueue_HandlerDropWhenFull(t *testing.T) {
+	received := make(chan struct{}, 10)
+	commence := make(chan struct{})
+	d, l := newQueueingDispatcherWithHandler(t,
+		ReportQueueOptions{QueueSize: 1, FlushInterval: time.Hour},
+		data.FakeTemplateSettings{Name: "treport", ReceivedCallChannel: received, CommenceSignalChannel: commence},
+		handlerAReport1DropWhenFull)
+
+	// The handler overrides the server default, so the third report is dropped instead of blocking.
+	report(t, d)
+	waitForCall(t, received)
+	report(t, d)
+	report(t, d)
+
+	close(commence)
+	d.StopReportQueues()
+
+	if got := dispatchedInstances(l); len(got) != 2 {
+		t.Fatalf("dispatched instances: %v, want 2 batches", got)
+	}
+}
+
+func TestReportQueue_BlockWhenFull(t *testing.T) {
+	received := make(chan struct{}, 10)
+	commence := make(chan struct{})
+	d, l := newQueueingDispatcher(t,
+		ReportQueueOptions{QueueSize: 1, FlushInterval: time.Hour},
+		data.FakeTemplateSettings{Name: "treport", ReceivedCallChannel: received, CommenceSignalChannel: commence})
+
+	report(t, d)
+	waitForCall(t, received)
+	report(t, d)
+
+	done := make(chan struct{})
+	go func() {
+		report(t, d)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Report() returned while the queue was full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(commence)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Report() did not return after the queue was drained")
+	}
+	d.StopReportQueues()
+
+	if got := dispatchedInstances(l); len(got) != 3 {
+		t.Fatalf("dispatched instances: %v, want 3 batches", got)
+	}
+}
+
+func TestReportQueue_HoldsRoutingContext(t *testing.T) {
+	received := make(chan struct{}, 10)
+	commence := make(chan struct{})
+	d, _ := newQueueingDispatcher(t,
+		ReportQueueOptions{QueueSize: 10, BatchSize: 1, FlushInterval: time.Hour},
+		data.FakeTemplateSettings{Name: "treport", ReceivedCallChannel: received, CommenceSignalChannel: commence})
+
+	report(t, d)
+	waitForCall(t, received)
+
+	old := d.ChangeRoute(routing.Empty())
+	if old.GetRefs() != 1 {
+		t.Fatalf("%d != 1", old.GetRefs())
+	}
+
+	close(commence)
+	d.StopReportQueues()
+	if old.GetRefs() != 0 {
+		t.Fatalf("%d != 0", old.GetRefs())
+	}
+}
+
+func TestReportQueue_HoldsContextOfInstances(t *testing.T) {
+	received := make(chan struct{}, 10)
+	commence := make(chan struct{})
+	d, _ := newQueueingDispatcher(t,
+		ReportQueueOptions{QueueSize: 10, BatchSize: 1, FlushInterval: time.Hour},
+		data.FakeTemplateSettings{Name: "treport", ReceivedCallChannel: received, CommenceSignalChannel: commence})
+
+	// The queue of the destination is created by a report of the current routing context.
+	report(t, d)
+	waitForCall(t, received)
+
+	// Instances built with another routing context that shares the destination hold on to that context.
+	current := d.context
+	destination := current.Routes.GetDestinations(tpb.TEMPLATE_VARIETY_REPORT, "istio-system").Entries()[0]
+	other := &RoutingContext{Routes: current.Routes}
+	if !d.reportQueues.enqueue(context.Background(), other, destination, []interface{}{"instance"}) {
+		t.Fatal("enqueue() => false, want true")
+	}
+	if other.GetRefs() != 1 {
+		t.Fatalf("%d != 1", other.GetRefs())
+	}
+
+	close(commence)
+	d.StopReportQueues()
+	if other.GetRefs() != 0 {
+		t.Fatalf("%d != 0", other.GetRefs())
+	}
+	if current.GetRefs() != 0 {
+		t.Fatalf("%d != 0", current.GetRefs())
+	}
+}
+
+func TestReportQueue_Stopped(t *testing.T) {
+	d, l := newQueueingDispatcher(t,
+		ReportQueueOptions{QueueSize: 10, FlushInterval: time.Hour},
+		data.FakeTemplateSettings{Name: "treport"})
+	d.StopReportQueues()
+
+	// Reports are dispatched synchronously once the queues are stopped.
+	report(t, d)
+	if got := dispatchedInstances(l); len(got) != 1 {
+		t.Fatalf("dispatched instances: %v, want a single batch", got)
+	}
+}
+
+// referencesTracer counts the references of the spans it starts.
+type referencesTracer struct {
+	opentracing.NoopTracer
+
+	mu         sync.Mutex
+	references int
+}
+
+func (t *referencesTracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	sso := opentracing.StartSpanOptions{}
+	for _, o := range opts {
+		o.Apply(&sso)
+	}
+
+	t.mu.Lock()
+	t.references += len(sso.References)
+	t.mu.Unlock()
+
+	return t.NoopTracer.StartSpan(operationName, opts...)
+}
+
+func TestReportQueue_FollowsRequestSpans(t *testing.T) {
+	tracer := &referencesTracer{}
+	old := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(old)
+
+	received := make(chan struct{}, 10)
+	d, _ := newQueueingDispatcher(t,
+		ReportQueueOptions{QueueSize: 10, BatchSize: 2, FlushInterval: time.Hour},
+		data.FakeTemplateSettings{Name: "treport", ReceivedCallChannel: received})
+
+	for i := 0; i < 2; i++ {
+		ctx := opentracing.ContextWithSpan(context.Background(), tracer.StartSpan("request"))
+		bag := attribute.GetFakeMutableBagForTesting(map[string]interface{}{"ident": "dest.istio-system"})
+		if err := d.Report(ctx, bag); err != nil {
+			t.Fatalf("Report() => %v", err)
+		}
+	}
+	waitForCall(t, received)
+	d.StopReportQueues()
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	// The batch is dispatched in a single span that follows from both requests.
+	if tracer.references != 2 {
+		t.Fatalf("span references: %d, want 2", tracer.references)
+	}
+}
@@ -54,6 +54,12 @@ type session struct {
 	// The variety of the operation that is being performed.
 	variety tpb.TemplateVariety
 
+	// id of the routing table used for the dispatch.
+	routes int64
+
+	// whether the check result must not be cached, because of the handlers or rules that produced it.
+	checkCacheDisabled bool
+
 	// whether to trace spans or not
 	trace bool
 }
@@ -73,6 +79,8 @@ func (s *session) ensureParallelism(minParallelism int) {
 
 func (s *session) clear() {
 	s.variety = 0
+	s.routes = 0
+	s.checkCacheDisabled = false
 	s.ctx = nil
 	s.bag = nil
 	s.quotaArgs = adapter.QuotaArgs{}
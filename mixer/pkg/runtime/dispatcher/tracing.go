@@ -29,6 +29,8 @@ const (
 	responseCode = "response_code"
 	responseMsg  = "response_message"
 	errorStr     = "error"
+	ruleName     = "rule"
+	wouldDeny    = "would_deny"
 )
 
 // LogToDispatchSpan logs to the given Span in a structured manner. Span must be valid.
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/gogo/protobuf/proto"
 
@@ -145,6 +146,22 @@ func (f *factory) buildHandler(
 	var ti *template.Info
 	var types inferredTypesMap
 
+	if remote, ok := builder.(template.RemoteHandlerBuilder); ok {
+		// Out-of-process handlers receive the instances as protos, and need no inferred types.
+		tmplNames := make([]string, 0, len(inferredTypes))
+		for tmplName := range inferredTypes {
+			tmplNames = append(tmplNames, tmplName)
+		}
+		sort.Strings(tmplNames)
+
+		templates := make([]*template.Info, 0, len(tmplNames))
+		for _, tmplName := range tmplNames {
+			templates = append(templates, f.snapshot.Templates[tmplName])
+		}
+		remote.SetRemoteTemplates(templates)
+		inferredTypes = nil
+	}
+
 	for tmplName := range inferredTypes {
 		types = inferredTypes[tmplName]
 		// ti should be there for a valid configuration.
@@ -50,8 +50,12 @@ package routing
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
 	tpb "istio.io/api/mixer/adapter/model/v1beta1"
 	descriptor "istio.io/api/policy/v1beta1"
 	"istio.io/istio/mixer/pkg/lang/ast"
@@ -82,16 +86,41 @@ type builder struct {
 	// instanceName set of builders by the input set.
 	instanceNamesByID map[uint32][]string
 
-	// InstanceBuilderFns by instance name.
-	builders map[string]template.InstanceBuilderFn
+	// compiled instances and expressions of the table being built.
+	artifacts *artifacts
 
-	// OutputMapperFns by instance name.
-	mappers map[string]template.OutputMapperFn
+	// compiled instances and expressions of the previous table, that can be reused. Nil if the previous table is
+	// not usable, e.g. because the attribute vocabulary changed.
+	previous *artifacts
+
+	// number of compilations that were required by the rules of each namespace.
+	compiledByNamespace map[string]int
+
+	// number of compilations that were avoided by reusing the previous table.
+	reusedCount int
+}
+
+// artifacts are the compiled instances and expressions of a table, which are carried over to the table that
+// replaces it on config change, so that only the changed config gets recompiled.
+type artifacts struct {
+	// attribute vocabulary the artifacts were compiled against.
+	attributes ast.AttributeDescriptorFinder
+
+	// compiled instances by instance name.
+	instances map[string]*compiledInstance
 
 	// compiled.Expressions by canonicalized rule match clauses
 	expressions map[string]compiled.Expression
 }
 
+// compiledInstance is the InstanceBuilderFn, and the OutputMapperFn for attribute generators, of an instance.
+type compiledInstance struct {
+	template *template.Info
+	params   proto.Message
+	builder  template.InstanceBuilderFn
+	mapper   template.OutputMapperFn
+}
+
 // BuildTable builds and returns a routing table. If debugInfo is set, the returned table will have debugging information
 // attached, which will show up in String() call.
 func BuildTable(
@@ -101,6 +130,23 @@ func BuildTable(
 	defaultConfigNamespace string,
 	debugInfo bool) *Table {
 
+	return RebuildTable(nil, handlers, config, expb, defaultConfigNamespace, debugInfo)
+}
+
+// RebuildTable builds a routing table that replaces the previous one. The instances and match expressions of the
+// previous table are reused as long as their config and the attribute vocabulary did not change, so that only the
+// rules affected by a config change get recompiled. The previous table can be nil, in which case the whole table is
+// compiled.
+func RebuildTable(
+	previous *Table,
+	handlers *handler.Table,
+	config *config.Snapshot,
+	expb *compiled.ExpressionBuilder,
+	defaultConfigNamespace string,
+	debugInfo bool) *Table {
+
+	start := time.Now()
+
 	b := &builder{
 
 		table: &Table{
@@ -116,20 +162,45 @@ func BuildTable(
 		matchesByID:       make(map[uint32]string, len(config.Rules)),
 		instanceNamesByID: make(map[uint32][]string, len(config.Instances)),
 
-		builders:    make(map[string]template.InstanceBuilderFn, len(config.Instances)),
-		mappers:     make(map[string]template.OutputMapperFn, len(config.Instances)),
-		expressions: make(map[string]compiled.Expression, len(config.Rules)),
+		artifacts: &artifacts{
+			attributes:  config.Attributes,
+			instances:   make(map[string]*compiledInstance, len(config.Instances)),
+			expressions: make(map[string]compiled.Expression, len(config.Rules)),
+		},
+		compiledByNamespace: make(map[string]int),
+	}
+
+	reusedPrevious := false
+	if previous != nil && previous.artifacts != nil &&
+		reflect.DeepEqual(previous.artifacts.attributes, config.Attributes) {
+		b.previous = previous.artifacts
+		reusedPrevious = true
 	}
 
 	b.build(config)
+	b.table.artifacts = b.artifacts
 
+	b.table.groupInfo = &tableDebugInfo{
+		matchesByID:       b.matchesByID,
+		instanceNamesByID: b.instanceNamesByID,
+	}
 	if debugInfo {
-		b.table.debugInfo = &tableDebugInfo{
-			matchesByID:       b.matchesByID,
-			instanceNamesByID: b.instanceNamesByID,
-		}
+		b.table.debugInfo = b.table.groupInfo
 	}
 
+	compiledCount := 0
+	namespaces := make([]string, 0, len(b.compiledByNamespace))
+	for ns, count := range b.compiledByNamespace {
+		namespaces = append(namespaces, ns)
+		compiledCount += count
+	}
+	sort.Strings(namespaces)
+
+	duration := time.Since(start)
+	updateBuildCounters(reusedPrevious, duration, b.compiledByNamespace, b.reusedCount)
+	log.Infof("Built routing table: id='%d', reusedPrevious='%v', duration='%v', compiled='%d', reused='%d', namespaces='%v'",
+		config.ID, reusedPrevious, duration, compiledCount, b.reusedCount, namespaces)
+
 	return b.table
 }
 
@@ -172,14 +243,15 @@ func (b *builder) build(config *config.Snapshot) {
 			for _, instance := range action.Instances {
 				// get the instance mapper and builder for this instance. Mapper is used by APA instances
 				// to map the instance result back to attributes.
-				builder, mapper, err := b.getBuilderAndMapper(config.Attributes, instance)
+				builder, mapper, err := b.getBuilderAndMapper(config.Attributes, rule.Namespace, instance)
 				if err != nil {
 					log.Warnf("Unable to create builder/mapper for instance: instance='%s', err='%v'", instance.Name, err)
 					continue
 				}
 
 				b.add(rule.Namespace, instance.Template, entry, condition, builder, mapper,
-					entry.Name, instance.Name, rule.Match, rule.ResourceType)
+					entry.Name, instance.Name, rule.Match, rule.ResourceType, dryRunRuleName(rule),
+					action.Handler.CheckCacheDisabled, action.Handler.ReportQueueFull)
 			}
 		}
 	}
@@ -210,40 +282,60 @@ func (b *builder) build(config *config.Snapshot) {
 }
 
 // get or create a builder and a mapper for the given instance. The mapper is created only if the template
-// is an attribute generator.
+// is an attribute generator. The builder and mapper of the previous table are reused if the instance did not change.
 func (b *builder) getBuilderAndMapper(
 	finder ast.AttributeDescriptorFinder,
+	namespace string,
 	instance *config.Instance) (template.InstanceBuilderFn, template.OutputMapperFn, error) {
-	var err error
 
-	t := instance.Template
+	if c := b.artifacts.instances[instance.Name]; c != nil {
+		return c.builder, c.mapper, nil
+	}
 
-	builder := b.builders[instance.Name]
-	if builder == nil {
-		if builder, err = t.CreateInstanceBuilder(instance.Name, instance.Params, b.expb); err != nil {
-			return nil, nil, err
+	if b.previous != nil {
+		if c := b.previous.instances[instance.Name]; c != nil && c.template == instance.Template &&
+			(c.params == instance.Params || proto.Equal(c.params, instance.Params)) {
+			b.artifacts.instances[instance.Name] = c
+			b.reusedCount++
+			return c.builder, c.mapper, nil
 		}
-		b.builders[instance.Name] = builder
 	}
 
-	var mapper template.OutputMapperFn
+	var err error
+	t := instance.Template
+	c := &compiledInstance{
+		template: t,
+		params:   instance.Params,
+	}
+
+	if c.builder, err = t.CreateInstanceBuilder(instance.Name, instance.Params, b.expb); err != nil {
+		return nil, nil, err
+	}
+
 	if t.Variety == tpb.TEMPLATE_VARIETY_ATTRIBUTE_GENERATOR {
-		mapper = b.mappers[instance.Name]
-		if mapper == nil {
-			var expressions map[string]compiled.Expression
-			if expressions, err = t.CreateOutputExpressions(instance.Params, finder, b.expb); err != nil {
-				return nil, nil, err
-			}
-			mapper = template.NewOutputMapperFn(expressions)
+		var expressions map[string]compiled.Expression
+		if expressions, err = t.CreateOutputExpressions(instance.Params, finder, b.expb); err != nil {
+			return nil, nil, err
 		}
-
-		b.mappers[instance.Name] = mapper
+		c.mapper = template.NewOutputMapperFn(expressions)
 	}
 
-	return builder, mapper, nil
+	b.artifacts.instances[instance.Name] = c
+	b.compiled(namespace)
+
+	return c.builder, c.mapper, nil
+}
+
+// dryRunRuleName returns the name of the rule if it is in dry-run mode, and empty string otherwise.
+func dryRunRuleName(rule *config.Rule) string {
+	if rule.DryRun {
+		return rule.Name
+	}
+	return ""
 }
 
-// get or create a compiled.Expression for the rule's match clause, if necessary.
+// get or create a compiled.Expression for the rule's match clause, if necessary. The expression of the previous
+// table is reused if there is one for the same match clause.
 func (b *builder) getConditionExpression(rule *config.Rule) (compiled.Expression, error) {
 	text := strings.TrimSpace(rule.Match)
 
@@ -256,7 +348,14 @@ func (b *builder) getConditionExpression(rule *config.Rule) (compiled.Expression
 		return nil, nil
 	}
 
-	expression := b.expressions[text]
+	expression := b.artifacts.expressions[text]
+	if expression == nil && b.previous != nil {
+		if expression = b.previous.expressions[text]; expression != nil {
+			b.artifacts.expressions[text] = expression
+			b.reusedCount++
+		}
+	}
+
 	if expression == nil {
 		var err error
 		var t descriptor.ValueType
@@ -267,12 +366,18 @@ func (b *builder) getConditionExpression(rule *config.Rule) (compiled.Expression
 			return nil, fmt.Errorf("expression does not return a boolean: '%s'", text)
 		}
 
-		b.expressions[text] = expression
+		b.artifacts.expressions[text] = expression
+		b.compiled(rule.Namespace)
 	}
 
 	return expression, nil
 }
 
+// compiled records a compilation for a rule of the namespace.
+func (b *builder) compiled(namespace string) {
+	b.compiledByNamespace[namespace]++
+}
+
 func (b *builder) add(
 	namespace string,
 	t *template.Info,
@@ -283,7 +388,10 @@ func (b *builder) add(
 	handlerName string,
 	instanceName string,
 	matchText string,
-	resourceType config.ResourceType) {
+	resourceType config.ResourceType,
+	dryRunRule string,
+	checkCacheDisabled bool,
+	reportQueueFull string) {
 
 	// Find or create the variety entry.
 	byVariety, found := b.table.entries[t.Variety]
@@ -322,6 +430,9 @@ func (b *builder) add(
 			Template:       t,
 			InstanceGroups: []*InstanceGroup{},
 			Counters:       newDestinationCounters(t.Name, handlerName, entry.Adapter.Name),
+
+			CheckCacheDisabled: checkCacheDisabled,
+			ReportQueueFull:    reportQueueFull,
 		}
 		byNamespace.entries = append(byNamespace.entries, byHandler)
 	}
@@ -334,7 +445,8 @@ func (b *builder) add(
 		// Try to find an input set to place the entry by comparing the compiled expression and resource type.
 		// This doesn't flatten across all actions, but only for actions coming from the same rule. We can
 		// flatten based on the expression text as well.
-		if set.Condition == condition && set.ResourceType == resourceType {
+		// Groups of dry-run rules are kept apart, so that their results can be attributed to the rule.
+		if set.Condition == condition && set.ResourceType == resourceType && set.RuleName == dryRunRule {
 			instanceGroup = set
 			break
 		}
@@ -347,6 +459,8 @@ func (b *builder) add(
 			ResourceType: resourceType,
 			Builders:     []template.InstanceBuilderFn{},
 			Mappers:      []template.OutputMapperFn{},
+			DryRun:       dryRunRule != "",
+			RuleName:     dryRunRule,
 		}
 		byHandler.InstanceGroups = append(byHandler.InstanceGroups, instanceGroup)
 
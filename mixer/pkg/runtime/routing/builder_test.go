@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/gogo/protobuf/types"
+	dto "github.com/prometheus/client_model/go"
 
 	"istio.io/istio/mixer/pkg/adapter"
 	"istio.io/istio/mixer/pkg/lang/compiled"
@@ -91,6 +92,31 @@ ID: 1
 `,
 	},
 
+	{
+		Name:          "dry-run-rule",
+		ServiceConfig: data.ServiceConfig,
+		Configs: []string{
+			data.HandlerACheck1,
+			data.InstanceCheck1,
+			data.RuleCheck1DryRun,
+			data.RuleCheck2WithInstance1AndHandler,
+		},
+		ExpectedTable: `
+[Routing ExpectedTable]
+ID: 1
+[#0] TEMPLATE_VARIETY_CHECK {V}
+  [#0] istio-system {NS}
+    [#0] hcheck1.acheck.istio-system {H}
+      [#0]
+        Condition: <NONE>
+        [#0] icheck1.tcheck.istio-system {I}
+      [#1]
+        Condition: <NONE>
+        DryRun: rcheck1.rule.istio-system
+        [#0] icheck1.tcheck.istio-system {I}
+`,
+	},
+
 	{
 		Name:          "multiple-instances-report",
 		ServiceConfig: data.ServiceConfig,
@@ -637,3 +663,57 @@ func (h nonPointerHandler) Close() error {
 	h.fn()
 	return nil
 }
+
+func TestRebuildTable(t *testing.T) {
+	templates := data.BuildTemplates(nil)
+	adapters := data.BuildAdapters(nil)
+
+	build := func(previous *Table, configs ...string) *Table {
+		s := util.GetSnapshot(templates, adapters, data.ServiceConfig, data.JoinConfigs(configs...))
+		ht := handler.NewTable(handler.Empty(), s, nil)
+		return RebuildTable(previous, ht, s, compiled.NewBuilder(s.Attributes), "istio-system", false)
+	}
+
+	t1 := build(nil, data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1WithMatchClause)
+	i1 := t1.artifacts.instances[data.FqnI1]
+	e1 := t1.artifacts.expressions[`match(destination.name, "foo*")`]
+	if i1 == nil || e1 == nil {
+		t.Fatalf("missing compiled artifacts: %+v", t1.artifacts)
+	}
+
+	// Unchanged config: both the instance and the match expression are reused.
+	t2 := build(t1, data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1WithMatchClause)
+	if t2.artifacts.instances[data.FqnI1] != i1 {
+		t.Fatal("instance was recompiled for unchanged config")
+	}
+	if t2.artifacts.expressions[`match(destination.name, "foo*")`] != e1 {
+		t.Fatal("match expression was recompiled for unchanged config")
+	}
+
+	// Changed instance: the instance is recompiled, the match expression is still reused.
+	compiledBefore := namespaceCompilations(t, "istio-system")
+	t3 := build(t2, data.HandlerACheck1, data.InstanceCheck1WithSpec, data.RuleCheck1WithMatchClause)
+	if i3 := t3.artifacts.instances[data.FqnI1]; i3 == nil || i3 == i1 {
+		t.Fatal("instance was not recompiled after changing")
+	}
+	if t3.artifacts.expressions[`match(destination.name, "foo*")`] != e1 {
+		t.Fatal("match expression was recompiled for unchanged rule")
+	}
+	if got := namespaceCompilations(t, "istio-system") - compiledBefore; got != 1 {
+		t.Fatalf("compilations reported for the namespace: %v, want 1", got)
+	}
+
+	// The tables built incrementally are the same as the ones built from scratch.
+	if normalize(t3.String()) != normalize(build(nil, data.HandlerACheck1, data.InstanceCheck1WithSpec,
+		data.RuleCheck1WithMatchClause).String()) {
+		t.Fatalf("incrementally built table differs:\n%s", t3)
+	}
+}
+
+func namespaceCompilations(t *testing.T, ns string) float64 {
+	m := &dto.Metric{}
+	if err := namespaceCompilationCount.WithLabelValues(ns).Write(m); err != nil {
+		t.Fatalf("unable to read the namespace compilation count: %v", err)
+	}
+	return m.GetCounter().GetValue()
+}
@@ -0,0 +1,128 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	tpb "istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/attribute"
+	"istio.io/istio/mixer/pkg/runtime/config"
+)
+
+// DestinationEvaluation is the result of evaluating an attribute bag against the instance groups of a destination.
+type DestinationEvaluation struct {
+	Handler  string             `json:"handler"`
+	Adapter  string             `json:"adapter"`
+	Template string             `json:"template"`
+	Groups   []*GroupEvaluation `json:"groups"`
+}
+
+// GroupEvaluation is the result of evaluating an attribute bag against an instance group.
+type GroupEvaluation struct {
+	// Match clause of the group, empty if the group applies unconditionally.
+	Match string `json:"match,omitempty"`
+
+	// Matched indicates whether the instances of the group would be dispatched for the bag.
+	Matched bool `json:"matched"`
+
+	// Error evaluating the match clause, if any.
+	Error string `json:"error,omitempty"`
+
+	// DryRunRule is the name of the rule the group belongs to, if it is in dry-run mode.
+	DryRunRule string `json:"dryRunRule,omitempty"`
+
+	// Instances built from the bag. Only present for matched groups.
+	Instances []*InstanceEvaluation `json:"instances,omitempty"`
+}
+
+// InstanceEvaluation is an instance built from an attribute bag.
+type InstanceEvaluation struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// Evaluate evaluates the attribute bag against the destinations of the given variety and namespace, the same way a
+// dispatch would, but without calling any handler. Instances are built for the groups that match.
+func (t *Table) Evaluate(variety tpb.TemplateVariety, namespace string, bag attribute.Bag) []*DestinationEvaluation {
+	// TODO(Issue #2139): This is for old-style metadata based policy decisions. This should be eventually removed.
+	ctxProtocol, _ := bag.Get(config.ContextProtocolAttributeName)
+	tcp := ctxProtocol == config.ContextProtocolTCP
+
+	destinations := t.GetDestinations(variety, namespace)
+
+	result := make([]*DestinationEvaluation, 0, destinations.Count())
+	for _, destination := range destinations.Entries() {
+		d := &DestinationEvaluation{
+			Handler:  destination.HandlerName,
+			Adapter:  destination.AdapterName,
+			Template: destination.Template.Name,
+			Groups:   make([]*GroupEvaluation, 0, len(destination.InstanceGroups)),
+		}
+
+		for _, group := range destination.InstanceGroups {
+			d.Groups = append(d.Groups, t.evaluateGroup(group, bag, tcp))
+		}
+
+		result = append(result, d)
+	}
+
+	return result
+}
+
+func (t *Table) evaluateGroup(group *InstanceGroup, bag attribute.Bag, tcp bool) *GroupEvaluation {
+	var names []string
+	g := &GroupEvaluation{DryRunRule: group.RuleName}
+	if t.groupInfo != nil {
+		g.Match = t.groupInfo.matchesByID[group.id]
+		names = t.groupInfo.instanceNamesByID[group.id]
+	}
+
+	if group.ResourceType.IsTCP() != tcp {
+		return g
+	}
+
+	g.Matched = true
+	if group.Condition != nil {
+		matched, err := group.Condition.EvaluateBoolean(bag)
+		if err != nil {
+			g.Matched = false
+			g.Error = err.Error()
+			return g
+		}
+		g.Matched = matched
+	}
+
+	if !g.Matched {
+		return g
+	}
+
+	for i, build := range group.Builders {
+		instance := &InstanceEvaluation{}
+		if i < len(names) {
+			instance.Name = names[i]
+		}
+
+		value, err := build(bag)
+		if err != nil {
+			instance.Error = err.Error()
+		} else {
+			instance.Value = value
+		}
+
+		g.Instances = append(g.Instances, instance)
+	}
+
+	return g
+}
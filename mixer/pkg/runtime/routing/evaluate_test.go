@@ -0,0 +1,79 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"testing"
+
+	tpb "istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/attribute"
+	"istio.io/istio/mixer/pkg/runtime/testing/data"
+)
+
+func TestEvaluate(t *testing.T) {
+	// Evaluation does not depend on the debug info of the table.
+	table, _ := buildTable(data.ServiceConfig, []string{
+		data.HandlerACheck1,
+		data.InstanceCheck1,
+		data.RuleCheck1WithMatchClause,
+	}, false)
+
+	cases := []struct {
+		name      string
+		attrs     map[string]interface{}
+		matched   bool
+		err       bool
+		instances int
+	}{
+		{"match", map[string]interface{}{"destination.name": "foobar"}, true, false, 1},
+		{"no match", map[string]interface{}{"destination.name": "barfoo"}, false, false, 0},
+		{"error", map[string]interface{}{}, false, true, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			bag := attribute.GetFakeMutableBagForTesting(c.attrs)
+			result := table.Evaluate(tpb.TEMPLATE_VARIETY_CHECK, "istio-system", bag)
+
+			if len(result) != 1 {
+				t.Fatalf("Evaluate() => %d destinations, want 1", len(result))
+			}
+			d := result[0]
+			if d.Handler != "hcheck1.acheck.istio-system" || d.Adapter != "acheck" || d.Template != "tcheck" {
+				t.Fatalf("unexpected destination: %+v", d)
+			}
+			if len(d.Groups) != 1 {
+				t.Fatalf("Evaluate() => %d groups, want 1", len(d.Groups))
+			}
+
+			g := d.Groups[0]
+			if g.Match != `match(destination.name, "foo*")` {
+				t.Errorf("Match = %q", g.Match)
+			}
+			if g.Matched != c.matched || (g.Error != "") != c.err || len(g.Instances) != c.instances {
+				t.Fatalf("unexpected group evaluation: %+v", g)
+			}
+			for _, i := range g.Instances {
+				if i.Name != "icheck1.tcheck.istio-system" || i.Value == nil || i.Error != "" {
+					t.Errorf("unexpected instance: %+v", i)
+				}
+			}
+		})
+	}
+
+	if got := table.Evaluate(tpb.TEMPLATE_VARIETY_REPORT, "istio-system", attribute.GetFakeMutableBagForTesting(nil)); len(got) != 0 {
+		t.Errorf("Evaluate() => %v, want no report destinations", got)
+	}
+}
@@ -15,6 +15,7 @@
 package routing
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -25,6 +26,9 @@ const (
 	handlerName  = "handler"
 	adapterName  = "adapter"
 	errorStr     = "error"
+	namespace    = "namespace"
+	reused       = "reused"
+	reusedTable  = "reused_table"
 )
 
 var (
@@ -47,11 +51,54 @@ var (
 			Help:      "Histogram of durations for adapter dispatches handled by Mixer.",
 			Buckets:   durationBuckets,
 		}, dispatchLabelNames)
+
+	buildDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "mixer",
+			Subsystem: "runtime",
+			Name:      "routing_table_build_duration",
+			Help:      "Histogram of durations for building routing tables, by whether the compilations of the previous table were reused.",
+			Buckets:   durationBuckets,
+		}, []string{reusedTable})
+
+	compilationCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "mixer",
+			Subsystem: "runtime",
+			Name:      "routing_compilation_count",
+			Help:      "Total number of instances and match expressions compiled, or reused from the previous routing table.",
+		}, []string{reused})
+
+	namespaceCompilationCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "mixer",
+			Subsystem: "runtime",
+			Name:      "routing_namespace_compilation_count",
+			Help:      "Total number of instances and match expressions compiled for the rules of a namespace.",
+		}, []string{namespace})
 )
 
 func init() {
 	prometheus.MustRegister(dispatchCount)
 	prometheus.MustRegister(dispatchDuration)
+	prometheus.MustRegister(buildDuration)
+	prometheus.MustRegister(compilationCount)
+	prometheus.MustRegister(namespaceCompilationCount)
+}
+
+// updateBuildCounters records the build of a routing table. ReusedPrevious indicates whether the compilations of
+// the previous table were available for reuse. CompiledByNamespace is the number of compilations required by the
+// rules of each namespace.
+func updateBuildCounters(reusedPrevious bool, duration time.Duration, compiledByNamespace map[string]int, reusedCount int) {
+	compiled := 0
+	for ns, count := range compiledByNamespace {
+		namespaceCompilationCount.With(prometheus.Labels{namespace: ns}).Add(float64(count))
+		compiled += count
+	}
+
+	buildDuration.With(prometheus.Labels{reusedTable: strconv.FormatBool(reusedPrevious)}).Observe(duration.Seconds())
+	compilationCount.With(prometheus.Labels{reused: "false"}).Add(float64(compiled))
+	compilationCount.With(prometheus.Labels{reused: "true"}).Add(float64(reusedCount))
 }
 
 // DestinationCounters are used to track the total/failed dispatch counts and dispatch duration for a target destination,
@@ -103,6 +103,9 @@ func (d *NamespaceTable) write(w io.Writer, indent int, debugInfo *tableDebugInf
 			sort.SliceStable(inputs, func(i int, j int) bool {
 				iMatch := debugInfo.matchesByID[inputs[i].id]
 				jMatch := debugInfo.matchesByID[inputs[j].id]
+				if iMatch == jMatch {
+					return inputs[i].RuleName < inputs[j].RuleName
+				}
 				return iMatch < jMatch
 			})
 		}
@@ -136,6 +139,11 @@ func (i *InstanceGroup) write(w io.Writer, indent int, debugInfo *tableDebugInfo
 	}
 	fmt.Fprintln(w)
 
+	if i.DryRun {
+		fmt.Fprintf(w, "%sDryRun: %s", idnt, i.RuleName)
+		fmt.Fprintln(w)
+	}
+
 	if debugInfo != nil {
 		// Copy and stable sort the input instance names, based on match clause text.
 		instanceNames := make([]string, len(i.Builders))
@@ -35,6 +35,12 @@ type Table struct {
 	entries map[tpb.TemplateVariety]*varietyTable
 
 	debugInfo *tableDebugInfo
+
+	// match clauses and instance names of the instance groups, always kept for evaluating attribute bags.
+	groupInfo *tableDebugInfo
+
+	// compiled instances and expressions, reused when the table gets rebuilt.
+	artifacts *artifacts
 }
 
 // varietyTable contains destination sets for a given template variety. It contains a mapping from namespaces
@@ -83,6 +89,13 @@ type Destination struct {
 
 	// Perf counters for keeping track of dispatches to adapters/handlers.
 	Counters DestinationCounters
+
+	// CheckCacheDisabled indicates that the check results of the handler must not be cached.
+	CheckCacheDisabled bool
+
+	// ReportQueueFull is the policy applied when the report queue of the handler is full. Empty for the server
+	// default.
+	ReportQueueFull string
 }
 
 // InstanceGroup is a set of instances that needs to be sent to a handler, grouped by a condition expression.
@@ -102,6 +115,13 @@ type InstanceGroup struct {
 
 	// Mappers for attribute-generating adapters that map output attributes into the main attribute set.
 	Mappers []template.OutputMapperFn
+
+	// DryRun indicates that the group belongs to a dry-run rule. The results of the dispatches for the group
+	// are only logged and counted, and never returned to the caller.
+	DryRun bool
+
+	// RuleName is the name of the dry-run rule the group belongs to. Used for monitoring/logging purposes.
+	RuleName string
 }
 
 var emptyTable = &Table{id: -1}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/attribute"
 	"istio.io/istio/mixer/pkg/config/store"
 	"istio.io/istio/mixer/pkg/lang/compiled"
 	"istio.io/istio/mixer/pkg/pool"
@@ -61,6 +62,8 @@ type Runtime struct {
 
 	handlers *handler.Table
 
+	routes *routing.Table
+
 	dispatcher *dispatcher.Impl
 
 	store store.Store
@@ -110,6 +113,28 @@ func (c *Runtime) Dispatcher() dispatcher.Dispatcher {
 	return c.dispatcher
 }
 
+// EnableReportQueues makes the dispatcher queue report instances and dispatch them to the handlers asynchronously,
+// in batches. It must be called before any request is dispatched.
+func (c *Runtime) EnableReportQueues(opts dispatcher.ReportQueueOptions) error {
+	return c.dispatcher.EnableReportQueues(opts)
+}
+
+// StopReportQueues waits until the queued report instances are dispatched, and makes further reports synchronous.
+func (c *Runtime) StopReportQueues() {
+	c.dispatcher.StopReportQueues()
+}
+
+// EnableCheckCache makes the dispatcher cache check results, keyed by the values of the attributes referenced to
+// produce them. It must be called before any request is dispatched.
+func (c *Runtime) EnableCheckCache(opts dispatcher.CheckCacheOptions) error {
+	return c.dispatcher.EnableCheckCache(opts)
+}
+
+// Evaluate evaluates the attribute bag against the current configuration, without dispatching to any handler.
+func (c *Runtime) Evaluate(bag attribute.Bag) (*dispatcher.Evaluation, error) {
+	return c.dispatcher.Evaluate(bag)
+}
+
 // StartListening directs Runtime to start listening to configuration changes. As config changes, runtime processes
 // the confguration and creates a dispatcher.
 func (c *Runtime) StartListening() error {
@@ -173,12 +198,13 @@ func (c *Runtime) processNewConfig() {
 	newHandlers := handler.NewTable(oldHandlers, newSnapshot, c.handlerPool)
 
 	builder := compiled.NewBuilder(newSnapshot.Attributes)
-	newRoutes := routing.BuildTable(
-		newHandlers, newSnapshot, builder, c.defaultConfigNamespace, log.DebugEnabled())
+	newRoutes := routing.RebuildTable(
+		c.routes, newHandlers, newSnapshot, builder, c.defaultConfigNamespace, log.DebugEnabled())
 
 	oldContext := c.dispatcher.ChangeRoute(newRoutes)
 
 	c.handlers = newHandlers
+	c.routes = newRoutes
 	c.snapshot = newSnapshot
 
 	log.Debugf("New routes in effect:\n%s", newRoutes)
@@ -319,6 +319,22 @@ spec:
     - icheck2.tcheck.istio-system
 `
 
+// RuleCheck1DryRun is Rule Check1 in dry-run mode.
+var RuleCheck1DryRun = `
+apiVersion: "config.istio.io/v1alpha2"
+kind: rule
+metadata:
+  name: rcheck1
+  namespace: istio-system
+  labels:
+    istio-dry-run: "true"
+spec:
+  actions:
+  - handler: hcheck1.acheck
+    instances:
+    - icheck1.tcheck.istio-system
+`
+
 // RuleCheck1WithMatchClause is Rule Check1 with a conditional.
 var RuleCheck1WithMatchClause = `
 apiVersion: "config.istio.io/v1alpha2"
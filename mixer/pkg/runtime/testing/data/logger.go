@@ -17,17 +17,21 @@ package data
 import (
 	"bytes"
 	"fmt"
+	"sync"
 )
 
 // Logger is used to capture the events that happen within fake adapters & templates during testing.
 type Logger struct {
-	b bytes.Buffer
+	mu sync.Mutex
+	b  bytes.Buffer
 }
 
 // Write s to the log, with a prefix of name. A newline character is added.
 func (l *Logger) Write(name string, s string) {
 	if l != nil {
+		l.mu.Lock()
 		fmt.Fprintf(&l.b, "[%s] %s\n", name, s)
+		l.mu.Unlock()
 	}
 }
 
@@ -42,7 +46,9 @@ func (l *Logger) WriteFormat(name string, format string, args ...interface{}) {
 // Clear the contents of this logger. Useful for reducing the event output to Write more readable tests.
 func (l *Logger) Clear() {
 	if l != nil {
+		l.mu.Lock()
 		l.b.Reset()
+		l.mu.Unlock()
 	}
 }
 
@@ -52,5 +58,7 @@ func (l *Logger) String() string {
 		return ""
 	}
 
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	return l.b.String()
 }
@@ -17,6 +17,8 @@ package server
 import (
 	"bytes"
 	"fmt"
+	"math"
+	"time"
 
 	"istio.io/istio/mixer/pkg/adapter"
 	"istio.io/istio/mixer/pkg/config/store"
@@ -94,6 +96,25 @@ type Args struct {
 
 	// If true, each request to Mixer will be executed in a single go routine (useful for debugging)
 	SingleThreaded bool
+
+	// Maximum number of report instances queued per handler. If 0, reports are dispatched synchronously.
+	// Preprocessing always runs synchronously, only the dispatch to handlers is queued.
+	ReportQueueSize int
+
+	// Number of queued report instances that triggers a dispatch to the handler
+	ReportBatchSize int
+
+	// Maximum amount of time report instances stay queued before being dispatched
+	ReportFlushInterval time.Duration
+
+	// If true, report instances are dropped when the queue of a handler is full, instead of blocking the report
+	ReportDropWhenFull bool
+
+	// Maximum number of check results cached. If 0, check results are not cached.
+	CheckCacheSize int
+
+	// Maximum amount of time check results stay cached, regardless of the valid duration returned by the adapters
+	CheckCacheMaxValidDuration time.Duration
 }
 
 // DefaultArgs allocates an Args struct initialized with Mixer's default configuration.
@@ -113,6 +134,9 @@ func DefaultArgs() *Args {
 		LivenessProbeOptions:          &probe.Options{},
 		ReadinessProbeOptions:         &probe.Options{},
 		EnableProfiling:               true,
+		ReportBatchSize:               100,
+		ReportFlushInterval:           time.Second,
+		CheckCacheMaxValidDuration:    time.Minute,
 	}
 }
 
@@ -125,6 +149,14 @@ func (a *Args) validate() error {
 		return fmt.Errorf("adapter worker pool size must be >= 0 and <= 2^31-1, got pool size %d", a.AdapterWorkerPoolSize)
 	}
 
+	if a.ReportQueueSize < 0 {
+		return fmt.Errorf("report queue size must be >= 0, got queue size %d", a.ReportQueueSize)
+	}
+
+	if a.CheckCacheSize < 0 || a.CheckCacheSize > math.MaxInt32 {
+		return fmt.Errorf("check cache size must be >= 0 and <= 2^31-1, got cache size %d", a.CheckCacheSize)
+	}
+
 	return nil
 }
 
@@ -140,6 +172,12 @@ func (a *Args) String() string {
 	fmt.Fprint(buf, "MonitoringPort: ", a.MonitoringPort, "\n")
 	fmt.Fprint(buf, "EnableProfiling: ", a.EnableProfiling, "\n")
 	fmt.Fprint(buf, "SingleThreaded: ", a.SingleThreaded, "\n")
+	fmt.Fprint(buf, "ReportQueueSize: ", a.ReportQueueSize, "\n")
+	fmt.Fprint(buf, "ReportBatchSize: ", a.ReportBatchSize, "\n")
+	fmt.Fprint(buf, "ReportFlushInterval: ", a.ReportFlushInterval, "\n")
+	fmt.Fprint(buf, "ReportDropWhenFull: ", a.ReportDropWhenFull, "\n")
+	fmt.Fprint(buf, "CheckCacheSize: ", a.CheckCacheSize, "\n")
+	fmt.Fprint(buf, "CheckCacheMaxValidDuration: ", a.CheckCacheMaxValidDuration, "\n")
 	fmt.Fprint(buf, "ConfigStoreURL: ", a.ConfigStoreURL, "\n")
 	fmt.Fprint(buf, "ConfigDefaultNamespace: ", a.ConfigDefaultNamespace, "\n")
 	fmt.Fprint(buf, "ConfigIdentityAttribute: ", a.ConfigIdentityAttribute, "\n")
@@ -0,0 +1,84 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	mixerpb "istio.io/api/mixer/v1"
+	"istio.io/istio/mixer/pkg/attribute"
+	"istio.io/istio/mixer/pkg/runtime/dispatcher"
+	"istio.io/istio/pkg/log"
+)
+
+const (
+	evaluatePath = "/debug/evaluate"
+
+	// maxEvaluateRequestSize is the maximum size of the attributes accepted by the evaluate endpoint.
+	maxEvaluateRequestSize = 1024 * 1024
+)
+
+// evaluator evaluates attribute bags against the current configuration.
+type evaluator interface {
+	Evaluate(bag attribute.Bag) (*dispatcher.Evaluation, error)
+}
+
+// newEvaluateHandler returns a handler that evaluates the attributes POSTed to it, as a CompressedAttributes message
+// in binary protobuf format, and responds with the evaluation as JSON.
+func newEvaluateHandler(e evaluator) http.Handler {
+	list := attribute.GlobalList()
+	globalDict := make(map[string]int32, len(list))
+	for i := 0; i < len(list); i++ {
+		globalDict[list[i]] = int32(i)
+	}
+
+	return http.HandlerFunc(func(out http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(out, "attributes must be POSTed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxEvaluateRequestSize))
+		if err != nil {
+			http.Error(out, "unable to read the attributes: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var attrs mixerpb.CompressedAttributes
+		if err = attrs.Unmarshal(body); err != nil {
+			http.Error(out, "unable to decode the attributes: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		bag := attribute.NewProtoBag(&attrs, globalDict, list)
+		defer bag.Done()
+
+		evaluation, err := e.Evaluate(bag)
+		if err != nil {
+			http.Error(out, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		out.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(evaluation); err != nil {
+			log.Errorf("Unable to write evaluation: %v", err)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mixerpb "istio.io/api/mixer/v1"
+	"istio.io/istio/mixer/pkg/attribute"
+	"istio.io/istio/mixer/pkg/runtime/dispatcher"
+)
+
+type fakeEvaluator struct{}
+
+func (f *fakeEvaluator) Evaluate(bag attribute.Bag) (*dispatcher.Evaluation, error) {
+	v, found := bag.Get("destination.service")
+	if !found {
+		return nil, errors.New("identity parameter not found: 'destination.service'")
+	}
+	return &dispatcher.Evaluation{Namespace: v.(string)}, nil
+}
+
+func TestEvaluateHandler(t *testing.T) {
+	b := attribute.GetMutableBag(nil)
+	b.Set("destination.service", "svc.ns")
+	var withIdentity mixerpb.CompressedAttributes
+	b.ToProto(&withIdentity, nil, 0)
+	withIdentityBytes, _ := withIdentity.Marshal()
+
+	empty, _ := (&mixerpb.CompressedAttributes{}).Marshal()
+
+	cases := []struct {
+		name   string
+		method string
+		body   []byte
+		status int
+		ns     string
+	}{
+		{"evaluate", http.MethodPost, withIdentityBytes, http.StatusOK, "svc.ns"},
+		{"no identity", http.MethodPost, empty, http.StatusBadRequest, ""},
+		{"bad body", http.MethodPost, []byte{0xff, 0xff}, http.StatusBadRequest, ""},
+		{"get", http.MethodGet, nil, http.StatusMethodNotAllowed, ""},
+	}
+
+	h := newEvaluateHandler(&fakeEvaluator{})
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(c.method, evaluatePath, bytes.NewReader(c.body))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != c.status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, c.status, rec.Body.String())
+			}
+			if c.status != http.StatusOK {
+				return
+			}
+
+			var e dispatcher.Evaluation
+			if err := json.Unmarshal(rec.Body.Bytes(), &e); err != nil {
+				t.Fatalf("unable to decode the evaluation: %v", err)
+			}
+			if e.Namespace != c.ns {
+				t.Errorf("namespace %q, want %q", e.Namespace, c.ns)
+			}
+		})
+	}
+}
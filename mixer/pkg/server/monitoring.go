@@ -29,6 +29,7 @@ import (
 
 type monitor struct {
 	monitoringServer *http.Server
+	mux              *http.ServeMux
 	// This channel is closed after the server stops serving requests.
 	closed chan struct{}
 }
@@ -70,6 +71,7 @@ func startMonitor(port uint16, enableProfiling bool, lf listenFunc) (*monitor, e
 		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 	}
 
+	m.mux = mux
 	m.monitoringServer = &http.Server{
 		Handler: mux,
 	}
@@ -50,6 +50,7 @@ type Server struct {
 	tracer    io.Closer
 
 	dispatcher dispatcher.Dispatcher
+	rt         *runtime.Runtime
 
 	// probes
 	livenessProbe  probe.Controller
@@ -173,12 +174,38 @@ func newServer(a *Args, p *patchTable) (*Server, error) {
 
 	rt = p.newRuntime(st, templateMap, adapterMap, a.ConfigIdentityAttribute, a.ConfigDefaultNamespace,
 		s.gp, s.adapterGP, a.TracingOptions.TracingEnabled())
+	s.rt = rt
+
+	if a.ReportQueueSize > 0 {
+		err = rt.EnableReportQueues(dispatcher.ReportQueueOptions{
+			QueueSize:     a.ReportQueueSize,
+			BatchSize:     a.ReportBatchSize,
+			FlushInterval: a.ReportFlushInterval,
+			DropWhenFull:  a.ReportDropWhenFull,
+		})
+		if err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("unable to enable report queues: %v", err)
+		}
+	}
+
+	if a.CheckCacheSize > 0 {
+		err = rt.EnableCheckCache(dispatcher.CheckCacheOptions{
+			MaxEntries:       int32(a.CheckCacheSize),
+			MaxValidDuration: a.CheckCacheMaxValidDuration,
+		})
+		if err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("unable to enable check cache: %v", err)
+		}
+	}
 
 	if err = p.runtimeListen(rt); err != nil {
 		_ = s.Close()
 		return nil, fmt.Errorf("unable to listen: %v", err)
 	}
 	s.dispatcher = rt.Dispatcher()
+	s.monitor.mux.Handle(evaluatePath, newEvaluateHandler(rt))
 
 	// get the grpc server wired up
 	grpc.EnableTracing = a.EnableGRPCTracing
@@ -232,6 +259,11 @@ func (s *Server) Close() error {
 		_ = s.Wait()
 	}
 
+	if s.rt != nil {
+		// flush the queued reports before the adapters go away.
+		s.rt.StopReportQueues()
+	}
+
 	if s.listener != nil {
 		_ = s.listener.Close()
 	}
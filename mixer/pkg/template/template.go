@@ -173,6 +173,11 @@ type (
 		BuilderSupportsTemplate BuilderSupportsTemplateFn
 		HandlerSupportsTemplate HandlerSupportsTemplateFn
 
+		// RemoteHandleMethod and RemoteHandleRequest name the gRPC method and request message of the
+		// generated Handle<Template>Service, used to dispatch instances to out-of-process adapters.
+		RemoteHandleMethod  string
+		RemoteHandleRequest string
+
 		AttributeManifests []*pb.AttributeManifest
 
 		DispatchReport   DispatchReportFn
@@ -184,6 +189,32 @@ type (
 		CreateOutputExpressions CreateOutputExpressionsFn
 	}
 
+	// RemoteHandlerBuilder is implemented by builders of handlers that run outside of Mixer. Instead of
+	// the template specific HandlerBuilder interfaces, they are given the templates of the instances
+	// they receive.
+	RemoteHandlerBuilder interface {
+		adapter.HandlerBuilder
+
+		// SetRemoteTemplates sets the templates of the instances dispatched to the handler.
+		SetRemoteTemplates(templates []*Info)
+	}
+
+	// RemoteHandler is implemented by handlers that forward instances to out-of-process adapters,
+	// through the gRPC service generated for each template. Instances of any template are dispatched
+	// to a RemoteHandler, regardless of the template specific Handler interfaces.
+	RemoteHandler interface {
+		adapter.Handler
+
+		// HandleRemoteCheck dispatches a check instance of the template.
+		HandleRemoteCheck(ctx context.Context, info *Info, instance interface{}) (adapter.CheckResult, error)
+
+		// HandleRemoteReport dispatches report instances of the template.
+		HandleRemoteReport(ctx context.Context, info *Info, instances []interface{}) error
+
+		// HandleRemoteQuota dispatches a quota instance of the template.
+		HandleRemoteQuota(ctx context.Context, info *Info, instance interface{}, args adapter.QuotaArgs) (adapter.QuotaResult, error)
+	}
+
 	// templateRepo implements Repository
 	repo struct {
 		info map[string]Info
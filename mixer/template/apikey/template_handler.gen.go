@@ -28,6 +28,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "apikey"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'apikey' instances.
+const RemoteHandleMethod = "/apikey.HandleApiKeyService/HandleApiKey"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "apikey.HandleApiKeyRequest"
+
 // Instance is constructed by Mixer for the 'apikey' template.
 //
 // The `apikey` template represents a single API key, used to authorize API calls.
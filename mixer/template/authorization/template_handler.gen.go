@@ -28,6 +28,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "authorization"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'authorization' instances.
+const RemoteHandleMethod = "/authorization.HandleAuthorizationService/HandleAuthorization"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "authorization.HandleAuthorizationRequest"
+
 // Instance is constructed by Mixer for the 'authorization' template.
 //
 // The `authorization` template defines parameters for performing policy
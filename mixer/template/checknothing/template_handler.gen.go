@@ -28,6 +28,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "checknothing"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'checknothing' instances.
+const RemoteHandleMethod = "/checknothing.HandleCheckNothingService/HandleCheckNothing"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "checknothing.HandleCheckNothingRequest"
+
 // Instance is constructed by Mixer for the 'checknothing' template.
 //
 // CheckNothing represents an empty block of data that is used for Check-capable
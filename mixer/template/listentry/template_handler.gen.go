@@ -28,6 +28,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "listentry"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'listentry' instances.
+const RemoteHandleMethod = "/listentry.HandleListEntryService/HandleListEntry"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "listentry.HandleListEntryRequest"
+
 // Instance is constructed by Mixer for the 'listentry' template.
 //
 // The `listentry` template is used to verify the presence/absence of a string
@@ -28,6 +28,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "logentry"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'logentry' instances.
+const RemoteHandleMethod = "/logentry.HandleLogEntryService/HandleLogEntry"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "logentry.HandleLogEntryRequest"
+
 // Instance is constructed by Mixer for the 'logentry' template.
 //
 // The `logentry` template represents an individual entry within a log.
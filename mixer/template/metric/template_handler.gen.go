@@ -28,6 +28,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "metric"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'metric' instances.
+const RemoteHandleMethod = "/metric.HandleMetricService/HandleMetric"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "metric.HandleMetricRequest"
+
 // Instance is constructed by Mixer for the 'metric' template.
 //
 // The `metric` template represents a single piece of data to report.
@@ -27,6 +27,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "quota"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'quota' instances.
+const RemoteHandleMethod = "/quota.HandleQuotaService/HandleQuota"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "quota.HandleQuotaRequest"
+
 // Instance is constructed by Mixer for the 'quota' template.
 //
 // The `quota` template represents a piece of data to check Quota for.
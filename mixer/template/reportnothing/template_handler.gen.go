@@ -28,6 +28,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "reportnothing"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'reportnothing' instances.
+const RemoteHandleMethod = "/reportnothing.HandleReportNothingService/HandleReportNothing"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "reportnothing.HandleReportNothingRequest"
+
 // Instance is constructed by Mixer for the 'reportnothing' template.
 //
 // ReportNothing represents an empty block of data that is used for Report-capable
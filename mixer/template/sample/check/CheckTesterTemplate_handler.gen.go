@@ -26,6 +26,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "check"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'check' instances.
+const RemoteHandleMethod = "/istio.mixer.adapter.sample.check.HandleCheckService/HandleCheck"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "istio.mixer.adapter.sample.check.HandleCheckRequest"
+
 // Instance is constructed by Mixer for the 'check' template.
 type Instance struct {
 	// Name of the instance as specified in configuration.
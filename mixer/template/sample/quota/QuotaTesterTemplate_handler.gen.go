@@ -26,6 +26,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "quota"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'quota' instances.
+const RemoteHandleMethod = "/istio.mixer.adapter.sample.quota.HandleQuotaService/HandleQuota"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "istio.mixer.adapter.sample.quota.HandleQuotaRequest"
+
 // Instance is constructed by Mixer for the 'quota' template.
 type Instance struct {
 	// Name of the instance as specified in configuration.
@@ -27,6 +27,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "report"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'report' instances.
+const RemoteHandleMethod = "/istio.mixer.adapter.sample.report.HandleReportService/HandleReport"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "istio.mixer.adapter.sample.report.HandleReportRequest"
+
 // Instance is constructed by Mixer for the 'report' template.
 type Instance struct {
 	// Name of the instance as specified in configuration.
@@ -567,12 +567,14 @@ var (
 		},
 
 		istio_mixer_adapter_sample_check.TemplateName: {
-			Name:               istio_mixer_adapter_sample_check.TemplateName,
-			Impl:               "istio.mixer.adapter.sample.check",
-			CtrCfg:             &istio_mixer_adapter_sample_check.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
-			BldrInterfaceName:  istio_mixer_adapter_sample_check.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: istio_mixer_adapter_sample_check.TemplateName + "." + "Handler",
+			Name:                istio_mixer_adapter_sample_check.TemplateName,
+			Impl:                "istio.mixer.adapter.sample.check",
+			CtrCfg:              &istio_mixer_adapter_sample_check.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
+			BldrInterfaceName:   istio_mixer_adapter_sample_check.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  istio_mixer_adapter_sample_check.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  istio_mixer_adapter_sample_check.RemoteHandleMethod,
+			RemoteHandleRequest: istio_mixer_adapter_sample_check.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(istio_mixer_adapter_sample_check.HandlerBuilder)
 				return ok
@@ -855,12 +857,14 @@ var (
 		},
 
 		istio_mixer_adapter_sample_quota.TemplateName: {
-			Name:               istio_mixer_adapter_sample_quota.TemplateName,
-			Impl:               "istio.mixer.adapter.sample.quota",
-			CtrCfg:             &istio_mixer_adapter_sample_quota.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_QUOTA,
-			BldrInterfaceName:  istio_mixer_adapter_sample_quota.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: istio_mixer_adapter_sample_quota.TemplateName + "." + "Handler",
+			Name:                istio_mixer_adapter_sample_quota.TemplateName,
+			Impl:                "istio.mixer.adapter.sample.quota",
+			CtrCfg:              &istio_mixer_adapter_sample_quota.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_QUOTA,
+			BldrInterfaceName:   istio_mixer_adapter_sample_quota.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  istio_mixer_adapter_sample_quota.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  istio_mixer_adapter_sample_quota.RemoteHandleMethod,
+			RemoteHandleRequest: istio_mixer_adapter_sample_quota.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(istio_mixer_adapter_sample_quota.HandlerBuilder)
 				return ok
@@ -1144,12 +1148,14 @@ var (
 		},
 
 		istio_mixer_adapter_sample_report.TemplateName: {
-			Name:               istio_mixer_adapter_sample_report.TemplateName,
-			Impl:               "istio.mixer.adapter.sample.report",
-			CtrCfg:             &istio_mixer_adapter_sample_report.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
-			BldrInterfaceName:  istio_mixer_adapter_sample_report.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: istio_mixer_adapter_sample_report.TemplateName + "." + "Handler",
+			Name:                istio_mixer_adapter_sample_report.TemplateName,
+			Impl:                "istio.mixer.adapter.sample.report",
+			CtrCfg:              &istio_mixer_adapter_sample_report.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
+			BldrInterfaceName:   istio_mixer_adapter_sample_report.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  istio_mixer_adapter_sample_report.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  istio_mixer_adapter_sample_report.RemoteHandleMethod,
+			RemoteHandleRequest: istio_mixer_adapter_sample_report.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(istio_mixer_adapter_sample_report.HandlerBuilder)
 				return ok
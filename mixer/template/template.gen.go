@@ -513,12 +513,14 @@ var (
 		},
 
 		servicecontrolreport.TemplateName: {
-			Name:               servicecontrolreport.TemplateName,
-			Impl:               "servicecontrolreport",
-			CtrCfg:             &servicecontrolreport.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
-			BldrInterfaceName:  servicecontrolreport.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: servicecontrolreport.TemplateName + "." + "Handler",
+			Name:                servicecontrolreport.TemplateName,
+			Impl:                "servicecontrolreport",
+			CtrCfg:              &servicecontrolreport.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
+			BldrInterfaceName:   servicecontrolreport.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  servicecontrolreport.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  servicecontrolreport.RemoteHandleMethod,
+			RemoteHandleRequest: servicecontrolreport.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(servicecontrolreport.HandlerBuilder)
 				return ok
@@ -736,12 +738,14 @@ var (
 		},
 
 		apikey.TemplateName: {
-			Name:               apikey.TemplateName,
-			Impl:               "apikey",
-			CtrCfg:             &apikey.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
-			BldrInterfaceName:  apikey.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: apikey.TemplateName + "." + "Handler",
+			Name:                apikey.TemplateName,
+			Impl:                "apikey",
+			CtrCfg:              &apikey.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
+			BldrInterfaceName:   apikey.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  apikey.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  apikey.RemoteHandleMethod,
+			RemoteHandleRequest: apikey.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(apikey.HandlerBuilder)
 				return ok
@@ -881,12 +885,14 @@ var (
 		},
 
 		authorization.TemplateName: {
-			Name:               authorization.TemplateName,
-			Impl:               "authorization",
-			CtrCfg:             &authorization.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
-			BldrInterfaceName:  authorization.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: authorization.TemplateName + "." + "Handler",
+			Name:                authorization.TemplateName,
+			Impl:                "authorization",
+			CtrCfg:              &authorization.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
+			BldrInterfaceName:   authorization.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  authorization.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  authorization.RemoteHandleMethod,
+			RemoteHandleRequest: authorization.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(authorization.HandlerBuilder)
 				return ok
@@ -1109,12 +1115,14 @@ var (
 		},
 
 		checknothing.TemplateName: {
-			Name:               checknothing.TemplateName,
-			Impl:               "checknothing",
-			CtrCfg:             &checknothing.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
-			BldrInterfaceName:  checknothing.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: checknothing.TemplateName + "." + "Handler",
+			Name:                checknothing.TemplateName,
+			Impl:                "checknothing",
+			CtrCfg:              &checknothing.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
+			BldrInterfaceName:   checknothing.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  checknothing.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  checknothing.RemoteHandleMethod,
+			RemoteHandleRequest: checknothing.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(checknothing.HandlerBuilder)
 				return ok
@@ -1209,12 +1217,14 @@ var (
 		},
 
 		listentry.TemplateName: {
-			Name:               listentry.TemplateName,
-			Impl:               "listentry",
-			CtrCfg:             &listentry.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
-			BldrInterfaceName:  listentry.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: listentry.TemplateName + "." + "Handler",
+			Name:                listentry.TemplateName,
+			Impl:                "listentry",
+			CtrCfg:              &listentry.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
+			BldrInterfaceName:   listentry.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  listentry.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  listentry.RemoteHandleMethod,
+			RemoteHandleRequest: listentry.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(listentry.HandlerBuilder)
 				return ok
@@ -1318,12 +1328,14 @@ var (
 		},
 
 		logentry.TemplateName: {
-			Name:               logentry.TemplateName,
-			Impl:               "logentry",
-			CtrCfg:             &logentry.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
-			BldrInterfaceName:  logentry.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: logentry.TemplateName + "." + "Handler",
+			Name:                logentry.TemplateName,
+			Impl:                "logentry",
+			CtrCfg:              &logentry.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
+			BldrInterfaceName:   logentry.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  logentry.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  logentry.RemoteHandleMethod,
+			RemoteHandleRequest: logentry.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(logentry.HandlerBuilder)
 				return ok
@@ -1471,12 +1483,14 @@ var (
 		},
 
 		metric.TemplateName: {
-			Name:               metric.TemplateName,
-			Impl:               "metric",
-			CtrCfg:             &metric.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
-			BldrInterfaceName:  metric.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: metric.TemplateName + "." + "Handler",
+			Name:                metric.TemplateName,
+			Impl:                "metric",
+			CtrCfg:              &metric.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
+			BldrInterfaceName:   metric.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  metric.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  metric.RemoteHandleMethod,
+			RemoteHandleRequest: metric.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(metric.HandlerBuilder)
 				return ok
@@ -1612,12 +1626,14 @@ var (
 		},
 
 		quota.TemplateName: {
-			Name:               quota.TemplateName,
-			Impl:               "quota",
-			CtrCfg:             &quota.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_QUOTA,
-			BldrInterfaceName:  quota.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: quota.TemplateName + "." + "Handler",
+			Name:                quota.TemplateName,
+			Impl:                "quota",
+			CtrCfg:              &quota.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_QUOTA,
+			BldrInterfaceName:   quota.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  quota.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  quota.RemoteHandleMethod,
+			RemoteHandleRequest: quota.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(quota.HandlerBuilder)
 				return ok
@@ -1722,12 +1738,14 @@ var (
 		},
 
 		reportnothing.TemplateName: {
-			Name:               reportnothing.TemplateName,
-			Impl:               "reportnothing",
-			CtrCfg:             &reportnothing.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
-			BldrInterfaceName:  reportnothing.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: reportnothing.TemplateName + "." + "Handler",
+			Name:                reportnothing.TemplateName,
+			Impl:                "reportnothing",
+			CtrCfg:              &reportnothing.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
+			BldrInterfaceName:   reportnothing.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  reportnothing.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  reportnothing.RemoteHandleMethod,
+			RemoteHandleRequest: reportnothing.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(reportnothing.HandlerBuilder)
 				return ok
@@ -1828,12 +1846,14 @@ var (
 		},
 
 		tracespan.TemplateName: {
-			Name:               tracespan.TemplateName,
-			Impl:               "tracespan",
-			CtrCfg:             &tracespan.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
-			BldrInterfaceName:  tracespan.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: tracespan.TemplateName + "." + "Handler",
+			Name:                tracespan.TemplateName,
+			Impl:                "tracespan",
+			CtrCfg:              &tracespan.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
+			BldrInterfaceName:   tracespan.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  tracespan.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  tracespan.RemoteHandleMethod,
+			RemoteHandleRequest: tracespan.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(tracespan.HandlerBuilder)
 				return ok
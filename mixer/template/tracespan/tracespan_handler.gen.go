@@ -26,6 +26,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "tracespan"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'tracespan' instances.
+const RemoteHandleMethod = "/tracespan.HandleTraceSpanService/HandleTraceSpan"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "tracespan.HandleTraceSpanRequest"
+
 // Instance is constructed by Mixer for the 'tracespan' template.
 //
 // TraceSpan represents an individual span within a distributed trace.
@@ -0,0 +1,47 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Runs the sample out-of-process adapter, to be dispatched to by a Mixer handler of the remote adapter.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"istio.io/istio/mixer/test/remoteadapter"
+)
+
+func main() {
+	address := flag.String("address", ":9070", "address to serve the gRPC services of the adapter on")
+	whitelist := flag.String("whitelist", "", "comma separated list entries accepted by the adapter")
+	quotaLimit := flag.Int64("quotaLimit", 100, "maximum quota amount granted by the adapter")
+	flag.Parse()
+
+	s, err := remoteadapter.NewServer(*address, strings.Split(*whitelist, ","), *quotaLimit)
+	if err != nil {
+		fmt.Printf("Error creating the adapter: %v\n", err)
+		os.Exit(1)
+	}
+	s.Run()
+	fmt.Printf("Serving on %s\n", s.Addr())
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+	_ = s.Close()
+}
@@ -0,0 +1,206 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remoteadapter is a sample out-of-process adapter, dispatched to by Mixer through the remote
+// adapter. It implements the generated gRPC services of the metric, listentry and quota templates:
+// it records the metrics it receives, checks list entries against a whitelist and grants quota
+// amounts up to a limit.
+package remoteadapter
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+
+	"istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/status"
+	"istio.io/istio/mixer/template/listentry"
+	"istio.io/istio/mixer/template/metric"
+	"istio.io/istio/mixer/template/quota"
+)
+
+type (
+	// Server is the gRPC server of the sample out-of-process adapter.
+	Server struct {
+		listener net.Listener
+		server   *grpc.Server
+
+		whitelist  map[string]bool
+		quotaLimit int64
+
+		mu      sync.Mutex
+		metrics []Metric
+	}
+
+	// Metric is a metric instance received by the server.
+	Metric struct {
+		Name       string
+		Value      interface{}
+		Dimensions map[string]interface{}
+	}
+)
+
+// validDuration is the duration for which check and quota results of the server are valid.
+const validDuration = 10 * time.Second
+
+var _ metric.HandleMetricServiceServer = &Server{}
+var _ listentry.HandleListEntryServiceServer = &Server{}
+var _ quota.HandleQuotaServiceServer = &Server{}
+
+// NewServer creates a server listening on the address, which accepts the list entries of the whitelist
+// and grants quota amounts up to quotaLimit.
+func NewServer(address string, whitelist []string, quotaLimit int64) (*Server, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on %s: %v", address, err)
+	}
+
+	s := &Server{
+		listener:   listener,
+		server:     grpc.NewServer(),
+		whitelist:  make(map[string]bool, len(whitelist)),
+		quotaLimit: quotaLimit,
+	}
+	for _, entry := range whitelist {
+		s.whitelist[entry] = true
+	}
+
+	metric.RegisterHandleMetricServiceServer(s.server, s)
+	listentry.RegisterHandleListEntryServiceServer(s.server, s)
+	quota.RegisterHandleQuotaServiceServer(s.server, s)
+	return s, nil
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Run starts serving in the background.
+func (s *Server) Run() {
+	go func() {
+		_ = s.server.Serve(s.listener)
+	}()
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	s.server.Stop()
+	return nil
+}
+
+// Metrics returns the metrics received so far, ordered by name.
+func (s *Server) Metrics() []Metric {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metrics := append([]Metric(nil), s.metrics...)
+	sort.SliceStable(metrics, func(i, j int) bool {
+		return metrics[i].Name < metrics[j].Name
+	})
+	return metrics
+}
+
+// HandleMetric records the metric instances.
+func (s *Server) HandleMetric(_ context.Context, req *metric.HandleMetricRequest) (*v1beta1.ReportResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, inst := range req.Instances {
+		m := Metric{
+			Name:       inst.Name,
+			Value:      decodeValue(inst.Value),
+			Dimensions: make(map[string]interface{}, len(inst.Dimensions)),
+		}
+		for k, v := range inst.Dimensions {
+			m.Dimensions[k] = decodeValue(v)
+		}
+		s.metrics = append(s.metrics, m)
+	}
+	return &v1beta1.ReportResult{}, nil
+}
+
+// HandleListEntry checks the value of the list entry against the whitelist.
+func (s *Server) HandleListEntry(_ context.Context, req *listentry.HandleListEntryRequest) (*v1beta1.CheckResult, error) {
+	st := status.OK
+	if !s.whitelist[req.Instance.Value] {
+		st = status.WithPermissionDenied(fmt.Sprintf("%s is not whitelisted", req.Instance.Value))
+	}
+	return &v1beta1.CheckResult{
+		Status:        st,
+		ValidDuration: validDuration,
+		ValidUseCount: 100,
+	}, nil
+}
+
+// HandleQuota grants the requested amounts, up to the quota limit.
+func (s *Server) HandleQuota(_ context.Context, req *quota.HandleQuotaRequest) (*v1beta1.QuotaResult, error) {
+	res := &v1beta1.QuotaResult{
+		Quotas: make(map[string]v1beta1.QuotaResult_Result),
+	}
+	if req.QuotaRequest == nil {
+		return res, nil
+	}
+	for name, params := range req.QuotaRequest.Quotas {
+		amount := params.Amount
+		if amount > s.quotaLimit {
+			amount = 0
+			if params.BestEffort {
+				amount = s.quotaLimit
+			}
+		}
+		res.Quotas[name] = v1beta1.QuotaResult_Result{
+			ValidDuration: validDuration,
+			GrantedAmount: amount,
+		}
+	}
+	return res, nil
+}
+
+func decodeValue(v *v1beta1.Value) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch t := v.Value.(type) {
+	case *v1beta1.Value_StringValue:
+		return t.StringValue
+	case *v1beta1.Value_Int64Value:
+		return t.Int64Value
+	case *v1beta1.Value_DoubleValue:
+		return t.DoubleValue
+	case *v1beta1.Value_BoolValue:
+		return t.BoolValue
+	case *v1beta1.Value_IpAddressValue:
+		return net.IP(t.IpAddressValue.Value).String()
+	case *v1beta1.Value_TimestampValue:
+		ts, _ := types.TimestampFromProto(t.TimestampValue.Value)
+		return ts
+	case *v1beta1.Value_DurationValue:
+		d, _ := types.DurationFromProto(t.DurationValue.Value)
+		return d
+	case *v1beta1.Value_EmailAddressValue:
+		return t.EmailAddressValue.Value
+	case *v1beta1.Value_DnsNameValue:
+		return t.DnsNameValue.Value
+	case *v1beta1.Value_UriValue:
+		return t.UriValue.Value
+	}
+	return nil
+}
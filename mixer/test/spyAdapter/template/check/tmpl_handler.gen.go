@@ -25,6 +25,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "samplecheck"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'samplecheck' instances.
+const RemoteHandleMethod = "/samplecheck.HandleSampleCheckService/HandleSampleCheck"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "samplecheck.HandleSampleCheckRequest"
+
 // Instance is constructed by Mixer for the 'samplecheck' template.
 type Instance struct {
 	// Name of the instance as specified in configuration.
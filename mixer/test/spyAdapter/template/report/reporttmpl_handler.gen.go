@@ -25,6 +25,13 @@ import (
 // Fully qualified name of the template
 const TemplateName = "samplereport"
 
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive 'samplereport' instances.
+const RemoteHandleMethod = "/samplereport.HandleSampleReportService/HandleSampleReport"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "samplereport.HandleSampleReportRequest"
+
 // Instance is constructed by Mixer for the 'samplereport' template.
 type Instance struct {
 	// Name of the instance as specified in configuration.
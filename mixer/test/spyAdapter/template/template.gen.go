@@ -349,12 +349,14 @@ var (
 		},
 
 		samplecheck.TemplateName: {
-			Name:               samplecheck.TemplateName,
-			Impl:               "samplecheck",
-			CtrCfg:             &samplecheck.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
-			BldrInterfaceName:  samplecheck.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: samplecheck.TemplateName + "." + "Handler",
+			Name:                samplecheck.TemplateName,
+			Impl:                "samplecheck",
+			CtrCfg:              &samplecheck.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_CHECK,
+			BldrInterfaceName:   samplecheck.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  samplecheck.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  samplecheck.RemoteHandleMethod,
+			RemoteHandleRequest: samplecheck.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(samplecheck.HandlerBuilder)
 				return ok
@@ -458,12 +460,14 @@ var (
 		},
 
 		samplereport.TemplateName: {
-			Name:               samplereport.TemplateName,
-			Impl:               "samplereport",
-			CtrCfg:             &samplereport.InstanceParam{},
-			Variety:            istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
-			BldrInterfaceName:  samplereport.TemplateName + "." + "HandlerBuilder",
-			HndlrInterfaceName: samplereport.TemplateName + "." + "Handler",
+			Name:                samplereport.TemplateName,
+			Impl:                "samplereport",
+			CtrCfg:              &samplereport.InstanceParam{},
+			Variety:             istio_adapter_model_v1beta1.TEMPLATE_VARIETY_REPORT,
+			BldrInterfaceName:   samplereport.TemplateName + "." + "HandlerBuilder",
+			HndlrInterfaceName:  samplereport.TemplateName + "." + "Handler",
+			RemoteHandleMethod:  samplereport.RemoteHandleMethod,
+			RemoteHandleRequest: samplereport.RemoteHandleRequest,
 			BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
 				_, ok := hndlrBuilder.(samplereport.HandlerBuilder)
 				return ok
@@ -115,6 +115,10 @@ var (
             Variety:   istio_adapter_model_v1beta1.{{.VarietyName}},
             BldrInterfaceName:  {{.GoPackageName}}.TemplateName + "." + "HandlerBuilder",
             HndlrInterfaceName: {{.GoPackageName}}.TemplateName + "." + "Handler",
+            {{- if ne .VarietyName "TEMPLATE_VARIETY_ATTRIBUTE_GENERATOR"}}
+            RemoteHandleMethod: {{.GoPackageName}}.RemoteHandleMethod,
+            RemoteHandleRequest: {{.GoPackageName}}.RemoteHandleRequest,
+            {{- end}}
             BuilderSupportsTemplate: func(hndlrBuilder adapter.HandlerBuilder) bool {
                 _, ok := hndlrBuilder.({{.GoPackageName}}.HandlerBuilder)
                 return ok
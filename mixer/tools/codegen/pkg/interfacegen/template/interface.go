@@ -31,6 +31,14 @@ import (
 
 // Fully qualified name of the template
 const TemplateName = "{{.TemplateName}}"
+{{if ne .VarietyName "TEMPLATE_VARIETY_ATTRIBUTE_GENERATOR"}}
+// RemoteHandleMethod is the full name of the gRPC method out-of-process adapters implement
+// to receive '{{.TemplateName}}' instances.
+const RemoteHandleMethod = "/{{.PackageName}}.Handle{{.InterfaceName}}Service/Handle{{.InterfaceName}}"
+
+// RemoteHandleRequest is the name of the request message of RemoteHandleMethod.
+const RemoteHandleRequest = "{{.PackageName}}.Handle{{.InterfaceName}}Request"
+{{end}}
 
 // Instance is constructed by Mixer for the '{{.TemplateName}}' template.{{if ne .TemplateMessage.Comment ""}}
 //
@@ -37,6 +37,10 @@ var (
 		"listeners": {},
 		"routes":    {},
 		"static":    {},
+
+		// config_dump is not part of "all" since it includes the other
+		// dynamic config types
+		"config_dump": {},
 	}
 
 	debugCmd = &cobra.Command{
@@ -45,7 +49,7 @@ var (
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			d := &debug{
-				envoyAdminAddress:    "http://127.0.0.1:15000",
+				envoyAdminAddress:    "127.0.0.1:15000",
 				staticConfigLocation: "/etc/istio/proxy",
 			}
 			return d.run(args)
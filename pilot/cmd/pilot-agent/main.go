@@ -31,7 +31,9 @@ import (
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/pkg/proxy"
 	envoy "istio.io/istio/pilot/pkg/proxy/envoy/v1"
+	"istio.io/istio/pilot/pkg/proxy/status"
 	"istio.io/istio/pilot/pkg/serviceregistry"
+	"istio.io/istio/pkg/bootstrap"
 	"istio.io/istio/pkg/collateral"
 	"istio.io/istio/pkg/log"
 	"istio.io/istio/pkg/version"
@@ -51,6 +53,11 @@ var (
 	discoveryAddress       string
 	discoveryRefreshDelay  time.Duration
 	zipkinAddress          string
+	tracer                 string
+	jaegerAddress          string
+	lightstepAddress       string
+	lightstepAccessToken   string
+	lightstepCacertPath    string
 	connectTimeout         time.Duration
 	statsdUDPAddress       string
 	proxyAdminPort         int
@@ -59,6 +66,8 @@ var (
 	proxyLogLevel          string
 	concurrency            int
 	bootstrapv2            bool
+	statusPort             uint16
+	terminationGracePeriod time.Duration
 
 	loggingOptions = log.DefaultOptions()
 
@@ -200,7 +209,14 @@ var (
 			if bootstrapv2 {
 				// Using a different constructor - the code will likely be refactored / split from the v1,
 				// but may expose same interface to minimize risks
-				envoyProxy = envoy.NewV2Proxy(proxyConfig, role.ServiceNode(), proxyLogLevel, pilotSAN)
+				opts := map[string]interface{}{
+					"tracer":                      tracer,
+					"jaeger_address":              jaegerAddress,
+					"lightstep_address":           lightstepAddress,
+					"lightstep_access_token_file": lightstepAccessToken,
+					"lightstep_cacert_path":       lightstepCacertPath,
+				}
+				envoyProxy = envoy.NewV2ProxyCustom(proxyConfig, role.ServiceNode(), proxyLogLevel, pilotSAN, opts, nil)
 			} else {
 				envoyProxy = envoy.NewProxy(proxyConfig, role.ServiceNode(), proxyLogLevel)
 			}
@@ -209,9 +225,30 @@ var (
 			ctx, cancel := context.WithCancel(context.Background())
 			go watcher.Run(ctx)
 
+			var drained <-chan struct{}
+			var statusServer *status.Server
+			if statusPort > 0 {
+				statusServer = status.NewServer(status.Config{
+					StatusPort:             statusPort,
+					AdminPort:              uint16(proxyAdminPort),
+					DrainDuration:          drainDuration,
+					TerminationGracePeriod: terminationGracePeriod,
+				})
+				go statusServer.Run(ctx)
+				drained = statusServer.Drained()
+			}
+
 			stop := make(chan struct{})
-			cmd.WaitSignal(stop)
-			<-stop
+			go cmd.WaitSignal(stop)
+			select {
+			case <-stop:
+				// drain Envoy before exiting on SIGTERM
+				if statusServer != nil {
+					statusServer.Drain()
+				}
+			case <-drained:
+				log.Info("Envoy drained, exiting")
+			}
 			cancel()
 			return nil
 		},
@@ -262,6 +299,17 @@ func init() {
 		"Polling interval for service discovery (used by EDS, CDS, LDS, but not RDS)")
 	proxyCmd.PersistentFlags().StringVar(&zipkinAddress, "zipkinAddress", values.ZipkinAddress,
 		"Address of the Zipkin service (e.g. zipkin:9411)")
+	proxyCmd.PersistentFlags().StringVar(&tracer, "tracer", bootstrap.TracerZipkin,
+		fmt.Sprintf("The tracer used by the v2 bootstrap (choose from {%s, %s, %s})",
+			bootstrap.TracerZipkin, bootstrap.TracerJaeger, bootstrap.TracerLightstep))
+	proxyCmd.PersistentFlags().StringVar(&jaegerAddress, "jaegerAddress", "",
+		"Address of the Jaeger agent (e.g. jaeger-agent:6831)")
+	proxyCmd.PersistentFlags().StringVar(&lightstepAddress, "lightstepAddress", "",
+		"Address of the Lightstep satellite pool (e.g. lightstep-satellite:8080)")
+	proxyCmd.PersistentFlags().StringVar(&lightstepAccessToken, "lightstepAccessToken", "",
+		"Path to the file holding the Lightstep access token")
+	proxyCmd.PersistentFlags().StringVar(&lightstepCacertPath, "lightstepCacertPath", "",
+		"Path to the CA certificate of the Lightstep satellites, enabling TLS")
 	proxyCmd.PersistentFlags().DurationVar(&connectTimeout, "connectTimeout",
 		timeDuration(values.ConnectTimeout),
 		"Connection timeout used by Envoy for supporting services")
@@ -281,6 +329,12 @@ func init() {
 		"number of worker threads to run")
 	proxyCmd.PersistentFlags().BoolVar(&bootstrapv2, "bootstrapv2", true,
 		"Use bootstrap v2")
+	proxyCmd.PersistentFlags().Uint16Var(&statusPort, "statusPort", 0,
+		"Port on which the agent serves the Envoy readiness probe ("+status.ReadyPath+") and drain ("+
+			status.DrainPath+") endpoints. Disabled if 0.")
+	proxyCmd.PersistentFlags().DurationVar(&terminationGracePeriod, "terminationGracePeriod",
+		status.DefaultTerminationGracePeriod,
+		"Termination grace period of the pod. The drain on SIGTERM is capped so that the agent exits within it")
 
 	// Attach the Istio logging options to the command.
 	loggingOptions.AttachCobraFlags(rootCmd)
@@ -26,6 +26,7 @@ import (
 
 	"istio.io/istio/pilot/cmd"
 	"istio.io/istio/pilot/pkg/bootstrap"
+	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/pkg/serviceregistry"
 	"istio.io/istio/pkg/collateral"
 	"istio.io/istio/pkg/log"
@@ -74,6 +75,8 @@ var (
 
 func init() {
 	discoveryCmd.PersistentFlags().BoolVar(&serverArgs.RDSv2, "rdsv2", false, "Enable RDS v2")
+	discoveryCmd.PersistentFlags().DurationVar(&serverArgs.JwksRefreshInterval, "jwksRefreshInterval",
+		model.DefaultJwksRefreshInterval, "Interval to refresh cached JWT public keys without a Cache-Control lifetime")
 
 	discoveryCmd.PersistentFlags().StringSliceVar(&serverArgs.Service.Registries, "registries",
 		[]string{string(serviceregistry.KubernetesRegistry)},
@@ -94,6 +97,8 @@ func init() {
 	// Config Controller options
 	discoveryCmd.PersistentFlags().StringVar(&serverArgs.Config.FileDir, "configDir", "",
 		"Directory to watch for updates to config yaml files. If specified, the files will be used as the source of config, rather than a CRD client.")
+	discoveryCmd.PersistentFlags().StringVar(&serverArgs.Config.OverrideDir, "configOverrideDir", "",
+		"Directory to watch for config yaml files that take precedence over CRDs with the same name, namespace and type.")
 	discoveryCmd.PersistentFlags().StringVarP(&serverArgs.Config.ControllerOptions.WatchedNamespace, "appNamespace",
 		"a", metav1.NamespaceAll,
 		"Restrict the applications namespace the controller manages; if not set, controller watches all namespaces")
@@ -142,6 +147,11 @@ func init() {
 	discoveryCmd.PersistentFlags().DurationVar(&serverArgs.Admission.RegistrationDelay,
 		"admission-registration-delay", 0*time.Second,
 		"Time to delay webhook registration after starting webhook server")
+	discoveryCmd.PersistentFlags().StringVar(&serverArgs.Admission.MixerConfigStoreURL,
+		"admission-mixer-config-store-url", "",
+		"URL of the Mixer config store (e.g. k8s://). If set, Mixer rules, handlers and instances are validated as well")
+	discoveryCmd.PersistentFlags().BoolVar(&serverArgs.Admission.WarnOnly, "admission-warn-only", false,
+		"Admit invalid or conflicting configuration, logging the validation errors instead of rejecting it")
 
 	// Attach the Istio logging options to the command.
 	loggingOptions.AttachCobraFlags(rootCmd)
@@ -65,7 +65,15 @@ const (
 	// CopilotTimeout when to cancel remote gRPC call to copilot
 	CopilotTimeout = 5 * time.Second
 	// FilepathWalkInterval dictates how often the file system is walked for config
+	// when the config directory cannot be watched for changes
 	FilepathWalkInterval = 100 * time.Millisecond
+
+	// FileConfigSource is the provenance of config read from files
+	FileConfigSource = "file"
+	// KubeConfigSource is the provenance of config read from Kubernetes CRDs
+	KubeConfigSource = "kubernetes"
+	// IngressConfigSource is the provenance of config synthesized from Kubernetes ingresses
+	IngressConfigSource = "ingress"
 )
 
 var (
@@ -100,13 +108,15 @@ type MeshArgs struct {
 
 // ConfigArgs provide configuration options for the configuration controller. If FileDir is set, that directory will
 // be monitored for CRD yaml files and will update the controller as those files change (This is used for testing
-// purposes). Otherwise, a CRD client is created based on the configuration.
+// purposes). Otherwise, a CRD client is created based on the configuration. If OverrideDir is set in addition to the
+// CRD client, config files in that directory take precedence over CRDs with the same key.
 type ConfigArgs struct {
 	ClusterRegistriesDir string
 	KubeConfig           string
 	CFConfig             string
 	ControllerOptions    kube.ControllerOptions
 	FileDir              string
+	OverrideDir          string
 }
 
 // ConsulArgs provides configuration for the Consul service registry.
@@ -158,6 +168,15 @@ type AdmissionArgs struct {
 	// potential races where registration completes and k8s apiserver
 	// invokes the webhook before the HTTP server is started.
 	RegistrationDelay time.Duration
+
+	// MixerConfigStoreURL is the URL of the Mixer config store, e.g.
+	// k8s:// for in-cluster config. If set, Mixer config kinds are
+	// validated by the admission controller as well.
+	MixerConfigStoreURL string
+
+	// WarnOnly admits invalid configuration, logging the validation
+	// errors instead of rejecting the request.
+	WarnOnly bool
 }
 
 // PilotArgs provides all of the configuration parameters for the Pilot discovery service.
@@ -169,6 +188,9 @@ type PilotArgs struct {
 	Service          ServiceArgs
 	Admission        AdmissionArgs
 	RDSv2            bool
+	// JwksRefreshInterval is how often cached JWT public keys are refreshed
+	// when the issuer sets no cache lifetime.
+	JwksRefreshInterval time.Duration
 }
 
 // Server contains the runtime configuration for the Pilot discovery service.
@@ -219,15 +241,15 @@ func NewServer(args PilotArgs) (*Server, error) {
 	if err := s.initMesh(&args); err != nil {
 		return nil, err
 	}
-	if err := s.initAdmissionController(&args); err != nil {
-		return nil, err
-	}
 	if err := s.initMixerSan(&args); err != nil {
 		return nil, err
 	}
 	if err := s.initConfigController(&args); err != nil {
 		return nil, err
 	}
+	if err := s.initAdmissionController(&args); err != nil {
+		return nil, err
+	}
 	if err := s.initServiceControllers(&args); err != nil {
 		return nil, err
 	}
@@ -428,7 +450,7 @@ func (s *Server) initConfigController(args *PilotArgs) error {
 		store := memory.Make(configDescriptor)
 		configController := memory.NewController(store)
 
-		err := s.makeFileMonitor(args, configController)
+		err := s.makeFileMonitor(args.Config.FileDir, configController)
 		if err != nil {
 			return err
 		}
@@ -447,6 +469,12 @@ func (s *Server) initConfigController(args *PilotArgs) error {
 			return err
 		}
 
+		if args.Config.OverrideDir != "" {
+			if controller, err = s.makeOverrideConfigController(args, controller); err != nil {
+				return err
+			}
+		}
+
 		s.configController = controller
 	}
 
@@ -473,9 +501,27 @@ func (s *Server) makeKubeConfigController(args *PilotArgs) (model.ConfigStoreCac
 	return crd.NewController(configClient, args.Config.ControllerOptions), nil
 }
 
-func (s *Server) makeFileMonitor(args *PilotArgs, configController model.ConfigStore) error {
-	fileSnapshot := configmonitor.NewFileSnapshot(args.Config.FileDir, configDescriptor)
-	fileMonitor := configmonitor.NewMonitor(configController, FilepathWalkInterval, fileSnapshot.ReadConfigFiles)
+// makeOverrideConfigController layers config files from the override directory
+// on top of the CRD config controller.
+func (s *Server) makeOverrideConfigController(args *PilotArgs, kubeController model.ConfigStoreCache) (model.ConfigStoreCache, error) {
+	overrideController := memory.NewController(memory.Make(configDescriptor))
+	if err := s.makeFileMonitor(args.Config.OverrideDir, overrideController); err != nil {
+		return nil, err
+	}
+
+	controller, err := configaggregate.MakeLayeredCache([]configaggregate.Layer{
+		{Name: FileConfigSource, Cache: overrideController},
+		{Name: KubeConfigSource, Cache: kubeController},
+	})
+	if err != nil {
+		return nil, multierror.Prefix(err, "failed to layer config overrides.")
+	}
+	return controller, nil
+}
+
+func (s *Server) makeFileMonitor(fileDir string, configController model.ConfigStore) error {
+	fileSnapshot := configmonitor.NewFileSnapshot(fileDir, configDescriptor)
+	fileMonitor := configmonitor.NewFileMonitor(configController, FilepathWalkInterval, fileSnapshot)
 
 	// Defer starting the file monitor until after the service is created.
 	s.addStartFunc(func(stop chan struct{}) error {
@@ -568,10 +614,14 @@ func (s *Server) initServiceControllers(args *PilotArgs) error {
 				return err
 			}
 			if s.mesh.IngressControllerMode != meshconfig.MeshConfig_OFF {
-				// Wrap the config controller with a cache.
-				configController, err := configaggregate.MakeCache([]model.ConfigStoreCache{
-					s.configController,
-					ingress.NewController(s.kubeClient, s.mesh, args.Config.ControllerOptions),
+				// Layer the config synthesized from Kubernetes ingresses on top of
+				// the config controller, which keeps receiving all mutations.
+				configController, err := configaggregate.MakeLayeredCache([]configaggregate.Layer{
+					{
+						Name:  IngressConfigSource,
+						Cache: ingress.NewController(s.kubeClient, s.mesh, args.Config.ControllerOptions),
+					},
+					{Name: KubeConfigSource, Cache: s.configController},
 				})
 				if err != nil {
 					return err
@@ -704,7 +754,21 @@ func (s *Server) initDiscoveryService(args *PilotArgs) error {
 		ServiceDiscovery: s.ServiceController,
 		ServiceAccounts:  s.ServiceController,
 		MixerSAN:         s.mixerSAN,
+		JwksResolver:     model.NewJwksResolver(args.JwksRefreshInterval, envoyv2.PushAll),
 	}
+	s.addStartFunc(func(stop chan struct{}) error {
+		go environment.JwksResolver.Run(stop)
+		return nil
+	})
+	// stop refreshing the keys of issuers no longer used by any policy
+	s.configController.RegisterEventHandler(model.AuthenticationPolicy.Type, func(model.Config, model.Event) {
+		referenced, err := model.ReferencedJwksURIs(s.configController)
+		if err != nil {
+			log.Warnf("Failed to list JWKS URIs of authentication policies: %v", err)
+			return
+		}
+		environment.JwksResolver.Prune(referenced)
+	})
 
 	// Set up discovery service
 	discovery, err := envoy.NewDiscoveryService(
@@ -801,6 +865,17 @@ func (s *Server) initAdmissionController(args *PilotArgs) error {
 			args.Config.ControllerOptions.WatchedNamespace,
 			args.Namespace,
 		},
+		ConfigStore: s.configController,
+		WarnOnly:    args.Admission.WarnOnly,
+	}
+
+	if args.Admission.MixerConfigStoreURL != "" {
+		validator, kinds, err := admit.NewMixerValidator(args.Admission.MixerConfigStoreURL, "destination.service")
+		if err != nil {
+			return multierror.Prefix(err, "failed to create Mixer config validator.")
+		}
+		admissionArgs.MixerValidator = validator
+		admissionArgs.MixerKinds = kinds
 	}
 
 	admissionController, err := admit.NewController(s.kubeClient, admissionArgs)
@@ -16,7 +16,9 @@
 // aggregate config store multiplexes requests to a configuration store based
 // on the type of the configuration objects. The aggregate config store cache
 // performs the reverse, by aggregating events from the multiplexed stores and
-// dispatching them back to event handlers.
+// dispatching them back to event handlers. The layered config store cache
+// overlays stores that serve the same types, letting higher precedence layers
+// override objects with the same key.
 package aggregate
 
 import (
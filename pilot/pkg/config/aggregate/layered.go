@@ -0,0 +1,233 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// Layer is a named config store cache participating in a layered store.
+// The name is recorded as the Source of every config served from the layer,
+// unless the layer (itself a layered store) already recorded one.
+type Layer struct {
+	Name  string
+	Cache model.ConfigStoreCache
+}
+
+// MakeLayeredCache creates a config store cache that overlays several layers
+// serving the same config types. Layers are ordered by decreasing precedence:
+// an object in an earlier layer shadows an object with the same key in any
+// later layer. Mutations are applied to the last (base) layer.
+func MakeLayeredCache(layers []Layer) (model.ConfigStoreCache, error) {
+	if len(layers) == 0 {
+		return nil, errors.New("no layers provided")
+	}
+
+	union := model.ConfigDescriptor{}
+	seen := make(map[string]model.ProtoSchema)
+	names := make(map[string]bool)
+	for _, layer := range layers {
+		if layer.Name == "" {
+			return nil, errors.New("layer name must be set")
+		}
+		if names[layer.Name] {
+			return nil, fmt.Errorf("duplicate layer name %q", layer.Name)
+		}
+		names[layer.Name] = true
+
+		for _, descriptor := range layer.Cache.ConfigDescriptor() {
+			if prev, exists := seen[descriptor.Type]; exists {
+				if prev.MessageName != descriptor.MessageName {
+					return nil, fmt.Errorf("layer %q declares type %q with message %q, expected %q",
+						layer.Name, descriptor.Type, descriptor.MessageName, prev.MessageName)
+				}
+				continue
+			}
+			seen[descriptor.Type] = descriptor
+			union = append(union, descriptor)
+		}
+	}
+	if err := union.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &layeredCache{
+		descriptor: union,
+		layers:     layers,
+	}, nil
+}
+
+type layeredCache struct {
+	descriptor model.ConfigDescriptor
+
+	// layers in decreasing order of precedence
+	layers []Layer
+
+	// mu serializes event delivery across layers since each layer runs its
+	// own event loop
+	mu sync.Mutex
+}
+
+func (l *layeredCache) ConfigDescriptor() model.ConfigDescriptor {
+	return l.descriptor
+}
+
+func (l *layeredCache) hasType(layer Layer, typ string) bool {
+	_, exists := layer.Cache.ConfigDescriptor().GetByType(typ)
+	return exists
+}
+
+// lookup returns the config with the given key from the first layer in
+// layers that holds it, annotated with the layer name.
+func (l *layeredCache) lookup(layers []Layer, typ, name, namespace string) (*model.Config, bool) {
+	for _, layer := range layers {
+		if !l.hasType(layer, typ) {
+			continue
+		}
+		if config, exists := layer.Cache.Get(typ, name, namespace); exists {
+			out := *config
+			if out.Source == "" {
+				out.Source = layer.Name
+			}
+			return &out, true
+		}
+	}
+	return nil, false
+}
+
+func (l *layeredCache) Get(typ, name, namespace string) (*model.Config, bool) {
+	return l.lookup(l.layers, typ, name, namespace)
+}
+
+func (l *layeredCache) List(typ, namespace string) ([]model.Config, error) {
+	var out []model.Config
+	keys := make(map[string]bool)
+	for _, layer := range l.layers {
+		if !l.hasType(layer, typ) {
+			continue
+		}
+		configs, err := layer.Cache.List(typ, namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, config := range configs {
+			key := config.Key()
+			if keys[key] {
+				continue
+			}
+			keys[key] = true
+			if config.Source == "" {
+				config.Source = layer.Name
+			}
+			out = append(out, config)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key() < out[j].Key() })
+	return out, nil
+}
+
+// base returns the lowest precedence layer that serves the type.
+func (l *layeredCache) base(typ string) (model.ConfigStore, bool) {
+	for i := len(l.layers) - 1; i >= 0; i-- {
+		if l.hasType(l.layers[i], typ) {
+			return l.layers[i].Cache, true
+		}
+	}
+	return nil, false
+}
+
+func (l *layeredCache) Create(config model.Config) (string, error) {
+	store, exists := l.base(config.Type)
+	if !exists {
+		return "", errors.New("missing type")
+	}
+	config.Source = ""
+	return store.Create(config)
+}
+
+func (l *layeredCache) Update(config model.Config) (string, error) {
+	store, exists := l.base(config.Type)
+	if !exists {
+		return "", errors.New("missing type")
+	}
+	config.Source = ""
+	return store.Update(config)
+}
+
+func (l *layeredCache) Delete(typ, name, namespace string) error {
+	store, exists := l.base(typ)
+	if !exists {
+		return fmt.Errorf("missing type %q", typ)
+	}
+	return store.Delete(typ, name, namespace)
+}
+
+func (l *layeredCache) HasSynced() bool {
+	for _, layer := range l.layers {
+		if !layer.Cache.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// RegisterEventHandler registers the handler with every layer serving the
+// type. Events are translated to the merged view: changes shadowed by a
+// higher precedence layer are suppressed, and removing an override surfaces
+// the object from the next layer as an update.
+func (l *layeredCache) RegisterEventHandler(typ string, handler func(model.Config, model.Event)) {
+	for i, layer := range l.layers {
+		if !l.hasType(layer, typ) {
+			continue
+		}
+		higher, lower, name := l.layers[:i], l.layers[i+1:], layer.Name
+		layer.Cache.RegisterEventHandler(typ, func(config model.Config, event model.Event) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			if _, shadowed := l.lookup(higher, config.Type, config.Name, config.Namespace); shadowed {
+				return
+			}
+
+			if config.Source == "" {
+				config.Source = name
+			}
+			underlying, exists := l.lookup(lower, config.Type, config.Name, config.Namespace)
+			switch event {
+			case model.EventAdd:
+				if exists {
+					event = model.EventUpdate
+				}
+			case model.EventDelete:
+				if exists {
+					config, event = *underlying, model.EventUpdate
+				}
+			}
+			handler(config, event)
+		})
+	}
+}
+
+func (l *layeredCache) Run(stop <-chan struct{}) {
+	for _, layer := range l.layers {
+		go layer.Cache.Run(stop)
+	}
+	<-stop
+}
@@ -0,0 +1,236 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregate_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"istio.io/istio/pilot/pkg/config/aggregate"
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/model/test"
+	"istio.io/istio/pilot/test/mock"
+	pkgtest "istio.io/istio/pkg/test"
+)
+
+type recordedEvent struct {
+	source string
+	value  string
+	event  model.Event
+}
+
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []recordedEvent
+}
+
+func (r *eventRecorder) handle(config model.Config, event model.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, recordedEvent{
+		source: config.Source,
+		value:  config.Spec.(*test.MockConfig).Pairs[0].Value,
+		event:  event,
+	})
+}
+
+func (r *eventRecorder) wait(t *testing.T, n int) []recordedEvent {
+	pkgtest.NewEventualOpts(10*time.Millisecond, 5*time.Second).Eventually(t, "receive events", func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return len(r.events) >= n
+	})
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := r.events
+	r.events = nil
+	return out
+}
+
+func makeLayered(t *testing.T) (model.ConfigStoreCache, model.ConfigStoreCache, model.ConfigStoreCache) {
+	override := memory.NewController(memory.Make(mock.Types))
+	base := memory.NewController(memory.Make(mock.Types))
+	layered, err := aggregate.MakeLayeredCache([]aggregate.Layer{
+		{Name: "file", Cache: override},
+		{Name: "kubernetes", Cache: base},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return layered, override, base
+}
+
+func makeValue(namespace, value string) model.Config {
+	config := mock.Make(namespace, 0)
+	config.Spec.(*test.MockConfig).Pairs[0].Value = value
+	return config
+}
+
+func TestLayeredValidation(t *testing.T) {
+	if _, err := aggregate.MakeLayeredCache(nil); err == nil {
+		t.Error("expected error with no layers")
+	}
+	cache := memory.NewController(memory.Make(mock.Types))
+	if _, err := aggregate.MakeLayeredCache([]aggregate.Layer{
+		{Name: "a", Cache: cache},
+		{Name: "a", Cache: cache},
+	}); err == nil {
+		t.Error("expected error with duplicate layer names")
+	}
+}
+
+func TestLayeredPrecedence(t *testing.T) {
+	layered, override, base := makeLayered(t)
+
+	if _, err := base.Create(makeValue(TestNamespace, "crd")); err != nil {
+		t.Fatal(err)
+	}
+	config, exists := layered.Get(model.MockConfig.Type, "mock-config0", TestNamespace)
+	if !exists || config.Source != "kubernetes" {
+		t.Fatalf("expected config from base layer, got %v", config)
+	}
+
+	if _, err := override.Create(makeValue(TestNamespace, "file")); err != nil {
+		t.Fatal(err)
+	}
+	config, exists = layered.Get(model.MockConfig.Type, "mock-config0", TestNamespace)
+	if !exists || config.Source != "file" || config.Spec.(*test.MockConfig).Pairs[0].Value != "file" {
+		t.Fatalf("expected config from override layer, got %v", config)
+	}
+
+	other := mock.Make(TestNamespace, 1)
+	if _, err := base.Create(other); err != nil {
+		t.Fatal(err)
+	}
+	configs, err := layered.List(model.MockConfig.Type, TestNamespace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("expected 2 merged configs, got %d", len(configs))
+	}
+	if configs[0].Source != "file" || configs[1].Source != "kubernetes" {
+		t.Errorf("unexpected sources %q and %q", configs[0].Source, configs[1].Source)
+	}
+
+	// mutations are applied to the base layer
+	if err = layered.Delete(model.MockConfig.Type, "mock-config1", TestNamespace); err != nil {
+		t.Fatal(err)
+	}
+	if _, exists = base.Get(model.MockConfig.Type, "mock-config1", TestNamespace); exists {
+		t.Error("expected delete to be applied to the base layer")
+	}
+}
+
+func TestLayeredNestedSource(t *testing.T) {
+	inner, override, base := makeLayered(t)
+	local := memory.NewController(memory.Make(mock.Types))
+	layered, err := aggregate.MakeLayeredCache([]aggregate.Layer{
+		{Name: "local", Cache: local},
+		{Name: "cluster", Cache: inner},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = override.Create(mock.Make(TestNamespace, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = base.Create(mock.Make(TestNamespace, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = local.Create(mock.Make(TestNamespace, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the nested store keeps the source recorded by its own layers
+	want := []string{"file", "kubernetes", "local"}
+	configs, err := layered.List(model.MockConfig.Type, TestNamespace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != len(want) {
+		t.Fatalf("expected %d merged configs, got %d", len(want), len(configs))
+	}
+	for i, config := range configs {
+		if config.Source != want[i] {
+			t.Errorf("List: got source %q for %s, want %q", config.Source, config.Name, want[i])
+		}
+		got, exists := layered.Get(model.MockConfig.Type, config.Name, TestNamespace)
+		if !exists || got.Source != want[i] {
+			t.Errorf("Get: got %v for %s, want source %q", got, config.Name, want[i])
+		}
+	}
+}
+
+func TestLayeredEvents(t *testing.T) {
+	layered, override, base := makeLayered(t)
+	recorder := &eventRecorder{}
+	layered.RegisterEventHandler(model.MockConfig.Type, recorder.handle)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go layered.Run(stop)
+
+	if _, err := base.Create(makeValue(TestNamespace, "crd")); err != nil {
+		t.Fatal(err)
+	}
+	want := recordedEvent{source: "kubernetes", value: "crd", event: model.EventAdd}
+	if got := recorder.wait(t, 1); got[0] != want {
+		t.Errorf("got %v, want %v", got[0], want)
+	}
+
+	// an override of an existing object is an update
+	if _, err := override.Create(makeValue(TestNamespace, "file")); err != nil {
+		t.Fatal(err)
+	}
+	want = recordedEvent{source: "file", value: "file", event: model.EventUpdate}
+	if got := recorder.wait(t, 1); got[0] != want {
+		t.Errorf("got %v, want %v", got[0], want)
+	}
+
+	// changes to a shadowed object are suppressed
+	crd, _ := base.Get(model.MockConfig.Type, "mock-config0", TestNamespace)
+	updated := makeValue(TestNamespace, "crd-updated")
+	updated.ResourceVersion = crd.ResourceVersion
+	if _, err := base.Update(updated); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	recorder.mu.Lock()
+	if len(recorder.events) != 0 {
+		t.Errorf("expected shadowed update to be suppressed, got %v", recorder.events)
+	}
+	recorder.mu.Unlock()
+
+	// removing the override reveals the underlying object
+	if err := override.Delete(model.MockConfig.Type, "mock-config0", TestNamespace); err != nil {
+		t.Fatal(err)
+	}
+	want = recordedEvent{source: "kubernetes", value: "crd-updated", event: model.EventUpdate}
+	if got := recorder.wait(t, 1); got[0] != want {
+		t.Errorf("got %v, want %v", got[0], want)
+	}
+
+	if err := base.Delete(model.MockConfig.Type, "mock-config0", TestNamespace); err != nil {
+		t.Fatal(err)
+	}
+	want = recordedEvent{source: "kubernetes", value: "crd-updated", event: model.EventDelete}
+	if got := recorder.wait(t, 1); got[0] != want {
+		t.Errorf("got %v, want %v", got[0], want)
+	}
+}
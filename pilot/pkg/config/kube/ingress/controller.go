@@ -13,12 +13,14 @@
 // limitations under the License.
 
 // Package ingress provides a read-only view of Kubernetes ingress resources
-// as an ingress rule configuration type store
+// as an ingress rule configuration type store, and as a gateway with virtual
+// services bound to the Istio ingress workloads
 package ingress
 
 import (
 	"errors"
 	"reflect"
+	"sort"
 	"time"
 
 	"k8s.io/api/extensions/v1beta1"
@@ -106,23 +108,82 @@ func NewController(client kubernetes.Interface, mesh *meshconfig.MeshConfig,
 }
 
 func (c *controller) RegisterEventHandler(typ string, f func(model.Config, model.Event)) {
+	// synthesized holds the configs last reported to f by name. Handlers run
+	// one at a time on the queue.
+	synthesized := make(map[string]model.Config)
 	c.handler.Append(func(obj interface{}, event model.Event) error {
 		ingress, ok := obj.(*v1beta1.Ingress)
 		if !ok || !shouldProcessIngress(c.mesh, ingress) {
 			return nil
 		}
 
-		// TODO: This works well for Add and Delete events, but not so for Update:
-		// An updated ingress may also trigger an Add or Delete for one of its constituent sub-rules.
-		rules := convertIngress(*ingress, c.domainSuffix)
-		for _, rule := range rules {
-			f(rule, event)
+		switch typ {
+		case model.IngressRule.Type:
+			// TODO: This works well for Add and Delete events, but not so for Update:
+			// An updated ingress may also trigger an Add or Delete for one of its constituent sub-rules.
+			rules := convertIngress(*ingress, c.domainSuffix)
+			for _, rule := range rules {
+				f(rule, event)
+			}
+		case model.Gateway.Type, model.VirtualService.Type:
+			// All ingresses are merged into the synthesized configs, so a change
+			// to an ingress is reported as the changes to those configs.
+			gateway, virtualServices := ConvertIngressesV1alpha3(c.processedIngresses(), c.domainSuffix)
+			current := virtualServices
+			if typ == model.Gateway.Type {
+				current = nil
+				if gateway != nil {
+					current = append(current, *gateway)
+				}
+			}
+			synthesized = notifySynthesized(synthesized, current, f)
 		}
 
 		return nil
 	})
 }
 
+// processedIngresses returns the ingresses handled by this controller
+// according to the mesh ingress controller mode.
+func (c *controller) processedIngresses() []*v1beta1.Ingress {
+	var out []*v1beta1.Ingress
+	for _, obj := range c.informer.GetStore().List() {
+		ingress := obj.(*v1beta1.Ingress)
+		if shouldProcessIngress(c.mesh, ingress) {
+			out = append(out, ingress)
+		}
+	}
+	return out
+}
+
+// notifySynthesized reports the configs added to, changed in and removed from
+// the previously synthesized configs, and returns the current configs by name.
+func notifySynthesized(previous map[string]model.Config, current []model.Config,
+	f func(model.Config, model.Event)) map[string]model.Config {
+	out := make(map[string]model.Config, len(current))
+	for _, config := range current {
+		out[config.Name] = config
+		old, exists := previous[config.Name]
+		if !exists {
+			f(config, model.EventAdd)
+		} else if !reflect.DeepEqual(old.Spec, config.Spec) {
+			f(config, model.EventUpdate)
+		}
+	}
+
+	removed := make([]string, 0)
+	for name := range previous {
+		if _, exists := out[name]; !exists {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		f(previous[name], model.EventDelete)
+	}
+	return out
+}
+
 func (c *controller) HasSynced() bool {
 	return c.informer.HasSynced()
 }
@@ -134,69 +195,72 @@ func (c *controller) Run(stop <-chan struct{}) {
 }
 
 func (c *controller) ConfigDescriptor() model.ConfigDescriptor {
-	return model.ConfigDescriptor{model.IngressRule}
+	return model.ConfigDescriptor{model.IngressRule, model.Gateway, model.VirtualService}
 }
 
 func (c *controller) Get(typ, name, namespace string) (*model.Config, bool) {
-	if typ != model.IngressRule.Type && typ != model.Gateway.Type && typ != model.VirtualService.Type {
-		return nil, false
-	}
-
-	ingressName, _, _, err := decodeIngressRuleName(name)
-	if err != nil {
-		return nil, false
-	}
+	switch typ {
+	case model.IngressRule.Type:
+		ingressName, _, _, err := decodeIngressRuleName(name)
+		if err != nil {
+			return nil, false
+		}
 
-	storeKey := kube.KeyFunc(ingressName, namespace)
-	obj, exists, err := c.informer.GetStore().GetByKey(storeKey)
-	if err != nil || !exists {
-		return nil, false
-	}
+		storeKey := kube.KeyFunc(ingressName, namespace)
+		obj, exists, err := c.informer.GetStore().GetByKey(storeKey)
+		if err != nil || !exists {
+			return nil, false
+		}
 
-	ingress := obj.(*v1beta1.Ingress)
-	if !shouldProcessIngress(c.mesh, ingress) {
-		return nil, false
-	}
+		ingress := obj.(*v1beta1.Ingress)
+		if !shouldProcessIngress(c.mesh, ingress) {
+			return nil, false
+		}
 
-	if typ == model.IngressRule.Type {
 		rules := convertIngress(*ingress, c.domainSuffix)
 		for _, rule := range rules {
 			if rule.Name == name {
 				return &rule, true
 			}
 		}
+	case model.Gateway.Type, model.VirtualService.Type:
+		configs, err := c.List(typ, namespace)
+		if err != nil {
+			return nil, false
+		}
+		for _, config := range configs {
+			if config.Name == name {
+				return &config, true
+			}
+		}
 	}
 
 	return nil, false
 }
 
 func (c *controller) List(typ, namespace string) ([]model.Config, error) {
-	if typ != model.IngressRule.Type && typ != model.Gateway.Type && typ != model.VirtualService.Type {
-		return nil, errUnsupportedOp
-	}
-
 	out := make([]model.Config, 0)
-	for _, obj := range c.informer.GetStore().List() {
-		ingress := obj.(*v1beta1.Ingress)
-		if namespace != "" && namespace != ingress.Namespace {
-			continue
+	switch typ {
+	case model.IngressRule.Type:
+		for _, ingress := range c.processedIngresses() {
+			if namespace != "" && namespace != ingress.Namespace {
+				continue
+			}
+			out = append(out, convertIngress(*ingress, c.domainSuffix)...)
 		}
-
-		if !shouldProcessIngress(c.mesh, ingress) {
-			continue
+	case model.Gateway.Type, model.VirtualService.Type:
+		// synthesized configs live in the ingress namespace
+		if namespace != "" && namespace != model.IstioIngressNamespace {
+			return out, nil
 		}
-
-		switch typ {
-		case model.VirtualService.Type:
-			_, virtualServices := ConvertIngressV1alpha3(*ingress, namespace)
-			out = append(out, virtualServices)
-		case model.Gateway.Type:
-			gateways, _ := ConvertIngressV1alpha3(*ingress, namespace)
-			out = append(out, gateways)
-		case model.IngressRule.Type:
-			rules := convertIngress(*ingress, c.domainSuffix)
-			out = append(out, rules...)
+		gateway, virtualServices := ConvertIngressesV1alpha3(c.processedIngresses(), c.domainSuffix)
+		if typ == model.VirtualService.Type {
+			out = append(out, virtualServices...)
+		} else if gateway != nil {
+			out = append(out, *gateway)
 		}
+	default:
+		return nil, errUnsupportedOp
 	}
 
 	return out, nil
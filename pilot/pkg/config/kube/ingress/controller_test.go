@@ -0,0 +1,103 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/api/extensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+func TestNotifySynthesized(t *testing.T) {
+	ingress := func(hosts ...string) *v1beta1.Ingress {
+		out := &v1beta1.Ingress{ObjectMeta: meta_v1.ObjectMeta{Name: "foo", Namespace: "ns1"}}
+		for _, host := range hosts {
+			out.Spec.Rules = append(out.Spec.Rules, v1beta1.IngressRule{
+				Host: host,
+				IngressRuleValue: v1beta1.IngressRuleValue{
+					HTTP: &v1beta1.HTTPIngressRuleValue{
+						Paths: []v1beta1.HTTPIngressPath{
+							{Path: "/.*", Backend: v1beta1.IngressBackend{ServiceName: "web", ServicePort: intstr.FromInt(80)}},
+						},
+					},
+				},
+			})
+		}
+		return out
+	}
+
+	var got []string
+	record := func(config model.Config, event model.Event) {
+		got = append(got, event.String()+" "+config.Name)
+	}
+	steps := []struct {
+		ingresses []*v1beta1.Ingress
+		want      []string
+	}{
+		{
+			ingresses: []*v1beta1.Ingress{ingress("a.com", "b.com")},
+			want: []string{
+				"add a.com" + IngressVirtualServiceSuffix,
+				"add b.com" + IngressVirtualServiceSuffix,
+			},
+		},
+		{
+			// b.com is dropped from the updated ingress
+			ingresses: []*v1beta1.Ingress{ingress("a.com", "c.com")},
+			want: []string{
+				"add c.com" + IngressVirtualServiceSuffix,
+				"delete b.com" + IngressVirtualServiceSuffix,
+			},
+		},
+		{
+			ingresses: nil,
+			want: []string{
+				"delete a.com" + IngressVirtualServiceSuffix,
+				"delete c.com" + IngressVirtualServiceSuffix,
+			},
+		},
+	}
+
+	synthesized := make(map[string]model.Config)
+	for i, step := range steps {
+		got = nil
+		_, virtualServices := ConvertIngressesV1alpha3(step.ingresses, "cluster.local")
+		synthesized = notifySynthesized(synthesized, virtualServices, record)
+		if !reflect.DeepEqual(got, step.want) {
+			t.Errorf("step %d: got events %v, want %v", i, got, step.want)
+		}
+	}
+
+	// the gateway is added once and deleted with the last ingress
+	got = nil
+	gateways := make(map[string]model.Config)
+	for _, ingresses := range [][]*v1beta1.Ingress{{ingress("a.com")}, {ingress("a.com", "b.com")}, nil} {
+		gateway, _ := ConvertIngressesV1alpha3(ingresses, "cluster.local")
+		var current []model.Config
+		if gateway != nil {
+			current = append(current, *gateway)
+		}
+		gateways = notifySynthesized(gateways, current, record)
+	}
+	want := []string{"add " + model.IstioIngressGatewayName, "delete " + model.IstioIngressGatewayName}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got gateway events %v, want %v", got, want)
+	}
+}
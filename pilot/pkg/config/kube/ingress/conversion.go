@@ -16,7 +16,9 @@ package ingress
 
 import (
 	"fmt"
+	"math"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -158,85 +160,157 @@ func decodeIngressRuleName(name string) (ingressName string, ruleNum, pathNum in
 	return
 }
 
-// ConvertIngressV1alpha3 converts from ingress spec to Istio Gateway + VirtualServices
-func ConvertIngressV1alpha3(ingress v1beta1.Ingress, domainSuffix string) (model.Config, model.Config) {
-	gateway := &networking.Gateway{
-		Selector: model.IstioIngressWorkloadLabels,
-	}
-
-	for _, tls := range ingress.Spec.TLS {
-		gateway.Servers = append(gateway.Servers, &networking.Server{
-			Port: &networking.Port{
-				Number:   443,
-				Protocol: string(model.ProtocolHTTPS),
-				Name:     "https-ingress-443",
-			},
-			Hosts: tls.Hosts,
-			// While we accept multiple certs, we expect them to be mounted in
-			// /etc/istio/certs/namespace/secretname/tls.crt|tls.key
-			Tls: &networking.Server_TLSOptions{
-				HttpsRedirect:     false,
-				Mode:              networking.Server_TLSOptions_SIMPLE,
-				ServerCertificate: path.Join(model.IngressCertsPath, ingress.Namespace, tls.SecretName, model.IngressCertFilename),
-				CaCertificates:    path.Join(model.IngressCertsPath, ingress.Namespace, tls.SecretName, model.IngressKeyFilename),
-			},
-		})
+// IngressVirtualServiceSuffix is appended to the names of virtual services
+// synthesized from Kubernetes ingress resources.
+const IngressVirtualServiceSuffix = "-istio-autogenerated-k8s-ingress"
+
+// ConvertIngressesV1alpha3 converts Kubernetes ingress resources to a single
+// Gateway selecting the Istio ingress workloads and one VirtualService per
+// ingress host bound to that gateway. Ingresses are merged since a gateway
+// workload can currently be bound to a single gateway only, and Envoy rejects
+// virtual hosts with overlapping domains. Each TLS secret is served by an HTTPS
+// server for the hosts it lists; hosts already served with another secret are
+// rejected. The gateway is nil if there are no ingresses.
+func ConvertIngressesV1alpha3(ingresses []*v1beta1.Ingress, domainSuffix string) (*model.Config, []model.Config) {
+	if len(ingresses) == 0 {
+		return nil, nil
 	}
 
-	gateway.Servers = append(gateway.Servers, &networking.Server{
-		Port: &networking.Port{
-			Number:   80,
-			Protocol: string(model.ProtocolHTTP),
-			Name:     "http-ingress-80",
-		},
+	sorted := make([]*v1beta1.Ingress, len(ingresses))
+	copy(sorted, ingresses)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
 	})
 
-	virtualService := &networking.VirtualService{
-		Hosts:    []string{"*"},
-		Gateways: []string{model.IstioIngressGatewayName},
+	gateway := &networking.Gateway{
+		Selector: model.IstioIngressWorkloadLabels,
+		Servers: []*networking.Server{{
+			Port: &networking.Port{
+				Number:   80,
+				Protocol: string(model.ProtocolHTTP),
+				Name:     "http-ingress-80",
+			},
+			Hosts: []string{"*"},
+		}},
 	}
 
-	var httpRoutes []*networking.HTTPRoute
-	for _, rule := range ingress.Spec.Rules {
-		if rule.HTTP == nil {
-			log.Infof("invalid ingress rule for host %q, no paths defined", rule.Host)
-			continue
-		}
-
-		for _, path := range rule.HTTP.Paths {
-			httpMatch := &networking.HTTPMatchRequest{
-				Uri: &networking.StringMatch{
-					MatchType: &networking.StringMatch_Regex{
-						Regex: path.Path,
-					},
+	var defaultRoute *networking.HTTPRoute
+	// secrets served for each host, as namespace/name
+	securedHosts := make(map[string]string)
+	routesByHost := make(map[string][]*networking.HTTPRoute)
+	for _, ingress := range sorted {
+		for _, tls := range ingress.Spec.TLS {
+			tlsHosts := tls.Hosts
+			if len(tlsHosts) == 0 {
+				tlsHosts = []string{"*"}
+			}
+			var hosts []string
+			for _, host := range tlsHosts {
+				if secret, exists := securedHosts[host]; exists {
+					log.Errorf("ingress %s/%s requires TLS secret %s for host %s, which is served with secret %s",
+						ingress.Namespace, ingress.Name, tls.SecretName, host, secret)
+					continue
+				}
+				securedHosts[host] = path.Join(ingress.Namespace, tls.SecretName)
+				hosts = append(hosts, host)
+			}
+			if len(hosts) == 0 {
+				continue
+			}
+			// Certificates are expected to be mounted in
+			// /etc/istio/ingress-certs/namespace/secretname/tls.crt|tls.key
+			certDir := path.Join(model.IngressCertsPath, ingress.Namespace, tls.SecretName)
+			gateway.Servers = append(gateway.Servers, &networking.Server{
+				Port: &networking.Port{
+					Number:   443,
+					Protocol: string(model.ProtocolHTTPS),
+					Name:     fmt.Sprintf("https-ingress-443-%d", len(gateway.Servers)),
 				},
-				Authority: &networking.StringMatch{
-					MatchType: &networking.StringMatch_Regex{
-						Regex: rule.Host,
-					},
+				Hosts: hosts,
+				Tls: &networking.Server_TLSOptions{
+					Mode:              networking.Server_TLSOptions_SIMPLE,
+					ServerCertificate: path.Join(certDir, model.IngressCertFilename),
+					PrivateKey:        path.Join(certDir, model.IngressKeyFilename),
 				},
+			})
+		}
+
+		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				log.Infof("invalid ingress rule for host %q, no paths defined", rule.Host)
+				continue
+			}
+			host := rule.Host
+			if host == "" {
+				host = "*"
+			}
+			for _, httpPath := range rule.HTTP.Paths {
+				httpRoute := ingressBackendToHTTPRoute(&httpPath.Backend, ingress.Namespace, domainSuffix)
+				httpRoute.Match = []*networking.HTTPMatchRequest{{
+					Uri: convertIngressPath(httpPath.Path),
+				}}
+				routesByHost[host] = append(routesByHost[host], httpRoute)
 			}
+		}
 
-			httpRoute := ingressBackendToHTTPRoute(&path.Backend)
-			if httpRoute == nil {
-				log.Infof("invalid ingress rule for host %q, no backend defined for path", rule.Host)
+		if ingress.Spec.Backend != nil {
+			if defaultRoute != nil {
+				log.Warnf("ingress %s/%s defines a default backend but one is already defined",
+					ingress.Namespace, ingress.Name)
 				continue
 			}
-			httpRoute.Match = []*networking.HTTPMatchRequest{httpMatch}
-			httpRoutes = append(httpRoutes, httpRoute)
+			defaultRoute = ingressBackendToHTTPRoute(ingress.Spec.Backend, ingress.Namespace, domainSuffix)
 		}
 	}
 
-	if ingress.Spec.Backend != nil {
-		httpRoutes = append(httpRoutes, ingressBackendToHTTPRoute(ingress.Spec.Backend))
+	// The default backend serves every request that does not match a path.
+	if defaultRoute != nil {
+		if _, exists := routesByHost["*"]; !exists {
+			routesByHost["*"] = nil
+		}
 	}
 
-	virtualService.Http = httpRoutes
+	hosts := make([]string, 0, len(routesByHost))
+	for host := range routesByHost {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	virtualServices := make([]model.Config, 0, len(hosts))
+	for _, host := range hosts {
+		routes := routesByHost[host]
+		// Envoy selects the first matching route, so more specific paths go first.
+		sort.SliceStable(routes, func(i, j int) bool {
+			return pathSpecificity(routes[i].Match[0].Uri) > pathSpecificity(routes[j].Match[0].Uri)
+		})
+		if defaultRoute != nil {
+			routes = append(routes, defaultRoute)
+		}
 
-	gatewayConfig := model.Config{
+		virtualServices = append(virtualServices, model.Config{
+			ConfigMeta: model.ConfigMeta{
+				Type:      model.VirtualService.Type,
+				Group:     crd.ResourceGroup(&model.VirtualService),
+				Version:   model.VirtualService.Version,
+				Name:      IngressVirtualServiceName(host),
+				Namespace: model.IstioIngressNamespace,
+				Domain:    domainSuffix,
+			},
+			Spec: &networking.VirtualService{
+				Hosts:    []string{host},
+				Gateways: []string{model.IstioIngressGatewayName},
+				Http:     routes,
+			},
+		})
+	}
+
+	gatewayConfig := &model.Config{
 		ConfigMeta: model.ConfigMeta{
 			Type:      model.Gateway.Type,
-			Group:     model.Gateway.Group,
+			Group:     crd.ResourceGroup(&model.Gateway),
 			Version:   model.Gateway.Version,
 			Name:      model.IstioIngressGatewayName,
 			Namespace: model.IstioIngressNamespace,
@@ -245,31 +319,49 @@ func ConvertIngressV1alpha3(ingress v1beta1.Ingress, domainSuffix string) (model
 		Spec: gateway,
 	}
 
-	virtualServiceConfig := model.Config{
-		ConfigMeta: model.ConfigMeta{
-			Type:      model.VirtualService.Type,
-			Group:     model.VirtualService.Group,
-			Version:   model.VirtualService.Version,
-			Name:      model.IstioIngressGatewayName,
-			Namespace: model.IstioIngressNamespace,
-			Domain:    domainSuffix,
-		},
-		Spec: virtualService,
-	}
-
-	return gatewayConfig, virtualServiceConfig
+	return gatewayConfig, virtualServices
+}
 
+// IngressVirtualServiceName returns the name of the virtual service
+// synthesized for an ingress host.
+func IngressVirtualServiceName(host string) string {
+	return strings.Replace(strings.ToLower(host), "*", "wildcard", -1) + IngressVirtualServiceSuffix
 }
 
-func ingressBackendToHTTPRoute(backend *v1beta1.IngressBackend) *networking.HTTPRoute {
-	if backend == nil {
-		return nil
+// convertIngressPath converts an ingress path to a URI match. Following the
+// v1 ingress rules, paths ending in ".*" match by prefix and other paths
+// match exactly; an empty path matches everything.
+func convertIngressPath(path string) *networking.StringMatch {
+	if path == "" {
+		return &networking.StringMatch{
+			MatchType: &networking.StringMatch_Prefix{Prefix: "/"},
+		}
 	}
+	if strings.HasSuffix(path, ".*") {
+		return &networking.StringMatch{
+			MatchType: &networking.StringMatch_Prefix{Prefix: strings.TrimSuffix(path, ".*")},
+		}
+	}
+	return &networking.StringMatch{
+		MatchType: &networking.StringMatch_Exact{Exact: path},
+	}
+}
 
-	port := &networking.PortSelector{
-		Port: nil,
+// pathSpecificity orders URI matches: exact matches before prefix matches,
+// and longer prefixes before shorter ones.
+func pathSpecificity(match *networking.StringMatch) int {
+	switch m := match.MatchType.(type) {
+	case *networking.StringMatch_Exact:
+		return math.MaxInt32
+	case *networking.StringMatch_Prefix:
+		return len(m.Prefix)
+	default:
+		return 0
 	}
+}
 
+func ingressBackendToHTTPRoute(backend *v1beta1.IngressBackend, namespace, domainSuffix string) *networking.HTTPRoute {
+	port := &networking.PortSelector{}
 	if backend.ServicePort.Type == intstr.Int {
 		port.Port = &networking.PortSelector_Number{
 			Number: uint32(backend.ServicePort.IntVal),
@@ -284,7 +376,9 @@ func ingressBackendToHTTPRoute(backend *v1beta1.IngressBackend) *networking.HTTP
 		Route: []*networking.DestinationWeight{
 			{
 				Destination: &networking.Destination{
-					Name: backend.ServiceName,
+					// The virtual service lives in the ingress namespace, so the
+					// backend service is referenced by its fully qualified name.
+					Name: fmt.Sprintf("%s.%s.svc.%s", backend.ServiceName, namespace, domainSuffix),
 					Port: port,
 				},
 				Weight: 100,
@@ -15,6 +15,7 @@
 package ingress
 
 import (
+	"reflect"
 	"testing"
 
 	"k8s.io/api/extensions/v1beta1"
@@ -22,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	meshconfig "istio.io/api/mesh/v1alpha1"
+	networking "istio.io/api/networking/v1alpha3"
 	"istio.io/istio/pilot/pkg/model"
 )
 
@@ -115,3 +117,141 @@ func TestIngressClass(t *testing.T) {
 		}
 	}
 }
+
+func TestConvertIngressesV1alpha3(t *testing.T) {
+	if gateway, virtualServices := ConvertIngressesV1alpha3(nil, "cluster.local"); gateway != nil || virtualServices != nil {
+		t.Fatalf("expected no configs without ingresses, got %v and %v", gateway, virtualServices)
+	}
+
+	ingresses := []*v1beta1.Ingress{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "bar", Namespace: "ns2"},
+			Spec: v1beta1.IngressSpec{
+				Rules: []v1beta1.IngressRule{{
+					Host: "my.host.com",
+					IngressRuleValue: v1beta1.IngressRuleValue{
+						HTTP: &v1beta1.HTTPIngressRuleValue{
+							Paths: []v1beta1.HTTPIngressPath{
+								{Path: "/api/.*", Backend: v1beta1.IngressBackend{ServiceName: "api", ServicePort: intstr.FromString("http")}},
+							},
+						},
+					},
+				}},
+			},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "foo", Namespace: "ns1"},
+			Spec: v1beta1.IngressSpec{
+				Backend: &v1beta1.IngressBackend{ServiceName: "default-http-backend", ServicePort: intstr.FromInt(80)},
+				TLS:     []v1beta1.IngressTLS{{Hosts: []string{"my.host.com"}, SecretName: "my-secret"}},
+				Rules: []v1beta1.IngressRule{{
+					Host: "my.host.com",
+					IngressRuleValue: v1beta1.IngressRuleValue{
+						HTTP: &v1beta1.HTTPIngressRuleValue{
+							Paths: []v1beta1.HTTPIngressPath{
+								{Path: "/.*", Backend: v1beta1.IngressBackend{ServiceName: "web", ServicePort: intstr.FromInt(8080)}},
+								{Path: "/login", Backend: v1beta1.IngressBackend{ServiceName: "auth", ServicePort: intstr.FromInt(80)}},
+							},
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	gateway, virtualServices := ConvertIngressesV1alpha3(ingresses, "cluster.local")
+	if gateway == nil || gateway.Name != model.IstioIngressGatewayName || gateway.Namespace != model.IstioIngressNamespace {
+		t.Fatalf("unexpected gateway %v", gateway)
+	}
+	servers := gateway.Spec.(*networking.Gateway).Servers
+	if len(servers) != 2 {
+		t.Fatalf("expected HTTP and HTTPS servers, got %v", servers)
+	}
+	tls := servers[1].Tls
+	if servers[1].Port.Number != 443 || tls == nil ||
+		tls.ServerCertificate != "/etc/istio/ingress-certs/ns1/my-secret/tls.crt" ||
+		tls.PrivateKey != "/etc/istio/ingress-certs/ns1/my-secret/tls.key" {
+		t.Errorf("unexpected HTTPS server %v", servers[1])
+	}
+
+	// one virtual service per host sorted by host, the wildcard one serving the
+	// default backend
+	if len(virtualServices) != 2 {
+		t.Fatalf("expected 2 virtual services, got %v", virtualServices)
+	}
+	host := virtualServices[1]
+	if host.Name != "my.host.com"+IngressVirtualServiceSuffix || host.Namespace != model.IstioIngressNamespace {
+		t.Errorf("unexpected virtual service %v", host.ConfigMeta)
+	}
+	spec := host.Spec.(*networking.VirtualService)
+	if len(spec.Gateways) != 1 || spec.Gateways[0] != model.IstioIngressGatewayName {
+		t.Errorf("unexpected gateways %v", spec.Gateways)
+	}
+	wantRoutes := []struct {
+		match       *networking.StringMatch
+		destination string
+	}{
+		{&networking.StringMatch{MatchType: &networking.StringMatch_Exact{Exact: "/login"}}, "auth.ns1.svc.cluster.local"},
+		{&networking.StringMatch{MatchType: &networking.StringMatch_Prefix{Prefix: "/api/"}}, "api.ns2.svc.cluster.local"},
+		{&networking.StringMatch{MatchType: &networking.StringMatch_Prefix{Prefix: "/"}}, "web.ns1.svc.cluster.local"},
+		{nil, "default-http-backend.ns1.svc.cluster.local"},
+	}
+	if len(spec.Http) != len(wantRoutes) {
+		t.Fatalf("expected %d routes, got %v", len(wantRoutes), spec.Http)
+	}
+	for i, want := range wantRoutes {
+		route := spec.Http[i]
+		if want.match != nil && (len(route.Match) != 1 || !reflect.DeepEqual(route.Match[0].Uri, want.match)) {
+			t.Errorf("route %d: got match %v, want %v", i, route.Match, want.match)
+		}
+		if got := route.Route[0].Destination.Name; got != want.destination {
+			t.Errorf("route %d: got destination %q, want %q", i, got, want.destination)
+		}
+	}
+
+	wildcard := virtualServices[0]
+	if wildcard.Name != "wildcard"+IngressVirtualServiceSuffix {
+		t.Errorf("unexpected virtual service %v", wildcard.ConfigMeta)
+	}
+	if routes := wildcard.Spec.(*networking.VirtualService).Http; len(routes) != 1 || routes[0].Match != nil {
+		t.Errorf("expected the default backend route only, got %v", routes)
+	}
+}
+
+func TestConvertIngressesV1alpha3TLS(t *testing.T) {
+	ingress := func(namespace, name string, tls ...v1beta1.IngressTLS) *v1beta1.Ingress {
+		return &v1beta1.Ingress{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1beta1.IngressSpec{
+				Backend: &v1beta1.IngressBackend{ServiceName: name, ServicePort: intstr.FromInt(80)},
+				TLS:     tls,
+			},
+		}
+	}
+	ingresses := []*v1beta1.Ingress{
+		ingress("ns1", "a", v1beta1.IngressTLS{Hosts: []string{"a.com"}, SecretName: "a-secret"}),
+		// a.com is already served with the secret of ns1/a
+		ingress("ns2", "b", v1beta1.IngressTLS{Hosts: []string{"b.com", "a.com"}, SecretName: "b-secret"}),
+		ingress("ns2", "c", v1beta1.IngressTLS{Hosts: []string{"a.com"}, SecretName: "c-secret"}),
+	}
+
+	gateway, _ := ConvertIngressesV1alpha3(ingresses, "cluster.local")
+	servers := gateway.Spec.(*networking.Gateway).Servers
+	if len(servers) != 3 {
+		t.Fatalf("expected an HTTP server and an HTTPS server per secret, got %v", servers)
+	}
+	want := []struct {
+		hosts       []string
+		certificate string
+	}{
+		{[]string{"a.com"}, "/etc/istio/ingress-certs/ns1/a-secret/tls.crt"},
+		{[]string{"b.com"}, "/etc/istio/ingress-certs/ns2/b-secret/tls.crt"},
+	}
+	for i, w := range want {
+		server := servers[i+1]
+		if server.Port.Number != 443 || server.Tls == nil || server.Tls.ServerCertificate != w.certificate ||
+			!reflect.DeepEqual(server.Hosts, w.hosts) {
+			t.Errorf("got HTTPS server %v, want hosts %v with certificate %s", server, w.hosts, w.certificate)
+		}
+	}
+}
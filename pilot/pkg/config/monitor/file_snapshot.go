@@ -20,6 +20,8 @@ import (
 	"path/filepath"
 	"sort"
 
+	"github.com/howeyc/fsnotify"
+
 	"istio.io/istio/pilot/pkg/config/kube/crd"
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pkg/log"
@@ -101,6 +103,67 @@ func (f *FileSnapshot) ReadConfigFiles() ([]*model.Config, error) {
 	return result, err
 }
 
+// Watch watches the root directory and all of its subdirectories for changes.
+// A value is sent on the returned channel after each change; notifications
+// are coalesced, so a slow reader observes at most one pending notification.
+// The watch is torn down when stop is closed.
+func (f *FileSnapshot) Watch(stop <-chan struct{}) (<-chan struct{}, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = watchDirs(watcher, f.root); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	notify := make(chan struct{}, 1)
+	go func() {
+		defer func() {
+			if err := watcher.Close(); err != nil {
+				log.Warnf("closing watcher for %s encounters an error %v", f.root, err)
+			}
+		}()
+		for {
+			select {
+			case ev := <-watcher.Event:
+				// fsnotify is not recursive, so new subdirectories need their own watch.
+				if ev.IsCreate() {
+					if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+						if err := watchDirs(watcher, ev.Name); err != nil {
+							log.Warnf("watching %s encounters an error %v", ev.Name, err)
+						}
+					}
+				}
+				select {
+				case notify <- struct{}{}:
+				default:
+				}
+			case err := <-watcher.Error:
+				log.Warnf("error watching %s: %v", f.root, err)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return notify, nil
+}
+
+// watchDirs adds a watch for root and every directory beneath it.
+func watchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		return watcher.Watch(path)
+	})
+}
+
 // parseInputs is identical to crd.ParseInputs, except that it returns an array of config pointers.
 func parseInputs(data []byte) ([]*model.Config, error) {
 	configs, _, err := crd.ParseInputs(string(data))
@@ -19,10 +19,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 
 	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/istio/pilot/pkg/config/memory"
 	"istio.io/istio/pilot/pkg/config/monitor"
 	"istio.io/istio/pilot/pkg/model"
 )
@@ -123,6 +125,68 @@ func TestFileSnapshotSorting(t *testing.T) {
 	g.Expect(configs[1].Spec).To(gomega.BeAssignableToTypeOf(&networking.VirtualService{}))
 }
 
+func TestFileSnapshotWatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	ts := &testState{}
+	ts.testSetup(t)
+	defer ts.testTeardown(t)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	fileWatcher := monitor.NewFileSnapshot(ts.rootPath, nil)
+	notify, err := fileWatcher.Watch(stop)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	err = ioutil.WriteFile(filepath.Join(ts.rootPath, "gateway.yml"), []byte(gatewayYAML), 0600)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Eventually(notify).Should(gomega.Receive())
+
+	// Files in directories created after the watch started are watched too.
+	subdir := filepath.Join(ts.rootPath, "overrides")
+	g.Expect(os.Mkdir(subdir, 0700)).To(gomega.Succeed())
+	g.Eventually(notify).Should(gomega.Receive())
+
+	// Give the watcher a moment to pick up the new directory.
+	time.Sleep(50 * time.Millisecond)
+	err = ioutil.WriteFile(filepath.Join(subdir, "vs.yml"), []byte(virtualServiceYAML), 0600)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Eventually(notify).Should(gomega.Receive())
+}
+
+func TestFileMonitor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	ts := &testState{
+		ConfigFiles: map[string][]byte{"gateway.yml": []byte(gatewayYAML)},
+	}
+	ts.testSetup(t)
+	defer ts.testTeardown(t)
+
+	store := memory.Make(model.ConfigDescriptor{model.Gateway, model.VirtualService})
+	// A long interval ensures that updates are driven by file events.
+	mon := monitor.NewFileMonitor(store, time.Hour, monitor.NewFileSnapshot(ts.rootPath, nil))
+	stop := make(chan struct{})
+	defer close(stop)
+	mon.Start(stop)
+
+	configs, err := store.List(model.Gateway.Type, "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(configs).To(gomega.HaveLen(1))
+
+	err = ioutil.WriteFile(filepath.Join(ts.rootPath, "vs.yml"), []byte(virtualServiceYAML), 0600)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Eventually(func() ([]model.Config, error) {
+		return store.List(model.VirtualService.Type, "")
+	}).Should(gomega.HaveLen(1))
+
+	g.Expect(os.Remove(filepath.Join(ts.rootPath, "gateway.yml"))).To(gomega.Succeed())
+	g.Eventually(func() ([]model.Config, error) {
+		return store.List(model.Gateway.Type, "")
+	}).Should(gomega.BeEmpty())
+}
+
 type testState struct {
 	ConfigFiles map[string][]byte
 	rootPath    string
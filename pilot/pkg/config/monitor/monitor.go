@@ -30,6 +30,10 @@ type Monitor struct {
 	checkDuration   time.Duration
 	configs         []*model.Config
 	getSnapshotFunc func() ([]*model.Config, error)
+
+	// watchFunc, if set, returns a channel that signals when the snapshot may
+	// have changed. Polling is only used if the watch cannot be established.
+	watchFunc func(stop <-chan struct{}) (<-chan struct{}, error)
 }
 
 // NewMonitor creates a Monitor and will delegate to a passed in controller.
@@ -44,10 +48,41 @@ func NewMonitor(delegateStore model.ConfigStore, checkInterval time.Duration, ge
 	return monitor
 }
 
+// NewFileMonitor creates a Monitor that updates the store from a FileSnapshot
+// whenever files under the snapshot root change. If the file system cannot be
+// watched, the Monitor falls back to polling every checkInterval.
+func NewFileMonitor(delegateStore model.ConfigStore, checkInterval time.Duration, snapshot *FileSnapshot) *Monitor {
+	monitor := NewMonitor(delegateStore, checkInterval, snapshot.ReadConfigFiles)
+	monitor.watchFunc = snapshot.Watch
+	return monitor
+}
+
 // Start starts a new Monitor. Immediately checks the Monitor getSnapshotFunc
 // and updates the controller. It then kicks off an asynchronous event loop that
-// periodically polls the getSnapshotFunc for changes until a close event is sent.
+// checks the getSnapshotFunc for changes, either on every watch notification or
+// periodically, until a close event is sent.
 func (m *Monitor) Start(stop chan struct{}) {
+	// Establish the watch before the initial check so that no change made
+	// in between is missed.
+	if m.watchFunc != nil {
+		notify, err := m.watchFunc(stop)
+		if err == nil {
+			m.checkAndUpdate()
+			go func() {
+				for {
+					select {
+					case <-stop:
+						return
+					case <-notify:
+						m.checkAndUpdate()
+					}
+				}
+			}()
+			return
+		}
+		log.Warnf("Failed to watch for config changes, falling back to polling: %v", err)
+	}
+
 	m.checkAndUpdate()
 	tick := time.NewTicker(m.checkDuration)
 
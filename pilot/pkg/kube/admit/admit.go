@@ -36,6 +36,7 @@ import (
 	clientadmissionregistrationv1beta1 "k8s.io/client-go/kubernetes/typed/admissionregistration/v1beta1"
 	"k8s.io/client-go/tools/cache"
 
+	"istio.io/istio/mixer/pkg/config/store"
 	"istio.io/istio/pilot/pkg/config/kube/crd"
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pkg/log"
@@ -97,6 +98,25 @@ type ControllerOptions struct {
 	// potential races where registration completes and k8s apiserver
 	// invokes the webhook before the HTTP server is started.
 	RegistrationDelay time.Duration
+
+	// MixerValidator, if set, validates the Mixer config kinds listed
+	// in MixerKinds, including type-checking of rule and instance
+	// expressions.
+	MixerValidator store.BackendValidator
+
+	// MixerKinds is the list of Mixer config kinds validated by
+	// MixerValidator.
+	MixerKinds []string
+
+	// ConfigStore, if set, provides the existing configuration used to
+	// detect conflicts between resources, e.g. two destination rules for
+	// the same host.
+	ConfigStore model.ConfigStore
+
+	// WarnOnly admits invalid configuration, logging the validation
+	// errors instead of rejecting the request. This is useful when
+	// rolling out validation to a cluster with existing config.
+	WarnOnly bool
 }
 
 // AdmissionController implements the external admission webhook for validation of
@@ -265,6 +285,14 @@ func (ac *AdmissionController) register(client clientadmissionregistrationv1beta
 			})
 	}
 
+	if ac.options.MixerValidator != nil && len(ac.options.MixerKinds) > 0 {
+		if rule, err := ac.mixerRule(); err != nil {
+			log.Warnf("Could not look up the Mixer CRDs, Mixer resources are not validated: %v", err)
+		} else {
+			rules = append(rules, rule)
+		}
+	}
+
 	webhook := &admissionregistrationv1beta1.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: ac.options.ExternalAdmissionWebhookName,
@@ -352,12 +380,24 @@ func watched(watchedNamespaces []string, namespace string) bool {
 	return false
 }
 
+// deny rejects the request, or admits it with a warning in warn-only mode.
+func (ac *AdmissionController) deny(request *admissionv1beta1.AdmissionRequest,
+	reason string, args ...interface{}) *admissionv1beta1.AdmissionResponse {
+	message := fmt.Sprintf(reason, args...)
+	if ac.options.WarnOnly {
+		log.Warnf("Admitting %s %s/%s in warn-only mode: %s",
+			request.Kind.Kind, request.Namespace, request.Name, message)
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+	result := apierrors.NewBadRequest(message).Status()
+	return &admissionv1beta1.AdmissionResponse{
+		Result: &result,
+	}
+}
+
 func (ac *AdmissionController) admit(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
-	makeErrorStatus := func(reason string, args ...interface{}) *admissionv1beta1.AdmissionResponse {
-		result := apierrors.NewBadRequest(fmt.Sprintf(reason, args...)).Status()
-		return &admissionv1beta1.AdmissionResponse{
-			Result: &result,
-		}
+	if ac.isMixerKind(request.Kind.Kind) {
+		return ac.admitMixer(request)
 	}
 
 	switch request.Operation {
@@ -369,7 +409,7 @@ func (ac *AdmissionController) admit(request *admissionv1beta1.AdmissionRequest)
 
 	var obj crd.IstioKind
 	if err := yaml.Unmarshal(request.Object.Raw, &obj); err != nil {
-		return makeErrorStatus("cannot decode configuration: %v", err)
+		return ac.deny(request, "cannot decode configuration: %v", err)
 	}
 
 	if !watched(ac.options.ValidateNamespaces, obj.Namespace) {
@@ -378,17 +418,36 @@ func (ac *AdmissionController) admit(request *admissionv1beta1.AdmissionRequest)
 
 	schema, exists := ac.options.Descriptor.GetByType(crd.CamelCaseToKabobCase(obj.Kind))
 	if !exists {
-		return makeErrorStatus("unrecognized type %v", obj.Kind)
+		return ac.deny(request, "unrecognized type %v", obj.Kind)
 	}
 
 	out, err := crd.ConvertObject(schema, &obj, ac.options.DomainSuffix)
 	if err != nil {
-		return makeErrorStatus("error decoding configuration: %v", err)
+		return ac.deny(request, "error decoding configuration: %v", err)
 	}
 
 	if err := schema.Validate(out.Spec); err != nil {
-		return makeErrorStatus("configuration is invalid: %v", err)
+		return ac.deny(request, "configuration is invalid: %v", err)
+	}
+
+	if err := ac.checkConflicts(out); err != nil {
+		return ac.deny(request, "configuration conflicts with existing configuration: %v", err)
 	}
 
 	return &admissionv1beta1.AdmissionResponse{Allowed: true}
 }
+
+// checkConflicts checks the config against the existing configs of the same
+// type in the config store.
+func (ac *AdmissionController) checkConflicts(config *model.Config) error {
+	check, exists := conflictChecks[config.Type]
+	if !exists || ac.options.ConfigStore == nil {
+		return nil
+	}
+	existing, err := ac.options.ConfigStore.List(config.Type, model.NamespaceAll)
+	if err != nil {
+		log.Warnf("Skipping conflict checks for %s: %v", config.Key(), err)
+		return nil
+	}
+	return check(*config, existing)
+}
@@ -36,7 +36,9 @@ import (
 
 	"os"
 
+	networking "istio.io/api/networking/v1alpha3"
 	"istio.io/istio/pilot/pkg/config/kube/crd"
+	"istio.io/istio/pilot/pkg/config/memory"
 	"istio.io/istio/pilot/pkg/kube/admit/testcerts"
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/pkg/model/test"
@@ -469,3 +471,108 @@ func TestGetAPIServerExtensionCACert(t *testing.T) {
 		t.Errorf("GetAPIServerExtensionCACert() failed: %v", err)
 	}
 }
+
+func TestAdmissionControllerWarnOnly(t *testing.T) {
+	invalid := makeConfig(t, watchedNamespace, 0, false)
+	testAdmissionController, err := NewController(nil, ControllerOptions{
+		Descriptor:         mock.Types,
+		ValidateNamespaces: []string{watchedNamespace},
+		DomainSuffix:       testDomainSuffix,
+		WarnOnly:           true,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	got := testAdmissionController.admit(&admissionv1beta1.AdmissionRequest{
+		Object: runtime.RawExtension{
+			Raw: invalid,
+		},
+		Operation: admissionv1beta1.Create,
+	})
+	if !got.Allowed {
+		t.Errorf("expected invalid config to be admitted in warn-only mode, got %v", got.Result)
+	}
+}
+
+func makeDestinationRule(t *testing.T, name, host string) model.Config {
+	t.Helper()
+	return model.Config{
+		ConfigMeta: model.ConfigMeta{
+			Type:      model.DestinationRule.Type,
+			Group:     "networking.istio.io",
+			Version:   model.DestinationRule.Version,
+			Name:      name,
+			Namespace: watchedNamespace,
+			Domain:    testDomainSuffix,
+		},
+		Spec: &networking.DestinationRule{
+			Name: host,
+		},
+	}
+}
+
+func TestAdmissionControllerConflicts(t *testing.T) {
+	configStore := memory.Make(model.IstioConfigTypes)
+	if _, err := configStore.Create(makeDestinationRule(t, "reviews", "reviews")); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		config  model.Config
+		allowed bool
+	}{
+		{
+			name:    "update of the existing rule",
+			config:  makeDestinationRule(t, "reviews", "reviews"),
+			allowed: true,
+		},
+		{
+			name:    "rule for another host",
+			config:  makeDestinationRule(t, "ratings", "ratings"),
+			allowed: true,
+		},
+		{
+			name:    "second rule for the same host",
+			config:  makeDestinationRule(t, "reviews-2", "reviews"),
+			allowed: false,
+		},
+		{
+			name:    "second rule for the same fully qualified host",
+			config:  makeDestinationRule(t, "reviews-3", "reviews."+watchedNamespace+".svc."+testDomainSuffix),
+			allowed: false,
+		},
+	}
+
+	testAdmissionController, err := NewController(nil, ControllerOptions{
+		Descriptor:         model.IstioConfigTypes,
+		ValidateNamespaces: []string{watchedNamespace},
+		DomainSuffix:       testDomainSuffix,
+		ConfigStore:        configStore,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for _, c := range cases {
+		obj, err := crd.ConvertConfig(model.DestinationRule, c.config)
+		if err != nil {
+			t.Fatalf("ConvertConfig(%v) failed: %v", c.config.Name, err)
+		}
+		raw, err := json.Marshal(&obj)
+		if err != nil {
+			t.Fatalf("Marshal(%v) failed: %v", c.config.Name, err)
+		}
+		got := testAdmissionController.admit(&admissionv1beta1.AdmissionRequest{
+			Object: runtime.RawExtension{
+				Raw: raw,
+			},
+			Operation: admissionv1beta1.Create,
+		})
+		if got.Allowed != c.allowed {
+			t.Errorf("%v: AdmissionResponse.Allowed is wrong : got %v want %v",
+				c.name, got.Allowed, c.allowed)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admit
+
+import (
+	"fmt"
+
+	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// conflictFunc reports an error if config conflicts with any of the existing
+// configs of the same type. The existing configs may include a previous
+// revision of config itself.
+type conflictFunc func(config model.Config, existing []model.Config) error
+
+// conflictChecks maps config types to their cross-resource conflict checks.
+var conflictChecks = map[string]conflictFunc{
+	model.DestinationRule.Type: destinationRuleConflict,
+}
+
+func sameResource(a, b model.ConfigMeta) bool {
+	return a.Type == b.Type && a.Name == b.Name && a.Namespace == b.Namespace
+}
+
+func destinationRuleHost(config model.Config) string {
+	rule := config.Spec.(*networking.DestinationRule)
+	return model.ResolveFQDN(rule.Name, config.Namespace+".svc."+config.Domain)
+}
+
+// destinationRuleConflict rejects a destination rule for a host that already
+// has a destination rule, since only one of them would take effect.
+func destinationRuleConflict(config model.Config, existing []model.Config) error {
+	host := destinationRuleHost(config)
+	for _, other := range existing {
+		if sameResource(config.ConfigMeta, other.ConfigMeta) {
+			continue
+		}
+		if destinationRuleHost(other) == host {
+			return fmt.Errorf("destination rule for host %q is already defined by %s/%s",
+				host, other.Namespace, other.Name)
+		}
+	}
+	return nil
+}
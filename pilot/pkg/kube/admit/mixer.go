@@ -0,0 +1,189 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admit
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+
+	adapterinventory "istio.io/istio/mixer/adapter"
+	mixerconfig "istio.io/istio/mixer/pkg/config"
+	"istio.io/istio/mixer/pkg/config/store"
+	"istio.io/istio/mixer/pkg/lang/checker"
+	runtimeconfig "istio.io/istio/mixer/pkg/runtime/config"
+	"istio.io/istio/mixer/pkg/runtime/validator"
+	"istio.io/istio/mixer/pkg/template"
+	generatedtemplates "istio.io/istio/mixer/template"
+	"istio.io/istio/pkg/log"
+)
+
+const (
+	// mixerAPIGroup is the API group of Mixer config resources.
+	mixerAPIGroup = "config.istio.io"
+
+	// mixerAPIVersion is the API version of Mixer config resources.
+	mixerAPIVersion = "v1alpha2"
+)
+
+// NewMixerValidator creates a validator for Mixer config kinds (rules,
+// handlers, instances and attribute manifests) backed by the Mixer config
+// store at configStoreURL. Rule match expressions and instance fields are
+// type-checked against the attribute manifests in the store. The returned
+// kinds are the Mixer kinds handled by the validator.
+func NewMixerValidator(configStoreURL, identityAttribute string) (store.BackendValidator, []string, error) {
+	infos := generatedtemplates.SupportedTmplInfo
+	templates := make(map[string]*template.Info, len(infos))
+	for k := range infos {
+		t := infos[k]
+		templates[k] = &t
+	}
+	adapters := mixerconfig.AdapterInfoMap(adapterinventory.Inventory(), template.NewRepository(infos).SupportsTemplate)
+	kinds := runtimeconfig.KindMap(adapters, templates)
+
+	s, err := store.NewRegistry(mixerconfig.StoreInventory()...).NewStore(configStoreURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	rv, err := validator.New(checker.NewTypeChecker(), identityAttribute, s, adapters, templates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(kinds))
+	for kind := range kinds {
+		names = append(names, kind)
+	}
+	return store.NewValidator(rv, kinds), names, nil
+}
+
+// mixerResourceNames returns the plural resource names of the Mixer kinds,
+// keyed by kind, as declared by the Mixer CRDs registered with the API
+// server. Kubernetes does not derive plurals, so they cannot be computed from
+// the kinds.
+func mixerResourceNames(d discovery.DiscoveryInterface) (map[string]string, error) {
+	resources, err := d.ServerResourcesForGroupVersion(mixerAPIGroup + "/" + mixerAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(resources.APIResources))
+	for _, res := range resources.APIResources {
+		// subresources are named <plural>/<subresource>
+		if strings.Contains(res.Name, "/") {
+			continue
+		}
+		names[res.Kind] = res.Name
+	}
+	return names, nil
+}
+
+// mixerRule returns the admission rule of the Mixer kinds validated by the
+// Mixer validator.
+func (ac *AdmissionController) mixerRule() (admissionregistrationv1beta1.RuleWithOperations, error) {
+	names, err := mixerResourceNames(ac.client.Discovery())
+	if err != nil {
+		return admissionregistrationv1beta1.RuleWithOperations{}, err
+	}
+	resources := make([]string, 0, len(ac.options.MixerKinds))
+	for _, kind := range ac.options.MixerKinds {
+		name, ok := names[kind]
+		if !ok {
+			log.Warnf("No CRD for Mixer kind %s, its resources are not validated", kind)
+			continue
+		}
+		resources = append(resources, name)
+	}
+	sort.Strings(resources)
+
+	return admissionregistrationv1beta1.RuleWithOperations{
+		Operations: []admissionregistrationv1beta1.OperationType{
+			admissionregistrationv1beta1.Create,
+			admissionregistrationv1beta1.Update,
+			admissionregistrationv1beta1.Delete,
+		},
+		Rule: admissionregistrationv1beta1.Rule{
+			APIGroups:   []string{mixerAPIGroup},
+			APIVersions: []string{mixerAPIVersion},
+			Resources:   resources,
+		},
+	}, nil
+}
+
+func (ac *AdmissionController) isMixerKind(kind string) bool {
+	if ac.options.MixerValidator == nil {
+		return false
+	}
+	for _, k := range ac.options.MixerKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// admitMixer validates Mixer config through the Mixer validator. Unlike
+// Pilot config, deletes are validated too so that resources still referenced
+// by rules are not removed.
+func (ac *AdmissionController) admitMixer(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	if !watched(ac.options.ValidateNamespaces, request.Namespace) {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+
+	ev := &store.BackendEvent{
+		Key: store.Key{
+			Kind:      request.Kind.Kind,
+			Namespace: request.Namespace,
+			Name:      request.Name,
+		},
+	}
+	switch request.Operation {
+	case admissionv1beta1.Create, admissionv1beta1.Update:
+		var obj unstructured.Unstructured
+		if err := yaml.Unmarshal(request.Object.Raw, &obj); err != nil {
+			return ac.deny(request, "cannot decode configuration: %v", err)
+		}
+		spec, _ := obj.UnstructuredContent()["spec"].(map[string]interface{})
+		ev.Type = store.Update
+		ev.Name = obj.GetName()
+		ev.Value = &store.BackEndResource{
+			Kind: request.Kind.Kind,
+			Metadata: store.ResourceMeta{
+				Name:        obj.GetName(),
+				Namespace:   obj.GetNamespace(),
+				Labels:      obj.GetLabels(),
+				Annotations: obj.GetAnnotations(),
+				Revision:    obj.GetResourceVersion(),
+			},
+			Spec: spec,
+		}
+	case admissionv1beta1.Delete:
+		if request.Name == "" {
+			return ac.deny(request, "illformed request: name not found on delete request")
+		}
+		ev.Type = store.Delete
+	default:
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+
+	if err := ac.options.MixerValidator.Validate(ev); err != nil {
+		return ac.deny(request, "configuration is invalid: %v", err)
+	}
+	return &admissionv1beta1.AdmissionResponse{Allowed: true}
+}
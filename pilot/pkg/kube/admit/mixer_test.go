@@ -0,0 +1,162 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admit
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"istio.io/istio/mixer/pkg/config/store"
+	"istio.io/istio/pilot/test/mock"
+)
+
+type fakeMixerValidator struct {
+	events []*store.BackendEvent
+	err    error
+}
+
+func (v *fakeMixerValidator) Validate(ev *store.BackendEvent) error {
+	v.events = append(v.events, ev)
+	return v.err
+}
+
+const ruleJSON = `{
+  "apiVersion": "config.istio.io/v1alpha2",
+  "kind": "rule",
+  "metadata": {"name": "deny", "namespace": "watched"},
+  "spec": {"match": "source.labels[\"app\"] == \"bad\"", "actions": [{"handler": "denier.denier"}]}
+}`
+
+func TestAdmitMixer(t *testing.T) {
+	cases := []struct {
+		name      string
+		operation admissionv1beta1.Operation
+		err       error
+		warnOnly  bool
+		allowed   bool
+		eventType store.ChangeType
+	}{
+		{
+			name:      "valid create",
+			operation: admissionv1beta1.Create,
+			allowed:   true,
+			eventType: store.Update,
+		},
+		{
+			name:      "invalid update",
+			operation: admissionv1beta1.Update,
+			err:       errors.New("bad match expression"),
+			allowed:   false,
+			eventType: store.Update,
+		},
+		{
+			name:      "invalid update in warn-only mode",
+			operation: admissionv1beta1.Update,
+			err:       errors.New("bad match expression"),
+			warnOnly:  true,
+			allowed:   true,
+			eventType: store.Update,
+		},
+		{
+			name:      "delete of a referenced resource",
+			operation: admissionv1beta1.Delete,
+			err:       errors.New("still referenced"),
+			allowed:   false,
+			eventType: store.Delete,
+		},
+	}
+
+	for _, c := range cases {
+		validator := &fakeMixerValidator{err: c.err}
+		testAdmissionController, err := NewController(nil, ControllerOptions{
+			Descriptor:         mock.Types,
+			ValidateNamespaces: []string{watchedNamespace},
+			DomainSuffix:       testDomainSuffix,
+			MixerValidator:     validator,
+			MixerKinds:         []string{"rule"},
+			WarnOnly:           c.warnOnly,
+		})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		request := &admissionv1beta1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Group: mixerAPIGroup, Version: mixerAPIVersion, Kind: "rule"},
+			Namespace: watchedNamespace,
+			Name:      "deny",
+			Operation: c.operation,
+		}
+		if c.operation != admissionv1beta1.Delete {
+			request.Object = runtime.RawExtension{Raw: []byte(ruleJSON)}
+		}
+
+		got := testAdmissionController.admit(request)
+		if got.Allowed != c.allowed {
+			t.Errorf("%v: AdmissionResponse.Allowed is wrong : got %v want %v", c.name, got.Allowed, c.allowed)
+		}
+		if len(validator.events) != 1 {
+			t.Fatalf("%v: expected the Mixer validator to be called once, got %d", c.name, len(validator.events))
+		}
+		ev := validator.events[0]
+		if ev.Type != c.eventType || ev.Kind != "rule" || ev.Name != "deny" || ev.Namespace != watchedNamespace {
+			t.Errorf("%v: unexpected event %+v", c.name, ev)
+		}
+		if ev.Type == store.Update && ev.Value.Spec["match"] == nil {
+			t.Errorf("%v: expected the rule spec to be passed to the validator", c.name)
+		}
+	}
+}
+
+func TestMixerRule(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: mixerAPIGroup + "/" + mixerAPIVersion,
+			APIResources: []metav1.APIResource{
+				{Name: "rules", SingularName: "rule", Kind: "rule", Namespaced: true},
+				{Name: "prometheuses", SingularName: "prometheus", Kind: "prometheus", Namespaced: true},
+				{Name: "listentries", SingularName: "listentry", Kind: "listentry", Namespaced: true},
+				{Name: "rules/status", Kind: "rule", Namespaced: true},
+			},
+		},
+	}
+
+	ac, err := NewController(client, ControllerOptions{
+		MixerValidator: &fakeMixerValidator{},
+		MixerKinds:     []string{"rule", "prometheus", "listentry", "unknown"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := ac.mixerRule()
+	if err != nil {
+		t.Fatalf("mixerRule() failed: %v", err)
+	}
+	// The plurals are the ones declared by the CRDs, and kinds without a CRD are left out.
+	want := []string{"listentries", "prometheuses", "rules"}
+	if !reflect.DeepEqual(rule.Resources, want) {
+		t.Errorf("mixerRule() resources = %v, want %v", rule.Resources, want)
+	}
+	if len(rule.Operations) != 3 {
+		t.Errorf("mixerRule() operations = %v, want create, update and delete", rule.Operations)
+	}
+}
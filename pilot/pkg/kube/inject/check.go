@@ -0,0 +1,175 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+
+	meshconfig "istio.io/api/mesh/v1alpha1"
+)
+
+// PatchOperation is a JSON patch (RFC 6902) operation.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// CheckResult explains the sidecar injection decision for a pod or a pod
+// template, and the changes the injector would make.
+type CheckResult struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// Inject reports whether the sidecar would be injected, and Reason why.
+	Inject bool   `json:"inject"`
+	Reason string `json:"reason"`
+
+	// Patch is the JSON patch the injector would apply.
+	Patch []PatchOperation `json:"patch,omitempty"`
+
+	// InjectedVersion is the sidecar template version recorded on an already
+	// injected pod. Stale reports whether it differs from the current
+	// template version.
+	InjectedVersion string `json:"injectedVersion,omitempty"`
+	Stale           bool   `json:"stale,omitempty"`
+}
+
+// CheckPod checks the injection of a pod against the injection config,
+// including whether a previously injected pod used a stale template.
+func CheckPod(config *Config, meshConfig *meshconfig.MeshConfig, pod *corev1.Pod) (*CheckResult, error) {
+	result := &CheckResult{
+		Kind:      "Pod",
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+	}
+	if err := checkTemplate(result, config, meshConfig, pod.ObjectMeta, pod.Spec); err != nil {
+		return nil, err
+	}
+
+	if _, injected := pod.Annotations[istioSidecarAnnotationStatusKey]; injected {
+		result.InjectedVersion = injectionStatus(pod).Version
+		result.Stale = result.InjectedVersion != sidecarTemplateVersionHash(config.Template)
+	}
+	return result, nil
+}
+
+// CheckResourceFile checks the injection of the pod templates in the
+// specified kubernetes YAML file. Resources without pod templates are
+// skipped.
+func CheckResourceFile(config *Config, meshConfig *meshconfig.MeshConfig, in io.Reader) ([]*CheckResult, error) {
+	var results []*CheckResult
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(in, 4096))
+	for {
+		raw, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err := fromRawToObject(raw)
+		if runtime.IsNotRegisteredError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		out, err := checkObject(config, meshConfig, obj)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, out...)
+	}
+	return results, nil
+}
+
+func checkObject(config *Config, meshConfig *meshconfig.MeshConfig, obj runtime.Object) ([]*CheckResult, error) {
+	if list, ok := obj.(*corev1.List); ok {
+		var results []*CheckResult
+		for _, item := range list.Items {
+			itemObj, err := fromRawToObject(item.Raw)
+			if runtime.IsNotRegisteredError(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			out, err := checkObject(config, meshConfig, itemObj)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, out...)
+		}
+		return results, nil
+	}
+
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	result := &CheckResult{
+		Kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+		Name:      accessor.GetName(),
+		Namespace: accessor.GetNamespace(),
+	}
+
+	metadata, podSpec := podTemplate(obj)
+	// pod templates inherit the namespace of their workload
+	templateMeta := *metadata
+	if templateMeta.Namespace == "" {
+		templateMeta.Namespace = accessor.GetNamespace()
+	}
+	if err := checkTemplate(result, config, meshConfig, templateMeta, *podSpec); err != nil {
+		return nil, err
+	}
+	return []*CheckResult{result}, nil
+}
+
+// checkTemplate records the injection decision and the patch the injector
+// would apply to a pod with the given metadata and spec.
+func checkTemplate(result *CheckResult, config *Config, meshConfig *meshconfig.MeshConfig,
+	metadata metav1.ObjectMeta, podSpec corev1.PodSpec) error {
+	result.Inject, result.Reason = injectionDecision(ignoredNamespaces, config.Policy, &podSpec, &metadata)
+	if !result.Inject {
+		return nil
+	}
+
+	spec, status, err := injectionData(config.Template, sidecarTemplateVersionHash(config.Template),
+		&podSpec, &metadata, meshConfig.DefaultConfig, meshConfig)
+	if err != nil {
+		return err
+	}
+	applyDefaultsWorkaround(spec.InitContainers, spec.Containers, spec.Volumes)
+
+	pod := &corev1.Pod{ObjectMeta: metadata, Spec: podSpec}
+	annotations := map[string]string{istioSidecarAnnotationStatusKey: status}
+	patch, err := createPatch(pod, injectionStatus(pod), annotations, spec)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(patch, &result.Patch)
+}
@@ -0,0 +1,130 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject
+
+import (
+	"os"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+func checkConfig(t *testing.T, policy InjectionPolicy) *Config {
+	t.Helper()
+	mesh := model.DefaultMeshConfig()
+	template, err := GenerateTemplateFromParams(&Params{
+		InitImage:       InitImageName(unitTestHub, unitTestTag, false),
+		ProxyImage:      ProxyImageName(unitTestHub, unitTestTag, false),
+		ImagePullPolicy: DefaultImagePullPolicy,
+		Verbosity:       DefaultVerbosity,
+		SidecarProxyUID: DefaultSidecarProxyUID,
+		Version:         "12345678",
+		Mesh:            &mesh,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Config{Policy: policy, Template: template}
+}
+
+func TestCheckResourceFile(t *testing.T) {
+	config := checkConfig(t, InjectionPolicyEnabled)
+	mesh := model.DefaultMeshConfig()
+
+	in, err := os.Open("testdata/hello-multi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = in.Close() }()
+
+	results, err := CheckResourceFile(config, &mesh, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected a result per deployment, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Kind != "Deployment" || !result.Inject || result.Reason == "" {
+			t.Errorf("unexpected result %+v", result)
+		}
+		var addsProxy bool
+		for _, op := range result.Patch {
+			if op.Op == "add" && op.Path == "/spec/containers/-" {
+				addsProxy = true
+			}
+		}
+		if !addsProxy {
+			t.Errorf("%s: expected the patch to add the proxy container, got %+v", result.Name, result.Patch)
+		}
+	}
+
+	in, err = os.Open("testdata/hello-host-network.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = in.Close() }()
+	if results, err = CheckResourceFile(config, &mesh, in); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Inject || len(results[0].Patch) != 0 {
+		t.Errorf("expected host network deployment not to be injected, got %+v", results)
+	}
+}
+
+func TestCheckPod(t *testing.T) {
+	config := checkConfig(t, InjectionPolicyDisabled)
+	mesh := model.DefaultMeshConfig()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hello",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "hello", Image: "hello"}},
+		},
+	}
+	result, err := CheckPod(config, &mesh, pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Inject || result.Stale {
+		t.Errorf("expected pod without annotation not to be injected, got %+v", result)
+	}
+
+	pod.Annotations = map[string]string{
+		istioSidecarAnnotationPolicyKey: "true",
+		istioSidecarAnnotationStatusKey: `{"version":"old","containers":["istio-proxy"]}`,
+	}
+	if result, err = CheckPod(config, &mesh, pod); err != nil {
+		t.Fatal(err)
+	}
+	if !result.Inject || !result.Stale || result.InjectedVersion != "old" {
+		t.Errorf("expected annotated pod with an old template to be stale, got %+v", result)
+	}
+
+	pod.Annotations[istioSidecarAnnotationStatusKey] = `{"version":"` +
+		sidecarTemplateVersionHash(config.Template) + `","containers":["istio-proxy"]}`
+	if result, err = CheckPod(config, &mesh, pod); err != nil {
+		t.Fatal(err)
+	}
+	if result.Stale {
+		t.Errorf("expected pod with the current template not to be stale, got %+v", result)
+	}
+}
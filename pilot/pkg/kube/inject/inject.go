@@ -74,6 +74,7 @@ const (
 	DefaultSidecarProxyUID = uint64(1337)
 	DefaultVerbosity       = 2
 	DefaultImagePullPolicy = "IfNotPresent"
+	DefaultStatusPort      = 15020
 )
 
 const (
@@ -144,6 +145,9 @@ type Params struct {
 	// Comma separated list of inbound ports for which traffic should not
 	// be redirected to Envoy.
 	ExcludeInboundPorts string `json:"excludeInboundPorts"`
+	// Port on which pilot-agent serves the readiness probe and drain
+	// endpoints of the sidecar. Both are disabled if 0.
+	StatusPort int `json:"statusPort"`
 }
 
 // Config specifies the sidecar injection configuration This includes
@@ -158,6 +162,13 @@ type Config struct {
 }
 
 func injectRequired(ignored []string, namespacePolicy InjectionPolicy, podSpec *corev1.PodSpec, metadata *metav1.ObjectMeta) bool { // nolint: lll
+	required, _ := injectionDecision(ignored, namespacePolicy, podSpec, metadata)
+	return required
+}
+
+// injectionDecision reports whether the sidecar should be injected and a
+// human readable reason for the decision.
+func injectionDecision(ignored []string, namespacePolicy InjectionPolicy, podSpec *corev1.PodSpec, metadata *metav1.ObjectMeta) (bool, string) { // nolint: lll
 	// Skip injection when host networking is enabled. The problem is
 	// that the iptable changes are assumed to be within the pod when,
 	// in fact, they are changing the routing at the host level. This
@@ -165,13 +176,13 @@ func injectRequired(ignored []string, namespacePolicy InjectionPolicy, podSpec *
 	// affect the network provider within the cluster causing
 	// additional pod failures.
 	if podSpec.HostNetwork {
-		return false
+		return false, "host networking is enabled"
 	}
 
 	// skip special kubernetes system namespaces
 	for _, namespace := range ignored {
 		if metadata.Namespace == namespace {
-			return false
+			return false, fmt.Sprintf("namespace %q is ignored", namespace)
 		}
 	}
 
@@ -182,7 +193,8 @@ func injectRequired(ignored []string, namespacePolicy InjectionPolicy, podSpec *
 
 	var useDefault bool
 	var inject bool
-	switch strings.ToLower(annotations[istioSidecarAnnotationPolicyKey]) {
+	annotation := annotations[istioSidecarAnnotationPolicyKey]
+	switch strings.ToLower(annotation) {
 	// http://yaml.org/type/bool.html
 	case "y", "yes", "true", "on":
 		inject = true
@@ -191,20 +203,20 @@ func injectRequired(ignored []string, namespacePolicy InjectionPolicy, podSpec *
 	}
 
 	var required bool
+	var reason string
 	switch namespacePolicy {
 	default: // InjectionPolicyOff
 		required = false
-	case InjectionPolicyDisabled:
+		reason = fmt.Sprintf("injection policy %q is off", namespacePolicy)
+	case InjectionPolicyDisabled, InjectionPolicyEnabled:
 		if useDefault {
-			required = false
-		} else {
-			required = inject
-		}
-	case InjectionPolicyEnabled:
-		if useDefault {
-			required = true
+			required = namespacePolicy == InjectionPolicyEnabled
+			reason = fmt.Sprintf("no %s annotation and injection policy is %q",
+				istioSidecarAnnotationPolicyKey, namespacePolicy)
 		} else {
 			required = inject
+			reason = fmt.Sprintf("annotation %s=%q overrides injection policy %q",
+				istioSidecarAnnotationPolicyKey, annotation, namespacePolicy)
 		}
 	}
 
@@ -213,7 +225,7 @@ func injectRequired(ignored []string, namespacePolicy InjectionPolicy, podSpec *
 	log.Debugf("Sidecar injection policy for %v/%v: namespacePolicy:%v useDefault:%v inject:%v status:%q required:%v",
 		metadata.Namespace, metadata.Name, namespacePolicy, useDefault, inject, status, required)
 
-	return required
+	return required, reason
 }
 
 func formatDuration(in *duration.Duration) string {
@@ -324,9 +336,6 @@ func fromRawToObject(raw []byte) (runtime.Object, error) {
 func intoObject(sidecarTemplate string, meshconfig *meshconfig.MeshConfig, in runtime.Object) (interface{}, error) {
 	out := in.DeepCopyObject()
 
-	var metadata *metav1.ObjectMeta
-	var podSpec *v1.PodSpec
-
 	// Handle Lists
 	if list, ok := out.(*v1.List); ok {
 		result := list
@@ -352,24 +361,7 @@ func intoObject(sidecarTemplate string, meshconfig *meshconfig.MeshConfig, in ru
 		return result, nil
 	}
 
-	// CronJobs have JobTemplates in them, instead of Templates, so we
-	// special case them.
-	if job, ok := out.(*v2alpha1.CronJob); ok {
-		metadata = &job.Spec.JobTemplate.ObjectMeta
-		podSpec = &job.Spec.JobTemplate.Spec.Template.Spec
-	} else {
-		// `in` is a pointer to an Object. Dereference it.
-		outValue := reflect.ValueOf(out).Elem()
-
-		templateValue := outValue.FieldByName("Spec").FieldByName("Template")
-		// `Template` is defined as a pointer in some older API
-		// definitions, e.g. ReplicationController
-		if templateValue.Kind() == reflect.Ptr {
-			templateValue = templateValue.Elem()
-		}
-		metadata = templateValue.FieldByName("ObjectMeta").Addr().Interface().(*metav1.ObjectMeta)
-		podSpec = templateValue.FieldByName("Spec").Addr().Interface().(*v1.PodSpec)
-	}
+	metadata, podSpec := podTemplate(out)
 
 	// Skip injection when host networking is enabled. The problem is
 	// that the iptable changes are assumed to be within the pod when,
@@ -405,6 +397,29 @@ func intoObject(sidecarTemplate string, meshconfig *meshconfig.MeshConfig, in ru
 	return out, nil
 }
 
+// podTemplate returns the pod template metadata and spec of a workload
+// resource.
+func podTemplate(obj runtime.Object) (*metav1.ObjectMeta, *v1.PodSpec) {
+	// CronJobs have JobTemplates in them, instead of Templates, so we
+	// special case them.
+	if job, ok := obj.(*v2alpha1.CronJob); ok {
+		return &job.Spec.JobTemplate.ObjectMeta, &job.Spec.JobTemplate.Spec.Template.Spec
+	}
+
+	// `obj` is a pointer to an Object. Dereference it.
+	objValue := reflect.ValueOf(obj).Elem()
+
+	templateValue := objValue.FieldByName("Spec").FieldByName("Template")
+	// `Template` is defined as a pointer in some older API
+	// definitions, e.g. ReplicationController
+	if templateValue.Kind() == reflect.Ptr {
+		templateValue = templateValue.Elem()
+	}
+	metadata := templateValue.FieldByName("ObjectMeta").Addr().Interface().(*metav1.ObjectMeta)
+	podSpec := templateValue.FieldByName("Spec").Addr().Interface().(*v1.PodSpec)
+	return metadata, podSpec
+}
+
 // GenerateTemplateFromParams generates a sidecar template from the legacy injection parameters
 func GenerateTemplateFromParams(params *Params) (string, error) {
 	t := template.New("inject").Delims(parameterizedTemplateDelimBegin, parameterizedTemplateDelimEnd)
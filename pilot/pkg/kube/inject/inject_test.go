@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	meshconfig "istio.io/api/mesh/v1alpha1"
 	"istio.io/istio/pilot/pkg/model"
@@ -225,3 +227,91 @@ func TestIntoResourceFile(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusPort(t *testing.T) {
+	mesh := model.DefaultMeshConfig()
+	params := &Params{
+		InitImage:       InitImageName(unitTestHub, unitTestTag, false),
+		ProxyImage:      ProxyImageName(unitTestHub, unitTestTag, false),
+		ImagePullPolicy: "IfNotPresent",
+		Verbosity:       DefaultVerbosity,
+		SidecarProxyUID: DefaultSidecarProxyUID,
+		Mesh:            &mesh,
+	}
+	withoutProbe, err := GenerateTemplateFromParams(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(withoutProbe, "statusPort") || strings.Contains(withoutProbe, "readinessProbe") {
+		t.Errorf("expected no status port or readiness probe when disabled:\n%s", withoutProbe)
+	}
+
+	params.StatusPort = DefaultStatusPort
+	sidecarTemplate, err := GenerateTemplateFromParams(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, _, err := injectionData(sidecarTemplate, sidecarTemplateVersionHash(sidecarTemplate),
+		&corev1.PodSpec{}, &metav1.ObjectMeta{}, mesh.DefaultConfig, &mesh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := spec.Containers[0]
+	args := strings.Join(proxy.Args, " ")
+	if !strings.Contains(args, "--statusPort 15020") {
+		t.Errorf("expected --statusPort 15020 in proxy args, got %q", args)
+	}
+	probe := proxy.ReadinessProbe
+	if probe == nil || probe.HTTPGet == nil {
+		t.Fatalf("expected an HTTP readiness probe, got %v", probe)
+	}
+	if probe.HTTPGet.Path != "/healthz/ready" || probe.HTTPGet.Port.IntValue() != DefaultStatusPort {
+		t.Errorf("unexpected readiness probe %v", probe.HTTPGet)
+	}
+}
+
+func TestTerminationGracePeriod(t *testing.T) {
+	mesh := model.DefaultMeshConfig()
+	params := &Params{
+		InitImage:       InitImageName(unitTestHub, unitTestTag, false),
+		ProxyImage:      ProxyImageName(unitTestHub, unitTestTag, false),
+		ImagePullPolicy: "IfNotPresent",
+		Verbosity:       DefaultVerbosity,
+		SidecarProxyUID: DefaultSidecarProxyUID,
+		StatusPort:      DefaultStatusPort,
+		Mesh:            &mesh,
+	}
+	sidecarTemplate, err := GenerateTemplateFromParams(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grace := int64(60)
+	cases := []struct {
+		name  string
+		grace *int64
+		want  string
+	}{
+		// pilot-agent defaults to the Kubernetes default of 30s
+		{name: "default", want: ""},
+		{name: "pod grace period", grace: &grace, want: "--terminationGracePeriod 60s"},
+	}
+
+	for _, c := range cases {
+		spec, _, err := injectionData(sidecarTemplate, sidecarTemplateVersionHash(sidecarTemplate),
+			&corev1.PodSpec{TerminationGracePeriodSeconds: c.grace}, &metav1.ObjectMeta{}, mesh.DefaultConfig, &mesh)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		args := strings.Join(spec.Containers[0].Args, " ")
+		if c.want == "" && strings.Contains(args, "--terminationGracePeriod") {
+			t.Errorf("%s: expected no --terminationGracePeriod in proxy args, got %q", c.name, args)
+		}
+		if c.want != "" && !strings.Contains(args, c.want) {
+			t.Errorf("%s: expected %s in proxy args, got %q", c.name, c.want, args)
+		}
+		if !strings.Contains(args, "--statusPort 15020") {
+			t.Errorf("%s: expected --statusPort 15020 in proxy args, got %q", c.name, args)
+		}
+	}
+}
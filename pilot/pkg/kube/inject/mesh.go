@@ -91,6 +91,14 @@ containers:
   - {{ .ProxyConfig.ProxyAdminPort }}
   - --controlPlaneAuthPolicy
   - {{ .ProxyConfig.ControlPlaneAuthPolicy }}
+  [[ if ne .StatusPort 0 -]]
+  - --statusPort
+  - [[ .StatusPort ]]
+  {{ if .Spec.TerminationGracePeriodSeconds -}}
+  - --terminationGracePeriod
+  - "{{ .Spec.TerminationGracePeriodSeconds }}s"
+  {{ end -}}
+  [[ end -]]
   env:
   - name: POD_NAME
     valueFrom:
@@ -109,6 +117,15 @@ containers:
   [[ else -]]
   imagePullPolicy: [[ .ImagePullPolicy ]]
   [[ end -]]
+  [[ if ne .StatusPort 0 -]]
+  readinessProbe:
+    httpGet:
+      path: /healthz/ready
+      port: [[ .StatusPort ]]
+    initialDelaySeconds: 1
+    periodSeconds: 2
+    failureThreshold: 30
+  [[ end -]]
   securityContext:
       [[ if eq .DebugMode true -]]
       privileged: true
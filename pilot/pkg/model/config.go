@@ -75,6 +75,11 @@ type ConfigMeta struct {
 	// An empty revision carries a special meaning that the associated object has
 	// not been stored and assigned a revision.
 	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// Source records the provenance of the config object when it is served
+	// by a layered store (e.g. "file" or "kubernetes"). It is not part of the
+	// unique key and is empty for objects read directly from a single store.
+	Source string `json:"source,omitempty"`
 }
 
 // Config is a configuration unit consisting of the type of configuration, the
@@ -44,6 +44,10 @@ type Environment struct {
 
 	// Mixer subject alternate name for mutual TLS
 	MixerSAN []string
+
+	// JwksResolver caches the public keys of JWT issuers. When nil, proxies
+	// fetch the keys from the issuers.
+	JwksResolver *JwksResolver
 }
 
 // Proxy defines the proxy attributes used by xDS identification
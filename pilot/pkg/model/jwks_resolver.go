@@ -0,0 +1,250 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	authn "istio.io/api/authentication/v1alpha1"
+	"istio.io/istio/pkg/log"
+)
+
+const (
+	// DefaultJwksRefreshInterval is the interval at which public keys are
+	// fetched again when the JWKS server sets no cache lifetime.
+	DefaultJwksRefreshInterval = 20 * time.Minute
+
+	// jwksFetchTimeout bounds each JWKS fetch.
+	jwksFetchTimeout = 5 * time.Second
+
+	// jwksRetryInterval is how long a failed fetch is cached before the
+	// keys are fetched again.
+	jwksRetryInterval = time.Minute
+)
+
+// errJwksPending is returned for keys that are still being fetched.
+var errJwksPending = errors.New("JWKS fetch in progress")
+
+// JwksResolver fetches and caches the public keys (JWKS) of JWT issuers, so
+// that proxies get the keys inlined in their config rather than fetching them
+// from the issuer.
+type JwksResolver struct {
+	client *http.Client
+
+	// refreshInterval is the cache lifetime of keys without Cache-Control.
+	refreshInterval time.Duration
+
+	// onRotation is called when fetched keys differ from the cached keys.
+	onRotation func()
+
+	mutex sync.Mutex
+	// keys by JWKS URI
+	keys map[string]*jwksEntry
+}
+
+// jwksEntry holds the keys of a JWKS URI, or the error of the last fetch
+// while no keys were ever fetched.
+type jwksEntry struct {
+	jwks    string
+	err     error
+	expires time.Time
+
+	// fetching is set while the first fetch is in progress.
+	fetching bool
+}
+
+// NewJwksResolver creates a JWKS resolver refreshing keys at the given
+// interval, unless the JWKS server sets a shorter max-age. onRotation is
+// called when the keys of an issuer are fetched for the first time or change.
+func NewJwksResolver(refreshInterval time.Duration, onRotation func()) *JwksResolver {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultJwksRefreshInterval
+	}
+	return &JwksResolver{
+		client:          &http.Client{Timeout: jwksFetchTimeout},
+		refreshInterval: refreshInterval,
+		onRotation:      onRotation,
+		keys:            make(map[string]*jwksEntry),
+	}
+}
+
+// GetPublicKey returns the cached JWKS served at jwksURI. It never blocks on
+// the issuer: keys are fetched in the background on first use, and an error
+// is returned until they are available. Failed fetches are cached and retried
+// by Run. onRotation is called once the keys are fetched.
+func (r *JwksResolver) GetPublicKey(jwksURI string) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if entry, exists := r.keys[jwksURI]; exists {
+		if entry.fetching {
+			return "", errJwksPending
+		}
+		if entry.err != nil {
+			return "", entry.err
+		}
+		return entry.jwks, nil
+	}
+
+	r.keys[jwksURI] = &jwksEntry{fetching: true}
+	go r.update(jwksURI)
+	return "", errJwksPending
+}
+
+// Prune drops the keys of the JWKS URIs that are not referenced, so that
+// they are no longer refreshed once the policies using them are deleted.
+func (r *JwksResolver) Prune(referenced map[string]bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for uri := range r.keys {
+		if !referenced[uri] {
+			delete(r.keys, uri)
+		}
+	}
+}
+
+// ReferencedJwksURIs returns the JWKS URIs of the Jwt specs of all the
+// authentication policies in the store.
+func ReferencedJwksURIs(store ConfigStore) (map[string]bool, error) {
+	configs, err := store.List(AuthenticationPolicy.Type, NamespaceAll)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]bool)
+	for _, config := range configs {
+		for _, spec := range CollectJwtSpecs(config.Spec.(*authn.Policy)) {
+			out[spec.JwksUri] = true
+		}
+	}
+	return out, nil
+}
+
+// Run refreshes the expired keys until stop is closed.
+func (r *JwksResolver) Run(stop <-chan struct{}) {
+	// check more often than the refresh interval so that shorter
+	// Cache-Control lifetimes are honored
+	ticker := time.NewTicker(r.refreshInterval / 10)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			r.refresh(time.Now())
+		}
+	}
+}
+
+// refresh fetches the keys expired at now, including failed fetches due for
+// a retry.
+func (r *JwksResolver) refresh(now time.Time) {
+	r.mutex.Lock()
+	var expired []string
+	for uri, entry := range r.keys {
+		if !entry.fetching && !now.Before(entry.expires) {
+			expired = append(expired, uri)
+		}
+	}
+	r.mutex.Unlock()
+
+	rotated := false
+	for _, uri := range expired {
+		rotated = r.store(uri) || rotated
+	}
+	r.notify(rotated)
+}
+
+// update fetches the keys of jwksURI and calls onRotation if they changed.
+func (r *JwksResolver) update(jwksURI string) {
+	r.notify(r.store(jwksURI))
+}
+
+// store fetches and caches the keys of jwksURI, and reports whether they
+// changed. Cached keys are kept when the fetch fails, so that proxies keep
+// them until the issuer is reachable again.
+func (r *JwksResolver) store(jwksURI string) bool {
+	entry, err := r.fetch(jwksURI)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	old := r.keys[jwksURI]
+	if old == nil {
+		// pruned while fetching
+		return false
+	}
+	if err != nil {
+		log.Warnf("Failed to fetch JWKS from %s: %v", jwksURI, err)
+		if !old.fetching && old.err == nil {
+			return false
+		}
+		r.keys[jwksURI] = &jwksEntry{err: err, expires: time.Now().Add(jwksRetryInterval)}
+		return false
+	}
+	r.keys[jwksURI] = entry
+	return old.jwks != entry.jwks
+}
+
+func (r *JwksResolver) notify(rotated bool) {
+	if rotated && r.onRotation != nil {
+		log.Infof("JWKS rotated, pushing config")
+		r.onRotation()
+	}
+}
+
+func (r *JwksResolver) fetch(jwksURI string) (*jwksEntry, error) {
+	resp, err := r.client.Get(jwksURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, jwksURI)
+	}
+
+	lifetime := r.refreshInterval
+	if maxAge, ok := parseMaxAge(resp.Header.Get("Cache-Control")); ok && maxAge < lifetime {
+		lifetime = maxAge
+	}
+	return &jwksEntry{
+		jwks:    string(body),
+		expires: time.Now().Add(lifetime),
+	}, nil
+}
+
+// parseMaxAge returns the max-age directive of a Cache-Control header.
+func parseMaxAge(cacheControl string) (time.Duration, bool) {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+		if !strings.HasPrefix(directive, "max-age=") {
+			continue
+		}
+		seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+		if err != nil || seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	return 0, false
+}
@@ -0,0 +1,198 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type jwksServer struct {
+	mutex        sync.Mutex
+	jwks         string
+	cacheControl string
+	fetches      int
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.fetches++
+	if s.cacheControl != "" {
+		w.Header().Set("Cache-Control", s.cacheControl)
+	}
+	fmt.Fprint(w, s.jwks)
+}
+
+func (s *jwksServer) set(jwks string) {
+	s.mutex.Lock()
+	s.jwks = jwks
+	s.mutex.Unlock()
+}
+
+func TestJwksResolver(t *testing.T) {
+	keys := &jwksServer{jwks: `{"keys": ["a"]}`, cacheControl: "public, max-age=60"}
+	server := httptest.NewServer(keys)
+	defer server.Close()
+
+	rotated := make(chan struct{}, 1)
+	r := NewJwksResolver(time.Hour, func() { rotated <- struct{}{} })
+
+	// keys are fetched in the background
+	if _, err := r.GetPublicKey(server.URL); err != errJwksPending {
+		t.Fatalf("expected the first lookup to be pending, got %v", err)
+	}
+	select {
+	case <-rotated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the keys to be fetched")
+	}
+	for i := 0; i < 2; i++ {
+		got, err := r.GetPublicKey(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != `{"keys": ["a"]}` {
+			t.Errorf("GetPublicKey() => %q", got)
+		}
+	}
+	if keys.fetches != 1 {
+		t.Errorf("expected keys to be fetched once, got %d fetches", keys.fetches)
+	}
+
+	// unchanged keys are not a rotation
+	r.refresh(time.Now().Add(2 * time.Minute))
+	if keys.fetches != 2 || len(rotated) != 0 {
+		t.Errorf("expected a refresh without rotation, got %d fetches and %d rotations", keys.fetches, len(rotated))
+	}
+
+	// the max-age is honored over the refresh interval
+	r.refresh(time.Now().Add(30 * time.Second))
+	if keys.fetches != 2 {
+		t.Errorf("expected cached keys to be kept, got %d fetches", keys.fetches)
+	}
+
+	keys.set(`{"keys": ["b"]}`)
+	r.refresh(time.Now().Add(2 * time.Minute))
+	if len(rotated) != 1 {
+		t.Errorf("expected a rotation, got %d", len(rotated))
+	}
+	if got, _ := r.GetPublicKey(server.URL); got != `{"keys": ["b"]}` {
+		t.Errorf("GetPublicKey() after rotation => %q", got)
+	}
+}
+
+func TestJwksResolverError(t *testing.T) {
+	keys := &jwksServer{jwks: `{"keys": ["a"]}`}
+	missing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		keys.mutex.Lock()
+		notFound := missing
+		keys.mutex.Unlock()
+		if notFound {
+			http.NotFound(w, req)
+			return
+		}
+		keys.ServeHTTP(w, req)
+	}))
+	defer server.Close()
+
+	r := NewJwksResolver(0, nil)
+	r.GetPublicKey(server.URL) // nolint: errcheck
+	var err error
+	for deadline := time.Now().Add(5 * time.Second); err == nil || err == errJwksPending; {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the fetch to fail")
+		}
+		time.Sleep(10 * time.Millisecond)
+		_, err = r.GetPublicKey(server.URL)
+	}
+
+	// the failure is cached until it is retried
+	if _, err := r.GetPublicKey(server.URL); err == nil || err == errJwksPending {
+		t.Errorf("expected the cached error, got %v", err)
+	}
+	keys.mutex.Lock()
+	missing = false
+	keys.mutex.Unlock()
+	r.refresh(time.Now())
+	if keys.fetches != 0 {
+		t.Errorf("expected the failure to be cached, got %d fetches", keys.fetches)
+	}
+	r.refresh(time.Now().Add(jwksRetryInterval))
+	if got, err := r.GetPublicKey(server.URL); err != nil || got != `{"keys": ["a"]}` {
+		t.Errorf("GetPublicKey() after retry => (%q, %v)", got, err)
+	}
+}
+
+func TestJwksResolverPrune(t *testing.T) {
+	keys := &jwksServer{jwks: `{"keys": ["a"]}`}
+	server := httptest.NewServer(keys)
+	defer server.Close()
+	used, unused := server.URL+"/used", server.URL+"/unused"
+
+	r := NewJwksResolver(time.Hour, nil)
+	for _, uri := range []string{used, unused} {
+		r.GetPublicKey(uri) // nolint: errcheck
+	}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		_, usedErr := r.GetPublicKey(used)
+		_, unusedErr := r.GetPublicKey(unused)
+		if usedErr == nil && unusedErr == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the keys to be fetched")
+		}
+	}
+
+	r.Prune(map[string]bool{used: true})
+	keys.mutex.Lock()
+	keys.fetches = 0
+	keys.mutex.Unlock()
+	r.refresh(time.Now().Add(2 * time.Hour))
+	if keys.fetches != 1 {
+		t.Errorf("expected only the referenced keys to be refreshed, got %d fetches", keys.fetches)
+	}
+	if _, err := r.GetPublicKey(used); err != nil {
+		t.Errorf("expected the referenced keys to be kept, got %v", err)
+	}
+	if _, err := r.GetPublicKey(unused); err != errJwksPending {
+		t.Errorf("expected the pruned keys to be fetched again, got %v", err)
+	}
+}
+
+func TestParseMaxAge(t *testing.T) {
+	cases := []struct {
+		in     string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"", 0, false},
+		{"no-cache", 0, false},
+		{"public, max-age=300", 300 * time.Second, true},
+		{"max-age=abc", 0, false},
+	}
+	for _, c := range cases {
+		got, ok := parseMaxAge(c.in)
+		if got != c.want || ok != c.wantOk {
+			t.Errorf("parseMaxAge(%q) => (%v, %v), want (%v, %v)", c.in, got, ok, c.want, c.wantOk)
+		}
+	}
+}
@@ -77,6 +77,10 @@ type Service struct {
 	// or use the passthrough model (i.e. proxy will forward the traffic to the network endpoint requested
 	// by the caller)
 	Resolution Resolution
+
+	// TraceSampling is the percentage of requests to the service that are
+	// randomly selected for tracing. Nil uses the mesh default.
+	TraceSampling *float64 `json:"traceSampling,omitempty"`
 }
 
 // Resolution indicates how the service instances need to be resolved before routing
@@ -74,6 +74,7 @@ func (configgen *ConfigGeneratorImpl) BuildClusters(env model.Environment, proxy
 
 		managementPorts := env.ManagementPorts(proxy.IPAddress)
 		clusters = append(clusters, configgen.buildInboundClusters(env, proxy, instances, managementPorts)...)
+		clusters = append(clusters, buildOutboundTrafficPolicyClusters(env.Mesh)...)
 	}
 
 	return clusters, nil // TODO: normalize/dedup/order
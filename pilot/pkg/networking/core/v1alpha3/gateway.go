@@ -73,14 +73,8 @@ func (configgen *ConfigGeneratorImpl) buildGatewayListeners(env model.Environmen
 	gateway := gateways[0].Spec.(*networking.Gateway)
 
 	listeners := make([]*xdsapi.Listener, 0, len(gateway.Servers))
-	listenerPortMap := make(map[uint32]bool)
+	listenerPortMap := make(map[uint32]*xdsapi.Listener)
 	for _, server := range gateway.Servers {
-
-		// TODO: this does not handle the case where there are two servers on same port
-		if listenerPortMap[server.Port.Number] {
-			log.Warnf("Multiple servers on same port is not supported yet, port %d", server.Port.Number)
-			continue
-		}
 		switch model.Protocol(server.Port.Protocol) {
 		case model.ProtocolHTTP, model.ProtocolHTTP2, model.ProtocolGRPC, model.ProtocolHTTPS:
 			opts := buildListenerOpts{
@@ -94,7 +88,7 @@ func (configgen *ConfigGeneratorImpl) buildGatewayListeners(env model.Environmen
 				tlsContext:     buildGatewayListenerTLSContext(server),
 				bindToPort:     true,
 				httpOpts: &httpListenerOpts{
-					routeConfig:      buildGatewayInboundHTTPRouteConfig(env, name, server),
+					routeConfig:      buildGatewayInboundHTTPRouteConfig(env, node, name, server),
 					rds:              "",
 					useRemoteAddress: true,
 					direction:        http_conn.EGRESS, // viewed as from gateway to internal
@@ -110,6 +104,17 @@ func (configgen *ConfigGeneratorImpl) buildGatewayListeners(env model.Environmen
 				}
 			}
 			l := buildListener(opts)
+			// TLS servers on the same port share a listener, with a filter
+			// chain per server selected by SNI
+			if existing, exists := listenerPortMap[server.Port.Number]; exists {
+				if server.Tls == nil || existing.FilterChains[0].TlsContext == nil {
+					log.Warnf("Multiple servers on same port are only supported with TLS, port %d", server.Port.Number)
+					continue
+				}
+				existing.FilterChains = append(existing.FilterChains, l.FilterChains...)
+				continue
+			}
+			listenerPortMap[server.Port.Number] = l
 			listeners = append(listeners, l)
 		case model.ProtocolTCP, model.ProtocolMongo:
 			// TODO
@@ -160,15 +165,27 @@ func buildGatewayListenerTLSContext(server *networking.Server) *auth.DownstreamT
 	}
 }
 
-func buildGatewayInboundHTTPRouteConfig(env model.Environment, gatewayName string,
+func buildGatewayInboundHTTPRouteConfig(env model.Environment, node model.Proxy, gatewayName string,
 	server *networking.Server) *xdsapi.RouteConfiguration {
+	services, err := env.Services()
+	if err != nil {
+		log.Warnf("Failed to get services for gateway %s: %v", gatewayName, err)
+	}
+	nameToServiceMap := make(map[string]*model.Service, len(services))
+	for _, svc := range services {
+		nameToServiceMap[svc.Hostname] = svc
+	}
+	serviceByName := TranslateServiceHostname(nameToServiceMap, node.Domain)
+
 	// TODO WE DO NOT SUPPORT two gateways on same workload binding to same virtual service
 	virtualServices := env.VirtualServices([]string{gatewayName})
 
 	virtualHosts := make([]route.VirtualHost, 0)
 	// TODO: Need to trim output based on source label/gateway match
 	for _, v := range virtualServices {
-		guardedRoute := TranslateRoutes(v, nil)
+		// destinations without a port default to the server port
+		clusterNaming := TranslateDestination(serviceByName, nil, v.Namespace, int(server.Port.Number))
+		guardedRoute := TranslateRoutes(v, clusterNaming)
 		var routes []route.Route
 		for _, g := range guardedRoute {
 			routes = append(routes, g.Route)
@@ -31,6 +31,7 @@ import (
 	accesslog "github.com/envoyproxy/go-control-plane/envoy/config/filter/accesslog/v2"
 	http_conn "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
 	tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/tcp_proxy/v2"
+	envoy_type "github.com/envoyproxy/go-control-plane/envoy/type"
 	xdsutil "github.com/envoyproxy/go-control-plane/pkg/util"
 
 	google_protobuf "github.com/gogo/protobuf/types"
@@ -69,8 +70,29 @@ var (
 	// Very verbose output in the logs - full LDS response logged for each sidecar.
 	// Use /debug/ldsz instead.
 	verboseDebug = os.Getenv("PILOT_DUMP_ALPHA3") != ""
+
+	// traceSampling is the mesh default percentage of requests randomly
+	// selected for tracing, for services setting no sampling of their own.
+	// TODO: read the default from MeshConfig and the per-service rate from
+	// DestinationRule once the pinned istio.io/api has fields for them. Until
+	// then the default comes from PILOT_TRACE_SAMPLING and the service rate
+	// from the alpha.istio.io/trace-sampling annotation of Kubernetes services.
+	traceSampling = parseTraceSampling(os.Getenv("PILOT_TRACE_SAMPLING"))
 )
 
+// parseTraceSampling parses a sampling percentage, which defaults to 100.
+func parseTraceSampling(value string) float64 {
+	if value == "" {
+		return 100
+	}
+	sampling, err := strconv.ParseFloat(value, 64)
+	if err != nil || sampling < 0 || sampling > 100 {
+		log.Warnf("Invalid trace sampling %q, sampling all requests", value)
+		return 100
+	}
+	return sampling
+}
+
 // ListenersALPNProtocols denotes the the list of ALPN protocols that the listener
 // should expose
 var ListenersALPNProtocols = []string{"h2", "http/1.1"}
@@ -135,11 +157,13 @@ func (configgen *ConfigGeneratorImpl) buildSidecarListeners(env model.Environmen
 			listeners = append(listeners, m)
 		}
 
-		// We need a dummy filter to fill in the filter stack for orig_dst listener
+		// Connections to destinations without a listener are handled by the
+		// outbound traffic policy. The stat prefix counts them per mode.
 		// TODO: Move to Listener filters and set up original dst filter there.
-		dummyTCPProxy := &tcp_proxy.TcpProxy{
-			StatPrefix: "Dummy",
-			Cluster:    "Dummy",
+		policyCluster := outboundTrafficPolicyCluster(outboundTrafficPolicy)
+		policyTCPProxy := &tcp_proxy.TcpProxy{
+			StatPrefix: policyCluster,
+			Cluster:    policyCluster,
 		}
 
 		// add an extra listener that binds to the port that is the recipient of the iptables redirect
@@ -152,7 +176,7 @@ func (configgen *ConfigGeneratorImpl) buildSidecarListeners(env model.Environmen
 					Filters: []listener.Filter{
 						{
 							Name:   xdsutil.TCPProxy,
-							Config: util.MessageToStruct(dummyTCPProxy),
+							Config: util.MessageToStruct(policyTCPProxy),
 						},
 					},
 				},
@@ -237,6 +261,10 @@ func (configgen *ConfigGeneratorImpl) buildSidecarInboundListeners(env model.Env
 				useRemoteAddress: false,
 				direction:        http_conn.INGRESS,
 				authnPolicy:      authenticationPolicy,
+				// requests arriving without a tracing decision, such as
+				// requests from outside the mesh, are sampled at the rate
+				// chosen for the service
+				traceSampling: instance.Service.TraceSampling,
 			}
 		case model.ProtocolTCP, model.ProtocolHTTPS, model.ProtocolMongo, model.ProtocolRedis:
 			listenerOpts.networkFilters = buildInboundNetworkFilters(instance)
@@ -349,6 +377,9 @@ func (configgen *ConfigGeneratorImpl) buildSidecarOutboundListeners(env model.En
 					useRemoteAddress: useRemoteAddress,
 					direction:        operation,
 					authnPolicy:      nil, /* authn policy is not needed for outbound listener */
+					// callers make the tracing decision for the services
+					// they call
+					traceSampling: outboundTraceSampling(services, servicePort.Port),
 				}
 			}
 
@@ -375,6 +406,34 @@ func (configgen *ConfigGeneratorImpl) buildSidecarOutboundListeners(env model.En
 	return append(tcpListeners, httpListeners...)
 }
 
+// outboundTraceSampling returns the trace sampling percentage of the outbound
+// HTTP listener on port, or nil for the mesh default. The services with an
+// HTTP port on port share the listener, which samples at the highest of their
+// rates so that no service is sampled below its own rate.
+func outboundTraceSampling(services []*model.Service, port int) *float64 {
+	var sampling *float64
+	custom := false
+	for _, service := range services {
+		for _, servicePort := range service.Ports {
+			if servicePort.Port != port || !servicePort.Protocol.IsHTTP() {
+				continue
+			}
+			rate := traceSampling
+			if service.TraceSampling != nil {
+				rate = *service.TraceSampling
+				custom = true
+			}
+			if sampling == nil || rate > *sampling {
+				sampling = &rate
+			}
+		}
+	}
+	if !custom {
+		return nil
+	}
+	return sampling
+}
+
 // buildMgmtPortListeners creates inbound TCP only listeners for the management ports on
 // server (inbound). Management port listeners are slightly different from standard Inbound listeners
 // in that, they do not have mixer filters nor do they have inbound auth.
@@ -471,6 +530,8 @@ type httpListenerOpts struct { //nolint: maligned
 	useRemoteAddress bool
 	direction        http_conn.HttpConnectionManager_Tracing_OperationName
 	authnPolicy      *authn.Policy
+	// traceSampling overrides the mesh default trace sampling percentage
+	traceSampling *float64
 }
 
 // options required to build a Listener
@@ -554,11 +615,6 @@ func buildHTTPConnectionManager(opts buildListenerOpts) *http_conn.HttpConnectio
 		refresh = 5 * time.Second
 	}
 
-	// TODO this has been explicitly disabled until the plugin stuff is implemented
-	//if filter := plugin_authn.BuildJwtFilter(opts.httpOpts.authnPolicy); filter != nil {
-	//	filters = append([]*http_conn.HttpFilter{filter}, filters...)
-	//}
-
 	connectionManager := &http_conn.HttpConnectionManager{
 		CodecType: http_conn.AUTO,
 		AccessLog: []*accesslog.AccessLog{
@@ -612,8 +668,13 @@ func buildHTTPConnectionManager(opts buildListenerOpts) *http_conn.HttpConnectio
 	}
 
 	if mesh.EnableTracing {
+		sampling := traceSampling
+		if opts.httpOpts.traceSampling != nil {
+			sampling = *opts.httpOpts.traceSampling
+		}
 		connectionManager.Tracing = &http_conn.HttpConnectionManager_Tracing{
-			OperationName: opts.httpOpts.direction,
+			OperationName:  opts.httpOpts.direction,
+			RandomSampling: &envoy_type.Percent{Value: sampling},
 		}
 		connectionManager.GenerateRequestId = &google_protobuf.BoolValue{true}
 	}
@@ -806,6 +867,12 @@ func (configgen *ConfigGeneratorImpl) buildSidecarOutboundHTTPRouteConfig(env mo
 		virtualHosts = vHostPortMap[port]
 	}
 
+	// requests for hosts outside the registry are handled by the outbound
+	// traffic policy, unless a virtual service already matches any host
+	if node.Type == model.Sidecar {
+		virtualHosts = appendOutboundTrafficPolicyVirtualHost(outboundTrafficPolicy, virtualHosts)
+	}
+
 	out := &xdsapi.RouteConfiguration{
 		Name:         fmt.Sprintf("%d", port),
 		VirtualHosts: virtualHosts,
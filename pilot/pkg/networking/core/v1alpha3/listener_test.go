@@ -0,0 +1,195 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha3
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	http_conn "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	xdsutil "github.com/envoyproxy/go-control-plane/pkg/util"
+	"github.com/gogo/protobuf/jsonpb"
+
+	authnproto "istio.io/api/authentication/v1alpha1"
+	rbacproto "istio.io/api/rbac/v1alpha1"
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/networking/plugin"
+	"istio.io/istio/pilot/pkg/networking/plugin/authn"
+	"istio.io/istio/pilot/pkg/networking/plugin/rbac"
+	"istio.io/istio/pilot/pkg/proxy/envoy/v1/mock"
+)
+
+func TestTraceSampling(t *testing.T) {
+	mesh := model.DefaultMeshConfig()
+	debug := 100.0
+	highQPS := 0.1
+
+	cases := []struct {
+		name     string
+		sampling *float64
+		want     float64
+	}{
+		{"mesh default", nil, traceSampling},
+		{"debug service", &debug, 100},
+		{"high QPS service", &highQPS, 0.1},
+	}
+	for _, c := range cases {
+		connectionManager := buildHTTPConnectionManager(buildListenerOpts{
+			env: model.Environment{Mesh: &mesh},
+			httpOpts: &httpListenerOpts{
+				routeConfig:   &xdsapi.RouteConfiguration{},
+				direction:     http_conn.INGRESS,
+				traceSampling: c.sampling,
+			},
+		})
+		if got := connectionManager.Tracing.RandomSampling.Value; got != c.want {
+			t.Errorf("%s: got sampling %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestOutboundTraceSampling(t *testing.T) {
+	debug := 100.0
+	highQPS := 0.1
+	service := func(port int, protocol model.Protocol, sampling *float64) *model.Service {
+		return &model.Service{
+			Ports:         model.PortList{{Port: port, Protocol: protocol}},
+			TraceSampling: sampling,
+		}
+	}
+
+	cases := []struct {
+		name     string
+		services []*model.Service
+		want     *float64
+	}{
+		{"mesh default", []*model.Service{service(80, model.ProtocolHTTP, nil)}, nil},
+		{"high QPS service", []*model.Service{service(80, model.ProtocolHTTP, &highQPS)}, &highQPS},
+		{"shared port", []*model.Service{
+			service(80, model.ProtocolHTTP, &highQPS),
+			service(80, model.ProtocolGRPC, &debug),
+		}, &debug},
+		{"other ports", []*model.Service{
+			service(80, model.ProtocolHTTP, &highQPS),
+			service(8080, model.ProtocolHTTP, &debug),
+			service(80, model.ProtocolTCP, &debug),
+		}, &highQPS},
+	}
+	for _, c := range cases {
+		got := outboundTraceSampling(c.services, 80)
+		if (got == nil) != (c.want == nil) || (got != nil && *got != *c.want) {
+			t.Errorf("%s: got sampling %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestParseTraceSampling(t *testing.T) {
+	cases := []struct {
+		in   string
+		want float64
+	}{
+		{"", 100},
+		{"1.5", 1.5},
+		{"0", 0},
+		{"150", 100},
+		{"all", 100},
+	}
+	for _, c := range cases {
+		if got := parseTraceSampling(c.in); got != c.want {
+			t.Errorf("parseTraceSampling(%q) => %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestInboundListenerJwtPrincipalFilters(t *testing.T) {
+	store := model.MakeIstioStore(memory.Make(model.IstioConfigTypes))
+	configs := []model.Config{
+		{
+			ConfigMeta: model.ConfigMeta{Type: model.AuthenticationPolicy.Type, Name: "jwt", Namespace: "default"},
+			Spec: &authnproto.Policy{
+				Origins: []*authnproto.OriginAuthenticationMethod{{
+					Jwt: &authnproto.Jwt{Issuer: "issuer", JwksUri: "http://issuer.com/keys"},
+				}},
+			},
+		},
+		{
+			ConfigMeta: model.ConfigMeta{Type: model.ServiceRole.Type, Name: "reader", Namespace: "default"},
+			Spec: &rbacproto.ServiceRole{
+				Rules: []*rbacproto.AccessRule{{Services: []string{"*"}, Methods: []string{"GET"}}},
+			},
+		},
+		{
+			ConfigMeta: model.ConfigMeta{Type: model.ServiceRoleBinding.Type, Name: "alice-reader", Namespace: "default"},
+			Spec: &rbacproto.ServiceRoleBinding{
+				Subjects: []*rbacproto.Subject{{User: "issuer/alice"}},
+				RoleRef:  &rbacproto.RoleRef{Kind: "ServiceRole", Name: "reader"},
+			},
+		},
+	}
+	for _, config := range configs {
+		if _, err := store.Create(config); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mesh := model.DefaultMeshConfig()
+	env := model.Environment{
+		ServiceDiscovery: mock.Discovery,
+		IstioConfigStore: store,
+		Mesh:             &mesh,
+	}
+	service := mock.HelloService
+	port := service.Ports[0]
+	l := buildListener(buildListenerOpts{
+		env:      env,
+		proxy:    mock.HelloProxyV0,
+		ip:       mock.HelloInstanceV0,
+		port:     port.Port,
+		protocol: port.Protocol,
+		httpOpts: &httpListenerOpts{
+			routeConfig: &xdsapi.RouteConfiguration{},
+			direction:   http_conn.INGRESS,
+		},
+	})
+	for _, p := range []plugin.Callbacks{authn.NewPlugin(), rbac.NewPlugin()} {
+		p.OnInboundListener(env, mock.HelloProxyV0, service, port, l)
+	}
+
+	// the authentication filter records the principal of the verified Jwt
+	// before the RBAC filter matches it
+	var names []string
+	var rbacConfig string
+	for _, value := range l.FilterChains[0].Filters[0].Config.Fields["http_filters"].GetListValue().Values {
+		fields := value.GetStructValue().GetFields()
+		name := fields["name"].GetStringValue()
+		names = append(names, name)
+		if name == "envoy.filters.http.rbac" {
+			var err error
+			if rbacConfig, err = (&jsonpb.Marshaler{}).MarshalToString(fields["config"]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	want := []string{xdsutil.CORS, "jwt-auth", authn.AuthnFilterName, "envoy.filters.http.rbac", xdsutil.Router}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("got filters %v, want %v", names, want)
+	}
+	if !strings.Contains(rbacConfig, `"filter":"`+authn.AuthnFilterName+`"`) {
+		t.Errorf("expected the RBAC filter to match the principal recorded by %s, got %s", authn.AuthnFilterName, rbacConfig)
+	}
+}
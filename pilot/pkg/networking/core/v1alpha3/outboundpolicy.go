@@ -0,0 +1,172 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha3
+
+import (
+	"os"
+
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	"github.com/gogo/protobuf/types"
+
+	meshconfig "istio.io/api/mesh/v1alpha1"
+	"istio.io/istio/pilot/pkg/networking/util"
+	"istio.io/istio/pkg/log"
+)
+
+// OutboundTrafficPolicyMode is how sidecars handle traffic to destinations
+// outside the service registry.
+type OutboundTrafficPolicyMode string
+
+const (
+	// UnsetOutboundTrafficPolicy keeps the behavior preceding the outbound
+	// traffic policy: HTTP requests to unknown hosts on service ports get a
+	// 404 and connections to other destinations are closed. It is the default
+	// until an operator opts in to one of the modes below.
+	UnsetOutboundTrafficPolicy OutboundTrafficPolicyMode = ""
+
+	// RegistryOnly blocks traffic to destinations outside the registry
+	RegistryOnly OutboundTrafficPolicyMode = "REGISTRY_ONLY"
+
+	// AllowAny forwards traffic to destinations outside the registry to
+	// their original destination
+	AllowAny OutboundTrafficPolicyMode = "ALLOW_ANY"
+)
+
+const (
+	// PassthroughCluster forwards traffic to unknown destinations to their
+	// original destination, in the ALLOW_ANY outbound traffic policy mode
+	PassthroughCluster = "PassthroughCluster"
+
+	// BlackHoleCluster drops traffic to unknown destinations, in the
+	// REGISTRY_ONLY outbound traffic policy mode
+	BlackHoleCluster = "BlackHoleCluster"
+
+	// allowAnyVirtualHost and blockAllVirtualHost catch HTTP requests for
+	// hosts outside the registry. Their virtual cluster of the same name
+	// counts these requests.
+	allowAnyVirtualHost = "allow_any"
+	blockAllVirtualHost = "block_all"
+)
+
+// outboundTrafficPolicy is the mesh-wide outbound traffic policy mode, set
+// with PILOT_OUTBOUND_TRAFFIC_POLICY. It is unset by default.
+// TODO: read the mode from MeshConfig once the pinned istio.io/api has it.
+var outboundTrafficPolicy = parseOutboundTrafficPolicy(os.Getenv("PILOT_OUTBOUND_TRAFFIC_POLICY"))
+
+// parseOutboundTrafficPolicy parses an outbound traffic policy mode. Unknown
+// modes fall back to the default, which neither opens egress nor changes the
+// responses of existing meshes.
+func parseOutboundTrafficPolicy(value string) OutboundTrafficPolicyMode {
+	switch mode := OutboundTrafficPolicyMode(value); mode {
+	case AllowAny, RegistryOnly, UnsetOutboundTrafficPolicy:
+		return mode
+	default:
+		log.Warnf("Unknown outbound traffic policy %q, using the default", value)
+		return UnsetOutboundTrafficPolicy
+	}
+}
+
+// outboundTrafficPolicyCluster returns the cluster receiving connections to
+// destinations without an outbound listener. Without a mode they are closed,
+// as they are in REGISTRY_ONLY mode.
+func outboundTrafficPolicyCluster(mode OutboundTrafficPolicyMode) string {
+	if mode == AllowAny {
+		return PassthroughCluster
+	}
+	return BlackHoleCluster
+}
+
+// buildOutboundTrafficPolicyClusters builds the passthrough and blackhole
+// clusters. Both are always sent so that switching the mode only updates
+// listeners and routes. The Envoy stats of these clusters count the egress
+// attempts to destinations outside the registry.
+func buildOutboundTrafficPolicyClusters(mesh *meshconfig.MeshConfig) []*v2.Cluster {
+	connectTimeout := util.ConvertGogoDurationToDuration(&types.Duration{
+		Seconds: mesh.ConnectTimeout.Seconds,
+		Nanos:   mesh.ConnectTimeout.Nanos,
+	})
+	return []*v2.Cluster{
+		{
+			Name:           PassthroughCluster,
+			Type:           v2.Cluster_ORIGINAL_DST,
+			LbPolicy:       v2.Cluster_ORIGINAL_DST_LB,
+			ConnectTimeout: connectTimeout,
+		},
+		{
+			// a static cluster without hosts fails every request
+			Name:           BlackHoleCluster,
+			Type:           v2.Cluster_STATIC,
+			ConnectTimeout: connectTimeout,
+		},
+	}
+}
+
+// buildOutboundTrafficPolicyVirtualHost builds the virtual host for HTTP
+// requests to hosts outside the registry. In ALLOW_ANY mode they are
+// forwarded to the original destination, otherwise they get a 502.
+func buildOutboundTrafficPolicyVirtualHost(mode OutboundTrafficPolicyMode) route.VirtualHost {
+	if mode == AllowAny {
+		return route.VirtualHost{
+			Name:    allowAnyVirtualHost,
+			Domains: []string{"*"},
+			Routes: []route.Route{{
+				Match: route.RouteMatch{PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"}},
+				Action: &route.Route_Route{
+					Route: &route.RouteAction{
+						ClusterSpecifier: &route.RouteAction_Cluster{Cluster: PassthroughCluster},
+					},
+				},
+			}},
+			VirtualClusters: []*route.VirtualCluster{{Name: allowAnyVirtualHost, Pattern: ".*"}},
+		}
+	}
+	return route.VirtualHost{
+		Name:    blockAllVirtualHost,
+		Domains: []string{"*"},
+		Routes: []route.Route{{
+			Match: route.RouteMatch{PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"}},
+			Action: &route.Route_DirectResponse{
+				DirectResponse: &route.DirectResponseAction{Status: 502},
+			},
+		}},
+		VirtualClusters: []*route.VirtualCluster{{Name: blockAllVirtualHost, Pattern: ".*"}},
+	}
+}
+
+// appendOutboundTrafficPolicyVirtualHost adds the virtual host handling HTTP
+// requests to hosts outside the registry in the mode, unless a virtual host
+// already matches any host. Without a mode no virtual host is added, so that
+// such requests keep getting a 404.
+func appendOutboundTrafficPolicyVirtualHost(mode OutboundTrafficPolicyMode,
+	virtualHosts []route.VirtualHost) []route.VirtualHost {
+	if mode == UnsetOutboundTrafficPolicy || hasWildcardVirtualHost(virtualHosts) {
+		return virtualHosts
+	}
+	return append(virtualHosts, buildOutboundTrafficPolicyVirtualHost(mode))
+}
+
+// hasWildcardVirtualHost returns whether a virtual host already matches any
+// host, in which case no catch-all virtual host can be added.
+func hasWildcardVirtualHost(virtualHosts []route.VirtualHost) bool {
+	for _, vhost := range virtualHosts {
+		for _, domain := range vhost.Domains {
+			if domain == "*" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha3
+
+import (
+	"testing"
+
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+func TestOutboundTrafficPolicyDefault(t *testing.T) {
+	if outboundTrafficPolicy != UnsetOutboundTrafficPolicy {
+		t.Fatalf("expected no outbound traffic policy by default, got %q", outboundTrafficPolicy)
+	}
+	if got := outboundTrafficPolicyCluster(outboundTrafficPolicy); got != BlackHoleCluster {
+		t.Errorf("got cluster %q for unknown destinations, want %q", got, BlackHoleCluster)
+	}
+
+	// requests to unknown hosts keep getting a 404
+	vhosts := []route.VirtualHost{{Name: "foo.com:80", Domains: []string{"foo.com", "foo.com:80"}}}
+	if got := appendOutboundTrafficPolicyVirtualHost(outboundTrafficPolicy, vhosts); len(got) != 1 {
+		t.Errorf("expected no catch-all virtual host by default, got %v", got)
+	}
+	if got := appendOutboundTrafficPolicyVirtualHost(RegistryOnly, vhosts); len(got) != 2 || got[1].Name != blockAllVirtualHost {
+		t.Errorf("expected a block_all virtual host in REGISTRY_ONLY mode, got %v", got)
+	}
+	wildcard := []route.VirtualHost{{Name: "all", Domains: []string{"*"}}}
+	if got := appendOutboundTrafficPolicyVirtualHost(AllowAny, wildcard); len(got) != 1 {
+		t.Errorf("expected no catch-all virtual host next to a wildcard virtual host, got %v", got)
+	}
+}
+
+func TestOutboundTrafficPolicyAllowAny(t *testing.T) {
+	if got := outboundTrafficPolicyCluster(AllowAny); got != PassthroughCluster {
+		t.Errorf("got cluster %q for unknown destinations, want %q", got, PassthroughCluster)
+	}
+
+	vhost := buildOutboundTrafficPolicyVirtualHost(AllowAny)
+	if vhost.Name != allowAnyVirtualHost || len(vhost.VirtualClusters) != 1 {
+		t.Errorf("expected an allow_any virtual host counting requests, got %v", vhost)
+	}
+	action, ok := vhost.Routes[0].Action.(*route.Route_Route)
+	if !ok || action.Route.GetCluster() != PassthroughCluster {
+		t.Errorf("expected requests to be forwarded to %s, got %v", PassthroughCluster, vhost.Routes[0].Action)
+	}
+}
+
+func TestOutboundTrafficPolicyRegistryOnly(t *testing.T) {
+	if got := outboundTrafficPolicyCluster(RegistryOnly); got != BlackHoleCluster {
+		t.Errorf("got cluster %q for unknown destinations, want %q", got, BlackHoleCluster)
+	}
+
+	vhost := buildOutboundTrafficPolicyVirtualHost(RegistryOnly)
+	if vhost.Name != blockAllVirtualHost || len(vhost.VirtualClusters) != 1 {
+		t.Errorf("expected a block_all virtual host counting requests, got %v", vhost)
+	}
+	action, ok := vhost.Routes[0].Action.(*route.Route_DirectResponse)
+	if !ok || action.DirectResponse.Status != 502 {
+		t.Errorf("expected a 502 response, got %v", vhost.Routes[0].Action)
+	}
+}
+
+func TestParseOutboundTrafficPolicy(t *testing.T) {
+	cases := []struct {
+		in   string
+		want OutboundTrafficPolicyMode
+	}{
+		{"", UnsetOutboundTrafficPolicy},
+		{"REGISTRY_ONLY", RegistryOnly},
+		{"ALLOW_ANY", AllowAny},
+		{"allow_any", UnsetOutboundTrafficPolicy},
+	}
+	for _, c := range cases {
+		if got := parseOutboundTrafficPolicy(c.in); got != c.want {
+			t.Errorf("parseOutboundTrafficPolicy(%q) => %s, want %s", c.in, got, c.want)
+		}
+	}
+}
+
+func TestBuildOutboundTrafficPolicyClusters(t *testing.T) {
+	mesh := model.DefaultMeshConfig()
+	clusters := buildOutboundTrafficPolicyClusters(&mesh)
+	if len(clusters) != 2 || clusters[0].Name != PassthroughCluster || clusters[1].Name != BlackHoleCluster {
+		t.Fatalf("expected the passthrough and blackhole clusters, got %v", clusters)
+	}
+	for _, c := range clusters {
+		if c.ConnectTimeout == 0 {
+			t.Errorf("cluster %s has no connect timeout", c.Name)
+		}
+	}
+	if len(clusters[1].Hosts) != 0 {
+		t.Errorf("expected the blackhole cluster to have no hosts")
+	}
+}
+
+func TestHasWildcardVirtualHost(t *testing.T) {
+	if hasWildcardVirtualHost([]route.VirtualHost{{Domains: []string{"foo.com", "foo.com:80"}}}) {
+		t.Errorf("expected no wildcard virtual host")
+	}
+	if !hasWildcardVirtualHost([]route.VirtualHost{{Domains: []string{"foo.com"}}, {Domains: []string{"*"}}}) {
+		t.Errorf("expected a wildcard virtual host")
+	}
+}
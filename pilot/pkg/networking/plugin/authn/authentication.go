@@ -19,6 +19,7 @@ import (
 	"github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
 	"github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	http_conn "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	"github.com/gogo/protobuf/types"
 
 	authn "istio.io/api/authentication/v1alpha1"
 	"istio.io/istio/pilot/pkg/model"
@@ -31,6 +32,13 @@ const (
 	// as the name defined in
 	// https://github.com/istio/proxy/blob/master/src/envoy/http/jwt_auth/http_filter_factory.cc#L50
 	jwtFilterName = "jwt-auth"
+
+	// AuthnFilterName is the name of the Istio authentication filter. It
+	// authenticates requests with the policy, taking the origin from the
+	// payload the Jwt filter verified, and records the authenticated
+	// principals, such as request.auth.principal, as dynamic metadata under
+	// its name.
+	AuthnFilterName = "istio_authn"
 )
 
 // Plugin implements Istio mTLS auth
@@ -41,18 +49,6 @@ func NewPlugin() plugin.Callbacks {
 	return Plugin{}
 }
 
-// BuildJwtFilter returns a Jwt filter for all Jwt specs in the policy.
-func BuildJwtFilter(policy *authn.Policy) *http_conn.HttpFilter {
-	filterConfigProto := model.ConvertPolicyToJwtConfig(policy)
-	if filterConfigProto == nil {
-		return nil
-	}
-	return &http_conn.HttpFilter{
-		Name:   jwtFilterName,
-		Config: util.MessageToStruct(filterConfigProto),
-	}
-}
-
 // OnOutboundListener is called whenever a new outbound listener is added to the LDS output for a given service
 // Can be used to add additional filters on the outbound path
 func (Plugin) OnOutboundListener(env model.Environment, node model.Proxy, service *model.Service,
@@ -64,6 +60,24 @@ func (Plugin) OnOutboundListener(env model.Environment, node model.Proxy, servic
 // on the inbound path
 func (Plugin) OnInboundListener(env model.Environment, node model.Proxy, service *model.Service,
 	servicePort *model.Port, listener *xdsapi.Listener) {
+	if !servicePort.Protocol.IsHTTP() {
+		return
+	}
+	policy := model.GetConsolidateAuthenticationPolicy(env.Mesh, env.IstioConfigStore, service.Hostname, servicePort)
+	if filter := BuildJwtFilter(policy, env.JwksResolver); filter != nil {
+		util.InsertHTTPFilter(listener, filter)
+		util.InsertHTTPFilter(listener, buildAuthnFilter(policy))
+	}
+}
+
+// buildAuthnFilter returns the Istio authentication filter for the policy.
+func buildAuthnFilter(policy *authn.Policy) *http_conn.HttpFilter {
+	return &http_conn.HttpFilter{
+		Name: AuthnFilterName,
+		Config: &types.Struct{Fields: map[string]*types.Value{
+			"policy": {Kind: &types.Value_StructValue{StructValue: util.MessageToStruct(policy)}},
+		}},
+	}
 }
 
 // OnInboundCluster is called whenever a new cluster is added to the CDS output
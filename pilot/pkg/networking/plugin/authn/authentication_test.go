@@ -15,87 +15,113 @@
 package authn
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
-	http_conn "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
-	"github.com/gogo/protobuf/types"
+	"github.com/gogo/protobuf/jsonpb"
 
 	authn "istio.io/api/authentication/v1alpha1"
+	"istio.io/istio/pilot/pkg/model"
 )
 
+func jwtPolicy(jwts ...*authn.Jwt) *authn.Policy {
+	policy := &authn.Policy{}
+	for _, jwt := range jwts {
+		policy.Origins = append(policy.Origins, &authn.OriginAuthenticationMethod{Jwt: jwt})
+	}
+	return policy
+}
+
 func TestBuildJwtFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"keys": []}`)
+	}))
+	defer server.Close()
+
+	// keys are inlined once the resolver has fetched them
+	fetched := make(chan struct{}, 1)
+	resolver := model.NewJwksResolver(time.Hour, func() { fetched <- struct{}{} })
+	if _, err := resolver.GetPublicKey(server.URL); err == nil {
+		t.Fatal("expected keys to be fetched in the background")
+	}
+	select {
+	case <-fetched:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the keys to be fetched")
+	}
+
 	cases := []struct {
+		name     string
 		in       *authn.Policy
-		expected *http_conn.HttpFilter
+		resolver *model.JwksResolver
+		expected *jwtAuthentication
 	}{
 		{
-			in:       nil,
-			expected: nil,
-		},
-		{
-			in:       nil,
-			expected: nil,
+			name: "no policy",
 		},
 		{
+			name: "mtls only",
 			in: &authn.Policy{
-				Peers: []*authn.PeerAuthenticationMethod{
-					{
-						Params: &authn.PeerAuthenticationMethod_Jwt{
-							Jwt: &authn.Jwt{
-								JwksUri: "http://abc.com",
-							},
-						},
-					},
-				},
+				Peers: []*authn.PeerAuthenticationMethod{{Params: &authn.PeerAuthenticationMethod_Mtls{}}},
 			},
-			expected: &http_conn.HttpFilter{
-				Name: "jwt-auth",
-				Config: &types.Struct{
-					Fields: map[string]*types.Value{
-						"jwts": {
-							Kind: &types.Value_ListValue{
-								ListValue: &types.ListValue{
-									Values: []*types.Value{
-										{
-											Kind: &types.Value_StructValue{
-												StructValue: &types.Struct{
-													Fields: map[string]*types.Value{
-														"forward_jwt": {
-															Kind: &types.Value_BoolValue{BoolValue: true},
-														},
-														"jwks_uri": {
-															Kind: &types.Value_StringValue{
-																StringValue: "http://abc.com",
-															},
-														},
-														"jwks_uri_envoy_cluster": {
-															Kind: &types.Value_StringValue{
-																StringValue: "jwks.abc.com|http",
-															},
-														},
-														"public_key_cache_duration": {
-															Kind: &types.Value_StringValue{
-																StringValue: "300.000s",
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
+		},
+		{
+			name: "remote keys",
+			in: jwtPolicy(&authn.Jwt{
+				Issuer:     "issuer",
+				JwksUri:    "http://abc.com",
+				JwtHeaders: []string{"x-token"},
+			}),
+			expected: &jwtAuthentication{Rules: []*jwtRule{{
+				Issuer: "issuer",
+				RemoteJwks: &remoteJwks{
+					HTTPURI:       httpURI{URI: "http://abc.com", Cluster: "jwks.abc.com|http"},
+					CacheDuration: jwksCacheDuration,
 				},
-			},
+				Forward:     true,
+				FromHeaders: []jwtHeader{{Name: "x-token"}},
+			}}},
+		},
+		{
+			name:     "local keys",
+			in:       jwtPolicy(&authn.Jwt{Issuer: "issuer", JwksUri: server.URL, Audiences: []string{"bookstore"}}),
+			resolver: resolver,
+			expected: &jwtAuthentication{Rules: []*jwtRule{{
+				Issuer:    "issuer",
+				Audiences: []string{"bookstore"},
+				LocalJwks: &dataSource{InlineString: `{"keys": []}`},
+				Forward:   true,
+			}}},
 		},
 	}
 
 	for _, c := range cases {
-		if got := BuildJwtFilter(c.in); !reflect.DeepEqual(c.expected, got) {
-			t.Errorf("buildJwtFilter(%#v), got:\n%#v\nwanted:\n%#v\n", c.in, got, c.expected)
+		got := BuildJwtFilter(c.in, c.resolver)
+		if c.expected == nil {
+			if got != nil {
+				t.Errorf("%s: expected no filter, got %v", c.name, got)
+			}
+			continue
+		}
+		if got == nil || got.Name != jwtFilterName {
+			t.Errorf("%s: expected a %s filter, got %v", c.name, jwtFilterName, got)
+			continue
+		}
+		data, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(got.Config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config := &jwtAuthentication{}
+		if err := json.Unmarshal([]byte(data), config); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(config, c.expected) {
+			t.Errorf("%s: got config %s", c.name, data)
 		}
 	}
 }
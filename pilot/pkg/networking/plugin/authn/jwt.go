@@ -0,0 +1,145 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	http_conn "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+
+	authn "istio.io/api/authentication/v1alpha1"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/log"
+)
+
+// jwksCacheDuration is how long proxies cache keys they fetch themselves.
+const jwksCacheDuration = "300s"
+
+// The Jwt filter config, following the jwt_auth v2alpha1 config of Istio
+// proxy (derived from envoy.config.filter.http.jwt_authn.v2alpha). It is
+// converted to a Struct through JSON.
+
+type jwtAuthentication struct {
+	Rules []*jwtRule `json:"rules"`
+}
+
+type jwtRule struct {
+	Issuer      string      `json:"issuer,omitempty"`
+	Audiences   []string    `json:"audiences,omitempty"`
+	RemoteJwks  *remoteJwks `json:"remote_jwks,omitempty"`
+	LocalJwks   *dataSource `json:"local_jwks,omitempty"`
+	Forward     bool        `json:"forward,omitempty"`
+	FromHeaders []jwtHeader `json:"from_headers,omitempty"`
+	FromParams  []string    `json:"from_params,omitempty"`
+}
+
+type remoteJwks struct {
+	HTTPURI       httpURI `json:"http_uri"`
+	CacheDuration string  `json:"cache_duration,omitempty"`
+}
+
+type httpURI struct {
+	URI     string `json:"uri"`
+	Cluster string `json:"cluster"`
+}
+
+type dataSource struct {
+	InlineString string `json:"inline_string"`
+}
+
+type jwtHeader struct {
+	Name string `json:"name"`
+}
+
+// convertPolicyToJwtConfig returns the Jwt filter config for all Jwt specs in
+// the policy, or nil if there are none. Keys are inlined when the resolver
+// has them, so that proxies do not fetch them from the issuers.
+func convertPolicyToJwtConfig(policy *authn.Policy, resolver *model.JwksResolver) *jwtAuthentication {
+	specs := model.CollectJwtSpecs(policy)
+	if len(specs) == 0 {
+		return nil
+	}
+	out := &jwtAuthentication{}
+	for _, spec := range specs {
+		rule := &jwtRule{
+			Issuer:     spec.Issuer,
+			Audiences:  spec.Audiences,
+			Forward:    true,
+			FromParams: spec.JwtParams,
+		}
+		for _, header := range spec.JwtHeaders {
+			rule.FromHeaders = append(rule.FromHeaders, jwtHeader{Name: header})
+		}
+
+		if resolver != nil {
+			jwks, err := resolver.GetPublicKey(spec.JwksUri)
+			if err == nil {
+				rule.LocalJwks = &dataSource{InlineString: jwks}
+				out.Rules = append(out.Rules, rule)
+				continue
+			}
+			// the resolver logs fetch failures
+			log.Debugf("JWKS of %s not resolved, proxies will fetch it: %v", spec.JwksUri, err)
+		}
+
+		hostname, port, _, err := model.ParseJwksURI(spec.JwksUri)
+		if err != nil {
+			log.Errorf("Cannot parse jwks_uri %q: %v", spec.JwksUri, err)
+			continue
+		}
+		rule.RemoteJwks = &remoteJwks{
+			HTTPURI: httpURI{
+				URI:     spec.JwksUri,
+				Cluster: model.JwksURIClusterName(hostname, port),
+			},
+			CacheDuration: jwksCacheDuration,
+		}
+		out.Rules = append(out.Rules, rule)
+	}
+	return out
+}
+
+// BuildJwtFilter returns a Jwt filter for all Jwt specs in the policy.
+func BuildJwtFilter(policy *authn.Policy, resolver *model.JwksResolver) *http_conn.HttpFilter {
+	config := convertPolicyToJwtConfig(policy, resolver)
+	if config == nil {
+		return nil
+	}
+	s, err := toStruct(config)
+	if err != nil {
+		log.Errorf("Failed to build Jwt filter config: %v", err)
+		return nil
+	}
+	return &http_conn.HttpFilter{
+		Name:   jwtFilterName,
+		Config: s,
+	}
+}
+
+func toStruct(v interface{}) (*types.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %T: %v", v, err)
+	}
+	pbs := &types.Struct{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(data), pbs); err != nil {
+		return nil, err
+	}
+	return pbs, nil
+}
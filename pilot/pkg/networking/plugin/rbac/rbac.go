@@ -0,0 +1,395 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rbac generates the Envoy HTTP RBAC filter from Istio ServiceRole and
+// ServiceRoleBinding config, so that requests are authorized in the proxy
+// rather than through a Mixer Check.
+//
+// The policy semantics follow the Mixer RBAC adapter (mixer/adapter/rbac),
+// with the request attributes of the authorization instance in
+// samples/bookinfo/kube/istio-rbac-enable.yaml:
+//   - the subject user is the mutual TLS peer identity or the JWT principal,
+//     which the Istio authentication filter of the authn plugin records,
+//   - the subject "namespace" property is the namespace of the peer identity,
+//   - action constraints are matched against the destination workload labels.
+//
+// Subjects using groups other than "*" or other properties cannot be verified
+// in the proxy and never match.
+//
+// Unlike the Mixer adapter, services in namespaces without roles get no filter,
+// so that enabling the plugin does not deny all requests to them.
+package rbac
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	http_conn "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+
+	rbacproto "istio.io/api/rbac/v1alpha1"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/networking/plugin"
+	"istio.io/istio/pilot/pkg/networking/plugin/authn"
+	"istio.io/istio/pilot/pkg/networking/util"
+	"istio.io/istio/pkg/log"
+)
+
+const (
+	// rbacFilterName is the name of the Envoy HTTP RBAC filter.
+	rbacFilterName = "envoy.filters.http.rbac"
+
+	// requestPrincipalKey is the metadata key of the JWT principal, set by the
+	// Istio authentication filter.
+	requestPrincipalKey = "request.auth.principal"
+
+	spiffePrefix = "spiffe://"
+
+	serviceRoleKind = "ServiceRole"
+)
+
+// Plugin generates the Envoy RBAC filter on inbound HTTP listeners.
+type Plugin struct{}
+
+// NewPlugin returns an instance of the rbac plugin
+func NewPlugin() plugin.Callbacks {
+	return Plugin{}
+}
+
+// The RBAC filter config, following envoy.config.filter.http.rbac.v2 and
+// envoy.config.rbac.v2alpha. The go-control-plane version in use predates the
+// filter, so the config is converted to a Struct through JSON.
+
+type filterConfig struct {
+	Rules rules `json:"rules"`
+}
+
+type rules struct {
+	Action   string             `json:"action"`
+	Policies map[string]*policy `json:"policies"`
+}
+
+type policy struct {
+	Permissions []*permission `json:"permissions"`
+	Principals  []*principal  `json:"principals"`
+}
+
+type permission struct {
+	Any      bool           `json:"any,omitempty"`
+	AndRules *permissionSet `json:"and_rules,omitempty"`
+	OrRules  *permissionSet `json:"or_rules,omitempty"`
+	Header   *headerMatcher `json:"header,omitempty"`
+}
+
+type permissionSet struct {
+	Rules []*permission `json:"rules"`
+}
+
+type headerMatcher struct {
+	Name         string `json:"name"`
+	ExactMatch   string `json:"exact_match,omitempty"`
+	PrefixMatch  string `json:"prefix_match,omitempty"`
+	SuffixMatch  string `json:"suffix_match,omitempty"`
+	PresentMatch bool   `json:"present_match,omitempty"`
+}
+
+type principal struct {
+	Any           bool                    `json:"any,omitempty"`
+	AndIds        *principalSet           `json:"and_ids,omitempty"`
+	OrIds         *principalSet           `json:"or_ids,omitempty"`
+	Authenticated *authenticatedPrincipal `json:"authenticated,omitempty"`
+	Metadata      *metadataMatcher        `json:"metadata,omitempty"`
+}
+
+type principalSet struct {
+	Ids []*principal `json:"ids"`
+}
+
+type authenticatedPrincipal struct {
+	PrincipalName *stringMatcher `json:"principal_name"`
+}
+
+type metadataMatcher struct {
+	Filter string        `json:"filter"`
+	Path   []pathSegment `json:"path"`
+	Value  *valueMatcher `json:"value"`
+}
+
+type pathSegment struct {
+	Key string `json:"key"`
+}
+
+type valueMatcher struct {
+	StringMatch *stringMatcher `json:"string_match"`
+}
+
+type stringMatcher struct {
+	Exact string `json:"exact,omitempty"`
+	Regex string `json:"regex,omitempty"`
+}
+
+// OnOutboundListener is called whenever a new outbound listener is added to the LDS output for a given service
+// Can be used to add additional filters on the outbound path
+func (Plugin) OnOutboundListener(env model.Environment, node model.Proxy, service *model.Service,
+	servicePort *model.Port, listener *xdsapi.Listener) {
+}
+
+// OnInboundListener is called whenever a new listener is added to the LDS output for a given service
+// Can be used to add additional filters (e.g., mixer filter) or add more stuff to the HTTP connection manager
+// on the inbound path
+func (Plugin) OnInboundListener(env model.Environment, node model.Proxy, service *model.Service,
+	servicePort *model.Port, listener *xdsapi.Listener) {
+	if !servicePort.Protocol.IsHTTP() {
+		return
+	}
+
+	// like the Mixer adapter, only the roles of the service namespace apply
+	namespace := serviceNamespace(service.Hostname)
+	roles, err := env.List(model.ServiceRole.Type, namespace)
+	if err != nil {
+		log.Errorf("Failed to list service roles in %s: %v", namespace, err)
+		return
+	}
+	// namespaces without roles, such as istio-system, have not adopted RBAC
+	// and are left unrestricted in the proxy
+	if len(roles) == 0 {
+		return
+	}
+	bindings, err := env.List(model.ServiceRoleBinding.Type, namespace)
+	if err != nil {
+		log.Errorf("Failed to list service role bindings in %s: %v", namespace, err)
+		return
+	}
+
+	instances, err := env.GetProxyServiceInstances(node)
+	if err != nil {
+		log.Errorf("Failed to get instances for %s: %v", node.ID, err)
+		return
+	}
+	labels := model.Labels{}
+	for _, instance := range instances {
+		if instance.Service.Hostname != service.Hostname {
+			continue
+		}
+		for k, v := range instance.Labels {
+			labels[k] = v
+		}
+	}
+
+	filter, err := buildFilter(buildConfig(service.Hostname, labels, roles, bindings))
+	if err != nil {
+		log.Errorf("Failed to build RBAC filter for %s: %v", service.Hostname, err)
+		return
+	}
+	util.InsertHTTPFilter(listener, filter)
+}
+
+// OnInboundCluster is called whenever a new cluster is added to the CDS output
+// Not used typically
+func (Plugin) OnInboundCluster(env model.Environment, node model.Proxy, service *model.Service,
+	servicePort *model.Port, cluster *xdsapi.Cluster) {
+}
+
+// OnOutboundRoute is called whenever a new set of virtual hosts (a set of virtual hosts with routes) is added to
+// RDS in the outbound path. Can be used to add route specific metadata or additional headers to forward
+func (Plugin) OnOutboundRoute(env model.Environment, node model.Proxy,
+	route *xdsapi.RouteConfiguration) {
+}
+
+// OnInboundRoute is called whenever a new set of virtual hosts are added to the inbound path.
+// Can be used to enable route specific stuff like Lua filters or other metadata.
+func (Plugin) OnInboundRoute(env model.Environment, node model.Proxy, service *model.Service,
+	servicePort *model.Port, route *xdsapi.RouteConfiguration) {
+}
+
+// OnOutboundCluster is called whenever a new cluster is added to the CDS output
+// Typically used by AuthN plugin to add mTLS settings
+func (Plugin) OnOutboundCluster(env model.Environment, node model.Proxy, service *model.Service,
+	servicePort *model.Port, cluster *xdsapi.Cluster) {
+}
+
+// serviceNamespace returns the namespace of a <name>.<namespace>.svc.<domain>
+// service hostname.
+func serviceNamespace(hostname string) string {
+	parts := strings.Split(hostname, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+// buildConfig returns the RBAC filter config for the service, with a policy
+// for every role granting access to it. Policies are named after the roles.
+// A config without policies denies all requests.
+func buildConfig(hostname string, labels model.Labels, roles, bindings []model.Config) *filterConfig {
+	roleBindings := make(map[string][]*rbacproto.ServiceRoleBinding)
+	for _, config := range bindings {
+		binding := config.Spec.(*rbacproto.ServiceRoleBinding)
+		if kind := binding.GetRoleRef().GetKind(); kind != serviceRoleKind {
+			log.Warnf("Service role binding %s refers to a role of kind %s, expected %s", config.Key(), kind, serviceRoleKind)
+		}
+		name := binding.GetRoleRef().GetName()
+		roleBindings[name] = append(roleBindings[name], binding)
+	}
+
+	out := &filterConfig{
+		Rules: rules{
+			Action:   "ALLOW",
+			Policies: make(map[string]*policy),
+		},
+	}
+	for _, config := range roles {
+		role := config.Spec.(*rbacproto.ServiceRole)
+		p := &policy{}
+		for _, rule := range role.Rules {
+			if perm := convertRule(hostname, labels, rule); perm != nil {
+				p.Permissions = append(p.Permissions, perm)
+			}
+		}
+		for _, binding := range roleBindings[config.Name] {
+			for _, subject := range binding.Subjects {
+				if id := convertSubject(subject); id != nil {
+					p.Principals = append(p.Principals, id)
+				}
+			}
+		}
+		if len(p.Permissions) > 0 && len(p.Principals) > 0 {
+			out.Rules.Policies[config.Name] = p
+		}
+	}
+	return out
+}
+
+// convertRule returns the permission for the rule, or nil if requests to the
+// service can never match it. The service and the constraints on the
+// destination labels are evaluated here, since they are fixed for the
+// listener.
+func convertRule(hostname string, labels model.Labels, rule *rbacproto.AccessRule) *permission {
+	if !stringMatch(hostname, rule.Services) || len(rule.Methods) == 0 {
+		return nil
+	}
+	for _, constraint := range rule.Constraints {
+		value, exists := labels[constraint.Key]
+		if !exists || !stringMatch(value, constraint.Values) {
+			return nil
+		}
+	}
+
+	var rules []*permission
+	if rule.Paths != nil {
+		rules = append(rules, headerPermission(":path", rule.Paths))
+	}
+	rules = append(rules, headerPermission(":method", rule.Methods))
+	return &permission{AndRules: &permissionSet{Rules: rules}}
+}
+
+// headerPermission matches a header against Mixer RBAC patterns: exact
+// values, "*", prefixes ending with "*" and suffixes starting with "*".
+func headerPermission(name string, patterns []string) *permission {
+	var rules []*permission
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			return &permission{Any: true}
+		}
+		rules = append(rules, &permission{Header: &headerMatcher{Name: name, ExactMatch: pattern}})
+		if strings.HasSuffix(pattern, "*") {
+			rules = append(rules, &permission{Header: &headerMatcher{Name: name, PrefixMatch: strings.TrimSuffix(pattern, "*")}})
+		}
+		if strings.HasPrefix(pattern, "*") {
+			rules = append(rules, &permission{Header: &headerMatcher{Name: name, SuffixMatch: strings.TrimPrefix(pattern, "*")}})
+		}
+	}
+	return &permission{OrRules: &permissionSet{Rules: rules}}
+}
+
+// convertSubject returns the principal for the subject, or nil if the subject
+// cannot match in the proxy. As in the Mixer adapter, properties are only
+// checked for subjects without user and group.
+func convertSubject(subject *rbacproto.Subject) *principal {
+	if subject.Group != "" && subject.Group != "*" {
+		log.Warnf("RBAC subject group %q cannot be verified in the proxy", subject.Group)
+		return nil
+	}
+	if subject.User == "" && subject.Group == "*" {
+		return &principal{Any: true}
+	}
+	if subject.User != "" {
+		if subject.User == "*" {
+			return &principal{Any: true}
+		}
+		return &principal{OrIds: &principalSet{Ids: []*principal{
+			{Authenticated: &authenticatedPrincipal{PrincipalName: &stringMatcher{Exact: spiffePrefix + subject.User}}},
+			{Metadata: &metadataMatcher{
+				Filter: authn.AuthnFilterName,
+				Path:   []pathSegment{{Key: requestPrincipalKey}},
+				Value:  &valueMatcher{StringMatch: &stringMatcher{Exact: subject.User}},
+			}},
+		}}}
+	}
+	if len(subject.Properties) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(subject.Properties))
+	for k := range subject.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var ids []*principal
+	for _, k := range keys {
+		if k != "namespace" {
+			log.Warnf("RBAC subject property %q cannot be verified in the proxy", k)
+			return nil
+		}
+		ids = append(ids, &principal{Authenticated: &authenticatedPrincipal{
+			PrincipalName: &stringMatcher{
+				Regex: regexp.QuoteMeta(spiffePrefix) + "[^/]*/ns/" + regexp.QuoteMeta(subject.Properties[k]) + "/.*",
+			},
+		}})
+	}
+	return &principal{AndIds: &principalSet{Ids: ids}}
+}
+
+// stringMatch mirrors the Mixer RBAC adapter string matching.
+func stringMatch(a string, list []string) bool {
+	for _, s := range list {
+		if a == s || s == "*" ||
+			(strings.HasSuffix(s, "*") && strings.HasPrefix(a, strings.TrimSuffix(s, "*"))) ||
+			(strings.HasPrefix(s, "*") && strings.HasSuffix(a, strings.TrimPrefix(s, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildFilter returns the RBAC HTTP filter for the config.
+func buildFilter(config *filterConfig) (*http_conn.HttpFilter, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	pbs := &types.Struct{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(data), pbs); err != nil {
+		return nil, err
+	}
+	return &http_conn.HttpFilter{
+		Name:   rbacFilterName,
+		Config: pbs,
+	}, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	http_conn "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	xdsutil "github.com/envoyproxy/go-control-plane/pkg/util"
+
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/networking/plugin/authn"
+	"istio.io/istio/pilot/pkg/networking/util"
+	"istio.io/istio/pkg/test/rbacfixture"
+)
+
+// request is the part of a request the RBAC filter sees.
+type request struct {
+	headers map[string]string
+	// peer is the URI SAN of the peer certificate
+	peer string
+	// jwtPrincipal is the principal set by the authentication filter
+	jwtPrincipal string
+}
+
+// The evaluation below follows the Envoy RBAC filter, to check the generated
+// config against the semantics of the Mixer adapter.
+
+func (c *filterConfig) allows(r request) bool {
+	for _, p := range c.Rules.Policies {
+		permitted, identified := false, false
+		for _, perm := range p.Permissions {
+			permitted = permitted || perm.matches(r)
+		}
+		for _, id := range p.Principals {
+			identified = identified || id.matches(r)
+		}
+		if permitted && identified {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *permission) matches(r request) bool {
+	switch {
+	case p.Any:
+		return true
+	case p.AndRules != nil:
+		for _, rule := range p.AndRules.Rules {
+			if !rule.matches(r) {
+				return false
+			}
+		}
+		return true
+	case p.OrRules != nil:
+		for _, rule := range p.OrRules.Rules {
+			if rule.matches(r) {
+				return true
+			}
+		}
+		return false
+	case p.Header != nil:
+		value, exists := r.headers[p.Header.Name]
+		switch {
+		case !exists:
+			return false
+		case p.Header.ExactMatch != "":
+			return value == p.Header.ExactMatch
+		case p.Header.PrefixMatch != "":
+			return strings.HasPrefix(value, p.Header.PrefixMatch)
+		case p.Header.SuffixMatch != "":
+			return strings.HasSuffix(value, p.Header.SuffixMatch)
+		}
+		return p.Header.PresentMatch
+	}
+	return false
+}
+
+func (p *principal) matches(r request) bool {
+	switch {
+	case p.Any:
+		return true
+	case p.AndIds != nil:
+		for _, id := range p.AndIds.Ids {
+			if !id.matches(r) {
+				return false
+			}
+		}
+		return true
+	case p.OrIds != nil:
+		for _, id := range p.OrIds.Ids {
+			if id.matches(r) {
+				return true
+			}
+		}
+		return false
+	case p.Authenticated != nil:
+		return r.peer != "" && p.Authenticated.PrincipalName.matches(r.peer)
+	case p.Metadata != nil:
+		return p.Metadata.Filter == authn.AuthnFilterName && len(p.Metadata.Path) == 1 &&
+			p.Metadata.Path[0].Key == requestPrincipalKey &&
+			r.jwtPrincipal != "" && p.Metadata.Value.StringMatch.matches(r.jwtPrincipal)
+	}
+	return false
+}
+
+func (m *stringMatcher) matches(value string) bool {
+	if m.Regex != "" {
+		return regexp.MustCompile("^(?:" + m.Regex + ")$").MatchString(value)
+	}
+	return value == m.Exact
+}
+
+func fixtureConfigs() (roles, bindings []model.Config) {
+	for _, role := range rbacfixture.Roles() {
+		roles = append(roles, model.Config{
+			ConfigMeta: model.ConfigMeta{Type: model.ServiceRole.Type, Name: role.Name, Namespace: rbacfixture.Namespace},
+			Spec:       role.Spec,
+		})
+		for name, binding := range role.Bindings {
+			bindings = append(bindings, model.Config{
+				ConfigMeta: model.ConfigMeta{Type: model.ServiceRoleBinding.Type, Name: name, Namespace: rbacfixture.Namespace},
+				Spec:       binding,
+			})
+		}
+	}
+	return
+}
+
+// inNamespace returns the configs in the namespace, as listed by the plugin.
+func inNamespace(configs []model.Config, namespace string) []model.Config {
+	var out []model.Config
+	for _, config := range configs {
+		if config.Namespace == namespace {
+			out = append(out, config)
+		}
+	}
+	return out
+}
+
+func TestBuildConfig(t *testing.T) {
+	roles, bindings := fixtureConfigs()
+	cases := append(rbacfixture.Cases(), rbacfixture.EmptyNamespaceCases()...)
+	for _, c := range cases {
+		namespace := serviceNamespace(c.Service)
+		config := buildConfig(c.Service, model.Labels(c.Labels), inNamespace(roles, namespace), inNamespace(bindings, namespace))
+
+		// evaluate the config as encoded for Envoy
+		data, err := json.Marshal(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := &filterConfig{}
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatal(err)
+		}
+
+		r := request{
+			headers: map[string]string{":path": c.Path, ":method": c.Method},
+			peer:    spiffePrefix + c.User,
+		}
+		if got := decoded.allows(r); got != c.Allowed {
+			t.Errorf("%+v: got allowed %v, want %v with config %s", c.Request, got, c.Allowed, data)
+		}
+	}
+}
+
+func TestBuildConfigJwtPrincipal(t *testing.T) {
+	roles, bindings := fixtureConfigs()
+	config := buildConfig("products.ns1.svc.cluster.local", model.Labels{"version": "v1"}, roles, bindings)
+
+	r := request{
+		headers:      map[string]string{":path": "/products", ":method": "GET"},
+		jwtPrincipal: "cluster.local/ns/ns2/sa/alice",
+	}
+	if !config.allows(r) {
+		t.Errorf("expected the JWT principal to be authorized")
+	}
+	r.jwtPrincipal = "bob"
+	if config.allows(r) {
+		t.Errorf("expected the JWT principal to be denied")
+	}
+}
+
+func TestOnInboundListenerWithoutRoles(t *testing.T) {
+	connectionManager := &http_conn.HttpConnectionManager{
+		HttpFilters: []*http_conn.HttpFilter{{Name: xdsutil.Router}},
+	}
+	l := &xdsapi.Listener{
+		FilterChains: []listener.FilterChain{{
+			Filters: []listener.Filter{{
+				Name:   xdsutil.HTTPConnectionManager,
+				Config: util.MessageToStruct(connectionManager),
+			}},
+		}},
+	}
+	env := model.Environment{IstioConfigStore: model.MakeIstioStore(memory.Make(model.IstioConfigTypes))}
+	service := &model.Service{Hostname: "pilot.istio-system.svc.cluster.local"}
+	NewPlugin().OnInboundListener(env, model.Proxy{}, service, &model.Port{Port: 8080, Protocol: model.ProtocolHTTP}, l)
+
+	// namespaces without roles are left unrestricted
+	filters := l.FilterChains[0].Filters[0].Config.Fields["http_filters"].GetListValue().Values
+	if len(filters) != 1 {
+		t.Errorf("expected no RBAC filter in a namespace without roles, got %v", filters)
+	}
+}
@@ -18,14 +18,25 @@
 package registry
 
 import (
+	"os"
+
 	"istio.io/istio/pilot/pkg/networking/plugin"
 	"istio.io/istio/pilot/pkg/networking/plugin/authn"
+	"istio.io/istio/pilot/pkg/networking/plugin/rbac"
 )
 
+// enableRBACFilter turns on the Envoy RBAC filter generated from ServiceRoles
+// and ServiceRoleBindings. When on, proxies enforce Istio RBAC in addition to
+// the Mixer RBAC adapter, so it is off unless PILOT_ENABLE_RBAC_FILTER is true.
+var enableRBACFilter = os.Getenv("PILOT_ENABLE_RBAC_FILTER") == "true"
+
 // NewPlugins returns a list of plugin instance handles. Each plugin implements the plugin.Callbacks interfaces
 func NewPlugins() []plugin.Callbacks {
 	plugins := make([]plugin.Callbacks, 0)
 	plugins = append(plugins, authn.NewPlugin())
+	if enableRBACFilter {
+		plugins = append(plugins, rbac.NewPlugin())
+	}
 	// plugins = append(plugins, mixer.NewPlugin())
 	// plugins = append(plugins, apim.NewPlugin())
 	return plugins
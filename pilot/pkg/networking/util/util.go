@@ -21,6 +21,7 @@ import (
 
 	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	"github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	http_conn "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
 	"github.com/envoyproxy/go-control-plane/pkg/util"
 	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
@@ -154,3 +155,31 @@ func ConvertGogoDurationToDuration(d *types.Duration) time.Duration {
 	}
 	return dur
 }
+
+// InsertHTTPFilter adds the filter to the HTTP connection managers of the
+// listener, ahead of the router.
+func InsertHTTPFilter(listener *xdsapi.Listener, filter *http_conn.HttpFilter) {
+	value := &types.Value{Kind: &types.Value_StructValue{StructValue: MessageToStruct(filter)}}
+	for i := range listener.FilterChains {
+		for j := range listener.FilterChains[i].Filters {
+			f := &listener.FilterChains[i].Filters[j]
+			if f.Name != util.HTTPConnectionManager || f.Config == nil {
+				continue
+			}
+			filters := f.Config.Fields["http_filters"].GetListValue()
+			if filters == nil {
+				continue
+			}
+			at := len(filters.Values)
+			for k, v := range filters.Values {
+				if v.GetStructValue().GetFields()["name"].GetStringValue() == util.Router {
+					at = k
+					break
+				}
+			}
+			filters.Values = append(filters.Values, nil)
+			copy(filters.Values[at+1:], filters.Values[at:])
+			filters.Values[at] = value
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	http_conn "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	"github.com/envoyproxy/go-control-plane/pkg/util"
+	"github.com/gogo/protobuf/types"
+)
+
+func httpFilter(name string) *types.Value {
+	return &types.Value{Kind: &types.Value_StructValue{StructValue: &types.Struct{
+		Fields: map[string]*types.Value{
+			"name": {Kind: &types.Value_StringValue{StringValue: name}},
+		},
+	}}}
+}
+
+func TestInsertHTTPFilter(t *testing.T) {
+	l := &xdsapi.Listener{
+		FilterChains: []listener.FilterChain{{
+			Filters: []listener.Filter{{
+				Name: util.HTTPConnectionManager,
+				Config: &types.Struct{Fields: map[string]*types.Value{
+					"http_filters": {Kind: &types.Value_ListValue{ListValue: &types.ListValue{
+						Values: []*types.Value{httpFilter(util.CORS), httpFilter(util.Router)},
+					}}},
+				}},
+			}},
+		}},
+	}
+	InsertHTTPFilter(l, &http_conn.HttpFilter{Name: "test"})
+
+	var got []string
+	for _, value := range l.FilterChains[0].Filters[0].Config.Fields["http_filters"].GetListValue().Values {
+		got = append(got, value.GetStructValue().Fields["name"].GetStringValue())
+	}
+	want := []string{util.CORS, "test", util.Router}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got filters %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	networking "istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+const httpConnectionManager = "envoy.http_connection_manager"
+
+// ConfigDump is the config Pilot generates for a proxy. Resources are encoded
+// with the proto field names, matching the Envoy admin config dump.
+type ConfigDump struct {
+	Clusters  []json.RawMessage `json:"clusters"`
+	Listeners []json.RawMessage `json:"listeners"`
+	// Routes are the route configs inlined in the listeners.
+	Routes []json.RawMessage `json:"routes"`
+	// Endpoints are the load assignments of the EDS clusters.
+	Endpoints []json.RawMessage `json:"endpoints"`
+	// Sources maps resources, as "<type>/<name>", to the keys of the Istio
+	// configs that contributed to them.
+	Sources map[string][]string `json:"sources"`
+}
+
+// configDump implements /debug/config_dump?proxyID=<service node>, which
+// returns the clusters, listeners, routes and endpoints Pilot would send to
+// the proxy.
+func (s *DiscoveryServer) configDump(w http.ResponseWriter, req *http.Request) {
+	proxyID := req.URL.Query().Get("proxyID")
+	if proxyID == "" {
+		http.Error(w, "proxyID is required", http.StatusBadRequest)
+		return
+	}
+	node, err := model.ParseServiceNode(proxyID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid proxyID %q: %v", proxyID, err), http.StatusBadRequest)
+		return
+	}
+
+	dump, err := s.buildConfigDump(node)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	out, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(out)
+}
+
+func (s *DiscoveryServer) buildConfigDump(node model.Proxy) (*ConfigDump, error) {
+	dump := &ConfigDump{
+		Clusters:  make([]json.RawMessage, 0),
+		Listeners: make([]json.RawMessage, 0),
+		Routes:    make([]json.RawMessage, 0),
+		Endpoints: make([]json.RawMessage, 0),
+		Sources:   make(map[string][]string),
+	}
+	sources, err := s.configSources(node)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := s.ConfigGenerator.BuildClusters(s.env, node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build clusters: %v", err)
+	}
+	for _, c := range clusters {
+		raw, err := marshalResource(c)
+		if err != nil {
+			return nil, err
+		}
+		dump.Clusters = append(dump.Clusters, raw)
+		keys := sources.forCluster(c.Name)
+		dump.addSources("cluster/"+c.Name, keys)
+
+		if c.Type != xdsapi.Cluster_EDS {
+			continue
+		}
+		// computed outside of the global EDS cluster map, so the dump does
+		// not register clusters no proxy is watching
+		eds := &EdsCluster{discovery: s}
+		updateCluster(c.Name, eds)
+		if eds.LoadAssignment == nil {
+			continue
+		}
+		raw, err = marshalResource(eds.LoadAssignment)
+		if err != nil {
+			return nil, err
+		}
+		dump.Endpoints = append(dump.Endpoints, raw)
+		dump.addSources("endpoint/"+c.Name, keys)
+	}
+
+	listeners, err := s.ConfigGenerator.BuildListeners(s.env, node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build listeners: %v", err)
+	}
+	routes := make(map[string]bool)
+	for _, l := range listeners {
+		raw, err := marshalResource(l)
+		if err != nil {
+			return nil, err
+		}
+		dump.Listeners = append(dump.Listeners, raw)
+		dump.addSources("listener/"+l.Name, sources.gateways)
+
+		// routes are inlined in the HTTP connection managers, and only exist
+		// in the listener config as a Struct
+		var listener struct {
+			FilterChains []struct {
+				Filters []struct {
+					Name   string `json:"name"`
+					Config struct {
+						RouteConfig json.RawMessage `json:"route_config"`
+					} `json:"config"`
+				} `json:"filters"`
+			} `json:"filter_chains"`
+		}
+		if err := json.Unmarshal(raw, &listener); err != nil {
+			return nil, err
+		}
+		for _, chain := range listener.FilterChains {
+			for _, filter := range chain.Filters {
+				if filter.Name != httpConnectionManager || filter.Config.RouteConfig == nil {
+					continue
+				}
+				var route struct {
+					Name         string `json:"name"`
+					VirtualHosts []struct {
+						Domains []string `json:"domains"`
+					} `json:"virtual_hosts"`
+				}
+				if err := json.Unmarshal(filter.Config.RouteConfig, &route); err != nil {
+					return nil, err
+				}
+				if route.Name == "" {
+					route.Name = l.Name
+				}
+				var domains []string
+				for _, vhost := range route.VirtualHosts {
+					domains = append(domains, vhost.Domains...)
+				}
+				keys := sources.forDomains(domains)
+				dump.addSources("listener/"+l.Name, keys)
+				if routes[route.Name] {
+					continue
+				}
+				routes[route.Name] = true
+				dump.Routes = append(dump.Routes, filter.Config.RouteConfig)
+				dump.addSources("route/"+route.Name, keys)
+			}
+		}
+	}
+	return dump, nil
+}
+
+// addSources records the config keys that contributed to a resource.
+func (dump *ConfigDump) addSources(resource string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, key := range dump.Sources[resource] {
+		seen[key] = true
+	}
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			dump.Sources[resource] = append(dump.Sources[resource], key)
+		}
+	}
+	sort.Strings(dump.Sources[resource])
+}
+
+// proxyConfigSources holds the Istio configs that can contribute to the
+// resources generated for a proxy.
+type proxyConfigSources struct {
+	store model.IstioConfigStore
+	// gateways are the keys of the gateways selecting a router
+	gateways []string
+	// virtualServices are the virtual services bound to the mesh or to the
+	// gateways of the proxy
+	virtualServices []model.Config
+}
+
+func (s *DiscoveryServer) configSources(node model.Proxy) (*proxyConfigSources, error) {
+	out := &proxyConfigSources{store: s.env.IstioConfigStore}
+	bound := []string{model.IstioMeshGateway}
+	if node.Type == model.Router {
+		instances, err := s.env.GetProxyServiceInstances(node)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get instances for %s: %v", node.ID, err)
+		}
+		var workloadLabels model.LabelsCollection
+		for _, instance := range instances {
+			workloadLabels = append(workloadLabels, instance.Labels)
+		}
+		for _, gateway := range s.env.Gateways(workloadLabels) {
+			out.gateways = append(out.gateways, gateway.Key())
+			bound = append(bound, gateway.Name)
+		}
+	}
+	out.virtualServices = s.env.VirtualServices(bound)
+	return out, nil
+}
+
+// forCluster returns the key of the destination rule for the cluster's
+// service, if any.
+func (cs *proxyConfigSources) forCluster(clusterName string) []string {
+	// only subset keys name a service; static clusters do not
+	if strings.Count(clusterName, "|") != 3 {
+		return nil
+	}
+	_, _, hostname, _ := model.ParseSubsetKey(clusterName)
+	rule := cs.store.DestinationRule(hostname, "")
+	if rule == nil {
+		return nil
+	}
+	return []string{rule.Key()}
+}
+
+// forDomains returns the keys of the virtual services with a host matching
+// one of the virtual host domains.
+func (cs *proxyConfigSources) forDomains(domains []string) []string {
+	hosts := make(map[string]bool)
+	for _, domain := range domains {
+		if i := strings.LastIndex(domain, ":"); i >= 0 {
+			domain = domain[:i]
+		}
+		hosts[domain] = true
+	}
+
+	var out []string
+	for _, config := range cs.virtualServices {
+		rule := config.Spec.(*networking.VirtualService)
+		for _, host := range rule.Hosts {
+			if hosts[host] || hosts[model.ResolveFQDN(host, config.Namespace+".svc."+config.Domain)] {
+				out = append(out, config.Key())
+				break
+			}
+		}
+	}
+	return out
+}
+
+func marshalResource(msg proto.Message) (json.RawMessage, error) {
+	jsonm := &jsonpb.Marshaler{OrigName: true}
+	out, err := jsonm.MarshalToString(msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(out), nil
+}
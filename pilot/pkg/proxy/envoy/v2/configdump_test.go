@@ -0,0 +1,77 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+func TestConfigDump(t *testing.T) {
+	server := initLocalPilotTestEnv()
+
+	rec := httptest.NewRecorder()
+	server.EnvoyXdsServer.configDump(rec, httptest.NewRequest(http.MethodGet, "/debug/config_dump", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected missing proxyID to be rejected, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	target := "/debug/config_dump?proxyID=" + url.QueryEscape(sidecarId(app3Ip, "app3"))
+	server.EnvoyXdsServer.configDump(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("config dump failed with %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var dump struct {
+		Clusters  []map[string]interface{} `json:"clusters"`
+		Listeners []map[string]interface{} `json:"listeners"`
+		Routes    []map[string]interface{} `json:"routes"`
+		Endpoints []map[string]interface{} `json:"endpoints"`
+		Sources   map[string][]string      `json:"sources"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &dump); err != nil {
+		t.Fatal(err)
+	}
+	if len(dump.Clusters) == 0 || len(dump.Listeners) == 0 || len(dump.Routes) == 0 || len(dump.Endpoints) == 0 {
+		t.Fatalf("expected clusters, listeners, routes and endpoints, got %s", rec.Body.String())
+	}
+	// resources use the proto field names, like the Envoy config dump
+	if _, ok := dump.Clusters[0]["connect_timeout"]; !ok {
+		t.Errorf("expected proto field names in %v", dump.Clusters[0])
+	}
+
+	// the testdata destination rule and virtual service named "all" apply to service3
+	for resource, typ := range map[string]string{
+		"cluster/outbound|http-main||service3.default.svc.cluster.local": model.DestinationRule.Type,
+		"route/80": model.VirtualService.Type,
+	} {
+		found := false
+		for _, key := range dump.Sources[resource] {
+			if strings.HasPrefix(key, typ+"/") && strings.HasSuffix(key, "/all") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected %s %q to contribute to %s, got %v", typ, "all", resource, dump.Sources[resource])
+		}
+	}
+}
@@ -49,6 +49,8 @@ func (s *DiscoveryServer) InitDebug(mux *http.ServeMux, sctl *aggregate.Controll
 	mux.HandleFunc("/debug/ldsz", LDSz)
 
 	mux.HandleFunc("/debug/registryz", s.registryz)
+
+	mux.HandleFunc("/debug/config_dump", s.configDump)
 }
 
 // NewMemServiceDiscovery builds an in-memory MemServiceDiscovery
@@ -0,0 +1,286 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package status provides the pilot-agent status server, which reports the
+// readiness of the Envoy proxy and drains it before the agent exits.
+package status
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"istio.io/istio/pkg/log"
+)
+
+const (
+	// ReadyPath is the path of the readiness probe endpoint.
+	ReadyPath = "/healthz/ready"
+
+	// DrainPath is the path of the endpoint triggering a graceful drain. It
+	// only accepts requests from localhost, since the status port is reachable
+	// from other pods.
+	DrainPath = "/drain"
+
+	// DefaultTerminationGracePeriod is the default termination grace period
+	// of Kubernetes pods.
+	DefaultTerminationGracePeriod = 30 * time.Second
+
+	// adminTimeout bounds requests to the Envoy admin API.
+	adminTimeout = 2 * time.Second
+
+	// exitMargin is the part of the termination grace period left for the
+	// agent to exit after a drain.
+	exitMargin = 5 * time.Second
+)
+
+// updateStats are the Envoy stats counting xDS updates. Envoy has received
+// its initial configuration once both CDS and LDS have been applied or
+// rejected at least once.
+var updateStats = [][]string{
+	{"cluster_manager.cds.update_success", "cluster_manager.cds.update_rejected"},
+	{"listener_manager.lds.update_success", "listener_manager.lds.update_rejected"},
+}
+
+// Config for the status server.
+type Config struct {
+	// StatusPort is the port the status server listens on.
+	StatusPort uint16
+
+	// AdminPort is the port of the Envoy admin API on localhost.
+	AdminPort uint16
+
+	// DrainDuration is how long Envoy is given to drain connections after its
+	// health checks start failing. It is capped so that the drain completes
+	// within the termination grace period.
+	DrainDuration time.Duration
+
+	// TerminationGracePeriod is how long Kubernetes waits for the pod to exit
+	// before killing it. Defaults to DefaultTerminationGracePeriod.
+	TerminationGracePeriod time.Duration
+}
+
+// Server serves the pilot-agent readiness and drain endpoints.
+type Server struct {
+	config   Config
+	client   *http.Client
+	adminURL string
+
+	drainOnce sync.Once
+	draining  chan struct{}
+	drained   chan struct{}
+}
+
+// NewServer creates a status server for the config.
+func NewServer(config Config) *Server {
+	if config.TerminationGracePeriod <= 0 {
+		config.TerminationGracePeriod = DefaultTerminationGracePeriod
+	}
+	if limit := config.TerminationGracePeriod - exitMargin; config.DrainDuration > limit {
+		if limit < 0 {
+			limit = 0
+		}
+		log.Warnf("Drain duration %v exceeds the termination grace period %v, draining for %v",
+			config.DrainDuration, config.TerminationGracePeriod, limit)
+		config.DrainDuration = limit
+	}
+
+	return &Server{
+		config:   config,
+		client:   &http.Client{Timeout: adminTimeout},
+		adminURL: fmt.Sprintf("http://127.0.0.1:%d", config.AdminPort),
+		draining: make(chan struct{}),
+		drained:  make(chan struct{}),
+	}
+}
+
+// Run serves the status endpoints until the context is cancelled.
+func (s *Server) Run(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ReadyPath, s.handleReady)
+	mux.HandleFunc(DrainPath, s.handleDrain)
+
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.StatusPort))
+	if err != nil {
+		log.Errorf("Error listening on status port %d: %v", s.config.StatusPort, err)
+		return
+	}
+	server := &http.Server{Handler: mux}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	log.Infof("Status server listening on %v", l.Addr())
+	if err := server.Serve(l); err != nil && err != http.ErrServerClosed {
+		log.Errorf("Error serving status endpoints: %v", err)
+	}
+}
+
+// Drain fails the Envoy health checks so that load balancers stop sending new
+// requests, and then waits for the drain duration. Only the first call
+// drains; subsequent calls block until that drain completes.
+func (s *Server) Drain() {
+	s.drainOnce.Do(func() {
+		close(s.draining)
+		log.Infof("Draining Envoy for %v", s.config.DrainDuration)
+		if err := s.failHealthChecks(); err != nil {
+			log.Warnf("Failed to fail Envoy health checks: %v", err)
+		}
+		time.Sleep(s.config.DrainDuration)
+		close(s.drained)
+	})
+	<-s.drained
+}
+
+// Drained is closed once a drain completes.
+func (s *Server) Drained() <-chan struct{} {
+	return s.drained
+}
+
+func (s *Server) isDraining() bool {
+	select {
+	case <-s.draining:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, _ *http.Request) {
+	if s.isDraining() {
+		http.Error(w, "envoy is draining", http.StatusServiceUnavailable)
+		return
+	}
+	if err := s.checkReady(); err != nil {
+		log.Debugf("Envoy is not ready: %v", err)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleDrain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "drain requires POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if !isLoopback(r.RemoteAddr) {
+		log.Warnf("Rejected drain request from %s", r.RemoteAddr)
+		http.Error(w, "drain is only allowed from localhost", http.StatusForbidden)
+		return
+	}
+	go s.Drain()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// checkReady returns an error unless Envoy is live and has received its
+// initial cluster and listener configuration.
+func (s *Server) checkReady() error {
+	info, err := s.admin(http.MethodGet, "/server_info")
+	if err != nil {
+		return err
+	}
+	if !isLive(info) {
+		return fmt.Errorf("envoy is not live: %s", strings.TrimSpace(string(info)))
+	}
+
+	out, err := s.admin(http.MethodGet, "/stats")
+	if err != nil {
+		return err
+	}
+	stats := parseStats(out)
+	for _, names := range updateStats {
+		var updates uint64
+		for _, name := range names {
+			updates += stats[name]
+		}
+		if updates == 0 {
+			return fmt.Errorf("envoy has not received %s", strings.Join(names, " or "))
+		}
+	}
+	return nil
+}
+
+func (s *Server) failHealthChecks() error {
+	_, err := s.admin(http.MethodPost, "/healthcheck/fail")
+	return err
+}
+
+func (s *Server) admin(method, path string) ([]byte, error) {
+	req, err := http.NewRequest(method, s.adminURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("envoy admin %s returned %d: %s", path, resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+// isLoopback reports whether a request remote address is a loopback address.
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// isLive reports whether the Envoy server_info output carries the live state,
+// e.g. "envoy 5bfa/1.7.0/Clean/RELEASE live 3 3 0".
+func isLive(info []byte) bool {
+	for _, field := range strings.Fields(string(info)) {
+		if field == "live" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStats parses the counters of the Envoy stats output, which has one
+// "name: value" pair per line.
+func parseStats(out []byte) map[string]uint64 {
+	stats := make(map[string]uint64)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil {
+			continue
+		}
+		stats[strings.TrimSpace(parts[0])] = value
+	}
+	return stats
+}
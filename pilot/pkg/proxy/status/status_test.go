@@ -0,0 +1,185 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeAdmin struct {
+	mu          sync.Mutex
+	state       string
+	stats       string
+	healthFails int
+}
+
+func (a *fakeAdmin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch r.URL.Path {
+	case "/server_info":
+		fmt.Fprintf(w, "envoy 5bfa/1.7.0/Clean/RELEASE %s 3 3 0\n", a.state)
+	case "/stats":
+		fmt.Fprint(w, a.stats)
+	case "/healthcheck/fail":
+		a.healthFails++
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestServer(admin *fakeAdmin, drain time.Duration) (*Server, func()) {
+	ts := httptest.NewServer(admin)
+	s := NewServer(Config{DrainDuration: drain})
+	s.adminURL = ts.URL
+	return s, ts.Close
+}
+
+func TestReady(t *testing.T) {
+	cases := []struct {
+		name  string
+		state string
+		stats string
+		ready bool
+	}{
+		{
+			name:  "initializing",
+			state: "initializing",
+			stats: "cluster_manager.cds.update_success: 1\nlistener_manager.lds.update_success: 1\n",
+		},
+		{
+			name:  "no listeners",
+			state: "live",
+			stats: "cluster_manager.cds.update_success: 1\nlistener_manager.lds.update_success: 0\n",
+		},
+		{
+			name:  "rejected listeners",
+			state: "live",
+			stats: "cluster_manager.cds.update_success: 2\nlistener_manager.lds.update_rejected: 1\n",
+			ready: true,
+		},
+		{
+			name:  "ready",
+			state: "live",
+			stats: "cluster_manager.cds.update_success: 1\nlistener_manager.lds.update_success: 1\nserver.uptime: 3\n",
+			ready: true,
+		},
+	}
+
+	for _, c := range cases {
+		s, done := newTestServer(&fakeAdmin{state: c.state, stats: c.stats}, 0)
+		rec := httptest.NewRecorder()
+		s.handleReady(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+		done()
+		if got := rec.Code == http.StatusOK; got != c.ready {
+			t.Errorf("%s: got ready %v (%d: %s), want %v", c.name, got, rec.Code, rec.Body.String(), c.ready)
+		}
+	}
+}
+
+func TestDrain(t *testing.T) {
+	admin := &fakeAdmin{
+		state: "live",
+		stats: "cluster_manager.cds.update_success: 1\nlistener_manager.lds.update_success: 1\n",
+	}
+	s, done := newTestServer(admin, 50*time.Millisecond)
+	defer done()
+
+	rec := httptest.NewRecorder()
+	s.handleDrain(rec, httptest.NewRequest(http.MethodGet, DrainPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected GET to be rejected, got %d", rec.Code)
+	}
+
+	// other pods cannot drain the proxy
+	rec = httptest.NewRecorder()
+	s.handleDrain(rec, httptest.NewRequest(http.MethodPost, DrainPath, nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected a remote drain to be rejected, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, DrainPath, nil)
+	req.RemoteAddr = "127.0.0.1:45678"
+	s.handleDrain(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected drain to be accepted, got %d", rec.Code)
+	}
+
+	select {
+	case <-s.Drained():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for drain")
+	}
+	// a second drain returns immediately
+	s.Drain()
+
+	admin.mu.Lock()
+	if admin.healthFails != 1 {
+		t.Errorf("expected health checks to be failed once, got %d", admin.healthFails)
+	}
+	admin.mu.Unlock()
+
+	rec = httptest.NewRecorder()
+	s.handleReady(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected a draining proxy not to be ready, got %d", rec.Code)
+	}
+}
+
+func TestDrainDurationCappedByGracePeriod(t *testing.T) {
+	cases := []struct {
+		name  string
+		drain time.Duration
+		grace time.Duration
+		want  time.Duration
+	}{
+		{
+			// the mesh default drain duration of 45s exceeds the Kubernetes default grace period of 30s
+			name:  "default grace period",
+			drain: 45 * time.Second,
+			want:  25 * time.Second,
+		},
+		{
+			name:  "within grace period",
+			drain: 10 * time.Second,
+			grace: 60 * time.Second,
+			want:  10 * time.Second,
+		},
+		{
+			name:  "longer grace period",
+			drain: 45 * time.Second,
+			grace: 40 * time.Second,
+			want:  35 * time.Second,
+		},
+		{
+			name:  "grace period shorter than the exit margin",
+			drain: 45 * time.Second,
+			grace: 2 * time.Second,
+		},
+	}
+
+	for _, c := range cases {
+		s := NewServer(Config{DrainDuration: c.drain, TerminationGracePeriod: c.grace})
+		if s.config.DrainDuration != c.want {
+			t.Errorf("%s: got drain duration %v, want %v", c.name, s.config.DrainDuration, c.want)
+		}
+	}
+}
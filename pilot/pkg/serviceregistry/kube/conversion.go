@@ -59,6 +59,10 @@ const (
 	// PortAuthenticationAnnotationKeyPrefix is the annotation key prefix that used to define
 	// authentication policy.
 	PortAuthenticationAnnotationKeyPrefix = "auth.istio.io"
+
+	// TraceSamplingAnnotation is the percentage of requests to the service randomly
+	// selected for tracing, overriding the mesh default
+	TraceSamplingAnnotation = "alpha.istio.io/trace-sampling"
 )
 
 func convertLabels(obj meta_v1.ObjectMeta) model.Labels {
@@ -142,7 +146,22 @@ func convertService(svc v1.Service, domainSuffix string) *model.Service {
 		LoadBalancingDisabled: loadBalancingDisabled,
 		MeshExternal:          meshExternal,
 		Resolution:            resolution,
+		TraceSampling:         extractTraceSampling(svc.ObjectMeta),
+	}
+}
+
+// Extracts the trace sampling percentage from the annotation. Returns nil if
+// there is no such annotation or the value is not a percentage.
+func extractTraceSampling(obj meta_v1.ObjectMeta) *float64 {
+	value, exists := obj.Annotations[TraceSamplingAnnotation]
+	if !exists {
+		return nil
+	}
+	sampling, err := strconv.ParseFloat(value, 64)
+	if err != nil || sampling < 0 || sampling > 100 {
+		return nil
 	}
+	return &sampling
 }
 
 // serviceHostname produces FQDN for a k8s service
@@ -240,6 +240,37 @@ func TestServiceSecurityAnnotation(t *testing.T) {
 
 }
 
+func TestServiceTraceSamplingAnnotation(t *testing.T) {
+	testCases := []struct {
+		annotations map[string]string
+		want        *float64
+	}{
+		{nil, nil},
+		{map[string]string{TraceSamplingAnnotation: "0.1"}, func() *float64 { v := 0.1; return &v }()},
+		{map[string]string{TraceSamplingAnnotation: "100"}, func() *float64 { v := 100.0; return &v }()},
+		{map[string]string{TraceSamplingAnnotation: "101"}, nil},
+		{map[string]string{TraceSamplingAnnotation: "all"}, nil},
+	}
+	for _, test := range testCases {
+		localSvc := v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "service1",
+				Namespace:   "default",
+				Annotations: test.annotations,
+			},
+			Spec: v1.ServiceSpec{
+				ClusterIP: "10.0.0.1",
+				Ports:     []v1.ServicePort{{Name: "http", Port: 8080, Protocol: v1.ProtocolTCP}},
+			},
+		}
+
+		got := convertService(localSvc, domainSuffix).TraceSampling
+		if (got == nil) != (test.want == nil) || (got != nil && *got != *test.want) {
+			t.Errorf("incorrect trace sampling for %v", test.annotations)
+		}
+	}
+}
+
 func TestExternalServiceConversion(t *testing.T) {
 	serviceName := "service1"
 	namespace := "default"
@@ -15,6 +15,7 @@
 package bootstrap
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -41,6 +42,20 @@ const (
 	MaxClusterNameLength = 189 // TODO: use MeshConfig.StatNameLength instead
 )
 
+// Tracers which can be selected with the "tracer" template option.
+const (
+	// TracerZipkin reports spans to a Zipkin collector
+	TracerZipkin = "zipkin"
+
+	// TracerJaeger reports spans to a Jaeger agent through the Jaeger
+	// OpenTracing plugin, which must be installed in the proxy image at
+	// /usr/local/lib/libjaegertracing_plugin.so
+	TracerJaeger = "jaeger"
+
+	// TracerLightstep reports spans to a Lightstep collector
+	TracerLightstep = "lightstep"
+)
+
 var (
 	defaultPilotSan = []string{
 		"spiffe://cluster.local/ns/istio-system/sa/istio-pilot-service-account"}
@@ -128,6 +143,46 @@ func StoreHostPort(host, port, field string, opts map[string]interface{}) {
 	opts[field] = fmt.Sprintf("{\"address\": \"%s\", \"port_value\": %s}", host, port)
 }
 
+// storeTracer sets the template options of the tracer selected by the "tracer"
+// option, Zipkin by default. The Zipkin collector address is taken from the
+// proxy config, the Jaeger agent and Lightstep collector addresses from the
+// "jaeger_address" and "lightstep_address" options.
+func storeTracer(config *meshconfig.ProxyConfig, opts map[string]interface{}) error {
+	tracer, _ := opts["tracer"].(string)
+	switch tracer {
+	case "", TracerZipkin:
+		if config.ZipkinAddress == "" {
+			return nil
+		}
+		h, p, err := GetHostPort("Zipkin", config.ZipkinAddress)
+		if err != nil {
+			return err
+		}
+		StoreHostPort(h, p, "zipkin", opts)
+	case TracerJaeger:
+		address, _ := opts["jaeger_address"].(string)
+		h, p, err := GetHostPort("Jaeger", address)
+		if err != nil {
+			return err
+		}
+		// the Jaeger client library takes a host:port string
+		opts["jaeger"] = net.JoinHostPort(h, p)
+	case TracerLightstep:
+		address, _ := opts["lightstep_address"].(string)
+		h, p, err := GetHostPort("Lightstep", address)
+		if err != nil {
+			return err
+		}
+		if token, _ := opts["lightstep_access_token_file"].(string); token == "" {
+			return errors.New("missing Lightstep access token file")
+		}
+		StoreHostPort(h, p, "lightstep", opts)
+	default:
+		return fmt.Errorf("unknown tracer %q", tracer)
+	}
+	return nil
+}
+
 // WriteBootstrap generates an envoy config based on config and epoch, and returns the filename.
 // TODO: in v2 some of the LDS ports (port, http_port) should be configured in the bootstrap.
 func WriteBootstrap(config *meshconfig.ProxyConfig, epoch int, pilotSAN []string, opts map[string]interface{}) (string, error) {
@@ -194,12 +249,8 @@ func WriteBootstrap(config *meshconfig.ProxyConfig, epoch int, pilotSAN []string
 	}
 	StoreHostPort(grpcHost, grpcPort, "pilot_grpc_address", opts)
 
-	if config.ZipkinAddress != "" {
-		h, p, err = GetHostPort("Zipkin", config.ZipkinAddress)
-		if err != nil {
-			return "", err
-		}
-		StoreHostPort(h, p, "zipkin", opts)
+	if err = storeTracer(config, opts); err != nil {
+		return "", err
 	}
 
 	if config.StatsdUdpAddress != "" {
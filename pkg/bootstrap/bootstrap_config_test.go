@@ -28,19 +28,38 @@ import (
 // cp $TOP/out/linux_amd64/release/bootstrap/all/envoy-rev0.json pkg/bootstrap/testdata/all_golden.json
 // cp $TOP/out/linux_amd64/release/bootstrap/auth/envoy-rev0.json pkg/bootstrap/testdata/auth_golden.json
 // cp $TOP/out/linux_amd64/release/bootstrap/default/envoy-rev0.json pkg/bootstrap/testdata/default_golden.json
+// cp $TOP/out/linux_amd64/release/bootstrap/jaeger/envoy-rev0.json pkg/bootstrap/testdata/jaeger_golden.json
+// cp $TOP/out/linux_amd64/release/bootstrap/lightstep/envoy-rev0.json pkg/bootstrap/testdata/lightstep_golden.json
 func TestGolden(t *testing.T) {
 	cases := []struct {
 		base string
+		opts map[string]interface{}
 	}{
 		{
-			"auth",
+			base: "auth",
 		},
 		{
-			"default",
+			base: "default",
 		},
 		{
 			// Specify zipkin/statsd address, similar with the default config in v1 tests
-			"all",
+			base: "all",
+		},
+		{
+			base: "jaeger",
+			opts: map[string]interface{}{
+				"tracer":         TracerJaeger,
+				"jaeger_address": "jaeger-agent:6831",
+			},
+		},
+		{
+			base: "lightstep",
+			opts: map[string]interface{}{
+				"tracer":                      TracerLightstep,
+				"lightstep_address":           "lightstep-satellite:8080",
+				"lightstep_access_token_file": "/etc/lightstep/access-token",
+				"lightstep_cacert_path":       "/etc/lightstep/cacert.pem",
+			},
 		},
 	}
 
@@ -56,7 +75,7 @@ func TestGolden(t *testing.T) {
 				t.Fatal(err)
 			}
 			fn, err := WriteBootstrap(cfg, 0, []string{
-				"spiffe://cluster.local/ns/istio-system/sa/istio-pilot-service-account"}, nil)
+				"spiffe://cluster.local/ns/istio-system/sa/istio-pilot-service-account"}, c.opts)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -98,6 +117,37 @@ func loadProxyConfig(base, out string, t *testing.T) (*meshconfig.ProxyConfig, e
 	return cfg, nil
 }
 
+func TestStoreTracer(t *testing.T) {
+	cases := []struct {
+		name string
+		opts map[string]interface{}
+		want string
+	}{
+		{"zipkin", map[string]interface{}{}, "zipkin"},
+		{"jaeger", map[string]interface{}{"tracer": TracerJaeger, "jaeger_address": "jaeger:6831"}, "jaeger"},
+		{"lightstep", map[string]interface{}{"tracer": TracerLightstep, "lightstep_address": "lightstep:8080",
+			"lightstep_access_token_file": "/etc/lightstep/access-token"}, "lightstep"},
+		{"missing jaeger address", map[string]interface{}{"tracer": TracerJaeger}, ""},
+		{"missing lightstep token", map[string]interface{}{
+			"tracer": TracerLightstep, "lightstep_address": "lightstep:8080"}, ""},
+		{"unknown tracer", map[string]interface{}{"tracer": "unknown"}, ""},
+	}
+	for _, c := range cases {
+		err := storeTracer(&meshconfig.ProxyConfig{ZipkinAddress: "zipkin:9411"}, c.opts)
+		if c.want == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		} else if c.opts[c.want] == nil {
+			t.Errorf("%s: expected the %q option to be set, got %v", c.name, c.want, c.opts)
+		}
+	}
+}
+
 func TestGetHostPort(t *testing.T) {
 	var testCases = []struct {
 		name         string
@@ -0,0 +1,220 @@
+// Copyright 2018 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rbacfixture holds the RBAC policies and requests shared by the tests
+// of the Mixer RBAC adapter and the Pilot RBAC filter plugin, so that both
+// enforcement points are held to the same semantics.
+//
+// Requests follow the attributes of the authorization instance in
+// samples/bookinfo/kube/istio-rbac-enable.yaml: the subject "namespace"
+// property is the source namespace and the action properties are the
+// destination workload labels.
+package rbacfixture
+
+import (
+	"strings"
+
+	rbacproto "istio.io/api/rbac/v1alpha1"
+)
+
+const (
+	// Namespace is the namespace of all the roles, bindings and services.
+	Namespace = "ns1"
+
+	// EmptyNamespace is a namespace without roles.
+	EmptyNamespace = "ns3"
+)
+
+// Role is a ServiceRole with the ServiceRoleBindings referring to it.
+type Role struct {
+	Name     string
+	Spec     *rbacproto.ServiceRole
+	Bindings map[string]*rbacproto.ServiceRoleBinding
+}
+
+// Request is a request to a service in Namespace.
+type Request struct {
+	// Service is the destination service hostname.
+	Service string
+	// Labels are the destination workload labels.
+	Labels map[string]string
+	Path   string
+	Method string
+	// User is the source identity, as authenticated by mutual TLS.
+	User string
+	// SourceNamespace is the namespace of the source workload.
+	SourceNamespace string
+}
+
+// ServiceNamespace returns the namespace of the destination service, whose
+// roles apply to the request.
+func (r Request) ServiceNamespace() string {
+	parts := strings.Split(r.Service, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+// Case is a request with the expected authorization result.
+type Case struct {
+	Request
+	Allowed bool
+}
+
+func binding(role string, subjects ...*rbacproto.Subject) *rbacproto.ServiceRoleBinding {
+	return &rbacproto.ServiceRoleBinding{
+		Subjects: subjects,
+		RoleRef: &rbacproto.RoleRef{
+			Kind: "ServiceRole",
+			Name: role,
+		},
+	}
+}
+
+// Roles returns the roles defined in Namespace.
+func Roles() []Role {
+	return []Role{
+		{
+			Name: "role1",
+			Spec: &rbacproto.ServiceRole{
+				Rules: []*rbacproto.AccessRule{{
+					Services: []string{"bookstore.ns1.svc.cluster.local"},
+					Paths:    []string{"/books"},
+					Methods:  []string{"GET"},
+				}},
+			},
+			Bindings: map[string]*rbacproto.ServiceRoleBinding{
+				"binding1": binding("role1", &rbacproto.Subject{Properties: map[string]string{"namespace": "acme"}}),
+			},
+		},
+		{
+			Name: "role2",
+			Spec: &rbacproto.ServiceRole{
+				Rules: []*rbacproto.AccessRule{{
+					Services: []string{"products.ns1.svc.cluster.local"},
+					Methods:  []string{"*"},
+					Constraints: []*rbacproto.AccessRule_Constraint{
+						{Key: "version", Values: []string{"v1", "v2"}},
+					},
+				}},
+			},
+			Bindings: map[string]*rbacproto.ServiceRoleBinding{
+				"binding2": binding("role2",
+					&rbacproto.Subject{User: "cluster.local/ns/ns2/sa/alice"},
+					&rbacproto.Subject{Properties: map[string]string{"namespace": "abc"}}),
+			},
+		},
+		{
+			Name: "role3",
+			Spec: &rbacproto.ServiceRole{
+				Rules: []*rbacproto.AccessRule{{
+					Services: []string{"fish*"},
+					Paths:    []string{"/pond/*"},
+					Methods:  []string{"GET"},
+				}},
+			},
+			Bindings: map[string]*rbacproto.ServiceRoleBinding{
+				"binding3": binding("role3", &rbacproto.Subject{Properties: map[string]string{"namespace": "abcfish"}}),
+			},
+		},
+		{
+			Name: "role4",
+			Spec: &rbacproto.ServiceRole{
+				Rules: []*rbacproto.AccessRule{{
+					Services: []string{"fish.ns1.svc.cluster.local"},
+					Paths:    []string{"*/review"},
+					Methods:  []string{"GET", "HEAD"},
+				}},
+			},
+			Bindings: map[string]*rbacproto.ServiceRoleBinding{
+				// properties are not checked once the user matches
+				"binding4": binding("role4", &rbacproto.Subject{
+					User:       "cluster.local/ns/mynamespace/sa/alice",
+					Properties: map[string]string{"namespace": "elsewhere"},
+				}),
+			},
+		},
+		{
+			Name: "role5",
+			Spec: &rbacproto.ServiceRole{
+				Rules: []*rbacproto.AccessRule{{
+					Services: []string{"abc.ns1.svc.cluster.local"},
+					Methods:  []string{"GET"},
+				}},
+			},
+			Bindings: map[string]*rbacproto.ServiceRoleBinding{
+				"binding5": binding("role5", &rbacproto.Subject{User: "*"}),
+			},
+		},
+		{
+			// rules without methods never match
+			Name: "role6",
+			Spec: &rbacproto.ServiceRole{
+				Rules: []*rbacproto.AccessRule{{
+					Services: []string{"*"},
+					Paths:    []string{"/open"},
+				}},
+			},
+			Bindings: map[string]*rbacproto.ServiceRoleBinding{
+				"binding6": binding("role6", &rbacproto.Subject{User: "*"}),
+			},
+		},
+		{
+			// roles without bindings grant nothing
+			Name: "role7",
+			Spec: &rbacproto.ServiceRole{
+				Rules: []*rbacproto.AccessRule{{
+					Services: []string{"*"},
+					Methods:  []string{"*"},
+				}},
+			},
+		},
+	}
+}
+
+// Cases returns the requests to authorize against Roles.
+func Cases() []Case {
+	v1 := map[string]string{"version": "v1"}
+	v2 := map[string]string{"version": "v2"}
+	v3 := map[string]string{"version": "v3"}
+	return []Case{
+		{Request{"products.ns1.svc.cluster.local", v1, "/products", "GET", "cluster.local/ns/ns2/sa/alice", "ns2"}, true},
+		{Request{"products.ns1.svc.cluster.local", v2, "/somepath", "POST", "cluster.local/ns/abc/sa/bob", "abc"}, true},
+		{Request{"products.ns1.svc.cluster.local", v2, "/somepath", "POST", "cluster.local/ns/ns2/sa/bob", "ns2"}, false},
+		{Request{"products.ns1.svc.cluster.local", v3, "/somepath", "POST", "cluster.local/ns/ns2/sa/alice", "ns2"}, false},
+		{Request{"products.ns1.svc.cluster.local", nil, "/products", "GET", "cluster.local/ns/ns2/sa/alice", "ns2"}, false},
+		{Request{"bookstore.ns1.svc.cluster.local", v1, "/books", "GET", "cluster.local/ns/acme/sa/svc", "acme"}, true},
+		{Request{"bookstore.ns1.svc.cluster.local", v1, "/books", "POST", "cluster.local/ns/acme/sa/svc", "acme"}, false},
+		{Request{"bookstore.ns1.svc.cluster.local", v1, "/shelf", "GET", "cluster.local/ns/acme/sa/svc", "acme"}, false},
+		{Request{"fishpond.ns1.svc.cluster.local", v1, "/pond/a", "GET", "cluster.local/ns/abcfish/sa/svc", "abcfish"}, true},
+		{Request{"fishpond.ns1.svc.cluster.local", v1, "/lake/a", "GET", "cluster.local/ns/abcfish/sa/svc", "abcfish"}, false},
+		{Request{"fish.ns1.svc.cluster.local", v1, "/pond/review", "HEAD", "cluster.local/ns/mynamespace/sa/alice", "mynamespace"}, true},
+		{Request{"fishpond.ns1.svc.cluster.local", v1, "/pond/review", "GET", "cluster.local/ns/mynamespace/sa/alice", "mynamespace"}, false},
+		{Request{"fish.ns1.svc.cluster.local", v1, "/pond/review", "GET", "cluster.local/ns/mynamespace/sa/bob", "mynamespace"}, false},
+		{Request{"abc.ns1.svc.cluster.local", nil, "/index", "GET", "cluster.local/ns/mynamespace/sa/anyone", "mynamespace"}, true},
+		{Request{"abc.ns1.svc.cluster.local", nil, "/open", "PUT", "cluster.local/ns/mynamespace/sa/anyone", "mynamespace"}, false},
+		{Request{"other.ns1.svc.cluster.local", v1, "/open", "GET", "cluster.local/ns/mynamespace/sa/anyone", "mynamespace"}, false},
+	}
+}
+
+// EmptyNamespaceCases returns requests to services in EmptyNamespace, which
+// are all denied since no role grants access to them.
+func EmptyNamespaceCases() []Case {
+	v1 := map[string]string{"version": "v1"}
+	return []Case{
+		{Request{"products.ns3.svc.cluster.local", v1, "/products", "GET", "cluster.local/ns/ns2/sa/alice", "ns2"}, false},
+		{Request{"abc.ns3.svc.cluster.local", nil, "/index", "GET", "cluster.local/ns/ns3/sa/anyone", "ns3"}, false},
+	}
+}